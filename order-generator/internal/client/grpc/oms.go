@@ -2,12 +2,16 @@ package grpcclient
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 	"time"
 
 	config "github.com/ZaiiiRan/backend_labs/order-generator/internal/config/settings"
 	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -17,9 +21,14 @@ type OmsGrpcClient struct {
 }
 
 func NewOmsGrpcClient(cfg config.GrpcClientSettings) (*OmsGrpcClient, error) {
+	creds, err := transportCredentials(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+
 	conn, err := grpc.Dial(
 		cfg.Address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to grpc server: %w", err)
@@ -32,6 +41,36 @@ func NewOmsGrpcClient(cfg config.GrpcClientSettings) (*OmsGrpcClient, error) {
 	}, nil
 }
 
+func transportCredentials(cfg config.TlsClientSettings) (credentials.TransportCredentials, error) {
+	if !cfg.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+	if cfg.CaFile != "" {
+		pem, err := os.ReadFile(cfg.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("read ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CaFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
+
 func (c *OmsGrpcClient) Close() error {
 	return c.conn.Close()
 }
@@ -1,5 +1,14 @@
 package settings
 
 type GrpcClientSettings struct {
-	Address string `mapstructure:"Address"`
+	Address string            `mapstructure:"Address"`
+	Tls     TlsClientSettings `mapstructure:"Tls"`
+}
+
+type TlsClientSettings struct {
+	Enabled    bool   `mapstructure:"Enabled"`
+	CaFile     string `mapstructure:"CaFile"`
+	CertFile   string `mapstructure:"CertFile"`
+	KeyFile    string `mapstructure:"KeyFile"`
+	ServerName string `mapstructure:"ServerName"`
 }
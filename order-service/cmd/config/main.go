@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config"
+	"go.yaml.in/yaml/v3"
+)
+
+const usage = "usage: config check [web-api|consumer|webhook-dispatcher]"
+
+// config check loads the configuration exactly like the named binary would and prints
+// the effective values with secrets masked.
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "check" {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	target := "web-api"
+	if len(os.Args) > 2 {
+		target = os.Args[2]
+	}
+
+	var (
+		cfg any
+		err error
+	)
+	switch target {
+	case "web-api":
+		cfg, err = config.LoadServerConfig()
+	case "consumer":
+		cfg, err = config.LoadConsumerConfig()
+	case "webhook-dispatcher":
+		cfg, err = config.LoadWebhookDispatcherConfig()
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	out, err := yaml.Marshal(config.Redacted(cfg))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "marshal config: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(string(out))
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/rabbitmq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dlq"
+)
+
+const usage = `usage: dlq <command> [flags]
+
+commands:
+  queues                              list the configured DLQs and their target queues
+  list   -queue Q [filters] [-limit N] show messages with headers and death reasons
+  export -queue Q [filters] -out FILE  write messages as JSON lines ("-" for stdout)
+  replay -queue Q [filters] -ids ID,... | -all [-rate N]
+                                      move messages back to the queue they came from
+  purge  -queue Q [filters] -ids ID,... | -all
+                                      delete messages
+
+filters: -routing-key KEY -from RFC3339 -to RFC3339 -contains TEXT`
+
+// dlq works on the DLQs listed in DlqSettings of the web-api config, through the same
+// broker the web-api publishes to. Messages that are not replayed or purged stay in
+// the DLQ.
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, args []string) error {
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	queue := fs.String("queue", "", "DLQ name")
+	routingKey := fs.String("routing-key", "", "only messages with this routing key or key prefix")
+	from := fs.String("from", "", "only messages dead-lettered at or after this time (RFC3339)")
+	to := fs.String("to", "", "only messages dead-lettered at or before this time (RFC3339)")
+	contains := fs.String("contains", "", "only messages whose body contains this text")
+	limit := fs.Int("limit", 100, "list at most this many messages, 0 for no limit")
+	out := fs.String("out", "-", "export file")
+	ids := fs.String("ids", "", "comma separated message ids")
+	all := fs.Bool("all", false, "act on every message matching the filters")
+	ratePerSecond := fs.Float64("rate", 0, "replayed messages per second, 0 for the configured rate")
+	fs.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	fs.Parse(args)
+
+	cfg, err := config.LoadServerConfig()
+	if err != nil {
+		return err
+	}
+
+	if command == "queues" {
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "DLQ\tQUEUE")
+		for _, q := range cfg.Dlq.Queues {
+			fmt.Fprintf(w, "%s\t%s\n", q.Dlq, q.Queue)
+		}
+		return w.Flush()
+	}
+
+	if *queue == "" {
+		return errors.New("-queue is required")
+	}
+	filter, err := parseFilter(*routingKey, *from, *to, *contains)
+	if err != nil {
+		return err
+	}
+
+	client, err := rabbitmq.NewRabbitMqClient(&cfg.OmsRabbitMqPublisherSettings.RabbitMqSettings)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	manager := dlq.NewManager(client, cfg.Dlq)
+
+	switch command {
+	case "list":
+		msgs, scanned, err := manager.List(ctx, *queue, filter, *limit)
+		if err != nil {
+			return err
+		}
+		printMessages(os.Stdout, msgs)
+		fmt.Fprintf(os.Stderr, "%d matching of %d scanned\n", len(msgs), scanned)
+	case "export":
+		msgs, scanned, err := manager.List(ctx, *queue, filter, 0)
+		if err != nil {
+			return err
+		}
+		if err := export(*out, msgs); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "exported %d of %d scanned\n", len(msgs), scanned)
+	case "replay", "purge":
+		sel := dlq.Selection{Filter: filter, All: *all}
+		if *ids != "" {
+			sel.IDs = strings.Split(*ids, ",")
+		}
+		if len(sel.IDs) == 0 && !sel.All {
+			return errors.New("either -ids or -all is required")
+		}
+		if command == "replay" {
+			n, scanned, _, err := manager.Replay(ctx, *queue, sel, *ratePerSecond, time.Time{})
+			fmt.Fprintf(os.Stderr, "replayed %d of %d scanned\n", n, scanned)
+			return err
+		}
+		n, scanned, err := manager.Purge(ctx, *queue, sel)
+		fmt.Fprintf(os.Stderr, "purged %d of %d scanned\n", n, scanned)
+		return err
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	return nil
+}
+
+func parseFilter(routingKey, from, to, contains string) (dlq.Filter, error) {
+	f := dlq.Filter{RoutingKey: routingKey, BodyContains: contains}
+	var err error
+	if from != "" {
+		if f.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return f, fmt.Errorf("-from: %w", err)
+		}
+	}
+	if to != "" {
+		if f.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return f, fmt.Errorf("-to: %w", err)
+		}
+	}
+	return f, nil
+}
+
+func printMessages(w io.Writer, msgs []dlq.Message) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tDEAD_LETTERED_AT\tROUTING_KEY\tREASON\tDEATHS\tRETRIES\tRETRY_REASON")
+	for _, m := range msgs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			m.ID, m.DeadLetteredAt.Format(time.RFC3339), m.RoutingKey, m.DeathReason, m.DeathCount, m.RetryCount, m.RetryReason)
+	}
+	tw.Flush()
+}
+
+func export(path string, msgs []dlq.Message) error {
+	var w io.Writer = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	for _, m := range msgs {
+		if err := enc.Encode(m); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return nil
+}
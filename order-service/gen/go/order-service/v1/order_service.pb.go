@@ -465,6 +465,7 @@ type LogOrder struct {
 	OrderStatus   string                 `protobuf:"bytes,5,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Actor         string                 `protobuf:"bytes,8,opt,name=actor,proto3" json:"actor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -548,28 +549,2293 @@ func (x *LogOrder) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *LogOrder) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
 type AuditLogOrderBatchCreateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*LogOrder            `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Orders        []*LogOrder            `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLogOrderBatchCreateRequest) Reset() {
+	*x = AuditLogOrderBatchCreateRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogOrderBatchCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogOrderBatchCreateRequest) ProtoMessage() {}
+
+func (x *AuditLogOrderBatchCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogOrderBatchCreateRequest.ProtoReflect.Descriptor instead.
+func (*AuditLogOrderBatchCreateRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AuditLogOrderBatchCreateRequest) GetOrders() []*LogOrder {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type AuditLogOrderBatchCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*LogOrder            `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLogOrderBatchCreateResponse) Reset() {
+	*x = AuditLogOrderBatchCreateResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogOrderBatchCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogOrderBatchCreateResponse) ProtoMessage() {}
+
+func (x *AuditLogOrderBatchCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogOrderBatchCreateResponse.ProtoReflect.Descriptor instead.
+func (*AuditLogOrderBatchCreateResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AuditLogOrderBatchCreateResponse) GetOrders() []*LogOrder {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type UpdateOrdersStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderIds      []int64                `protobuf:"varint,1,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	NewStatus     string                 `protobuf:"bytes,2,opt,name=new_status,json=newStatus,proto3" json:"new_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrdersStatusRequest) Reset() {
+	*x = UpdateOrdersStatusRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrdersStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrdersStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrdersStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrdersStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrdersStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateOrdersStatusRequest) GetOrderIds() []int64 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *UpdateOrdersStatusRequest) GetNewStatus() string {
+	if x != nil {
+		return x.NewStatus
+	}
+	return ""
+}
+
+type UpdateOrdersStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrdersStatusResponse) Reset() {
+	*x = UpdateOrdersStatusResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrdersStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrdersStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOrdersStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrdersStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrdersStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{10}
+}
+
+type WebhookSubscription struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url                 string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes          []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	CustomerIds         []int64                `protobuf:"varint,4,rep,packed,name=customer_ids,json=customerIds,proto3" json:"customer_ids,omitempty"`
+	Secret              string                 `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	IsActive            bool                   `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	ConsecutiveFailures int32                  `protobuf:"varint,7,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	DisabledAt          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=disabled_at,json=disabledAt,proto3" json:"disabled_at,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *WebhookSubscription) Reset() {
+	*x = WebhookSubscription{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSubscription) ProtoMessage() {}
+
+func (x *WebhookSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookSubscription.ProtoReflect.Descriptor instead.
+func (*WebhookSubscription) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WebhookSubscription) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookSubscription) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetCustomerIds() []int64 {
+	if x != nil {
+		return x.CustomerIds
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *WebhookSubscription) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *WebhookSubscription) GetDisabledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisabledAt
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	CustomerIds   []int64                `protobuf:"varint,3,rep,packed,name=customer_ids,json=customerIds,proto3" json:"customer_ids,omitempty"`
+	Secret        string                 `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookSubscriptionRequest) Reset() {
+	*x = CreateWebhookSubscriptionRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetCustomerIds() []int64 {
+	if x != nil {
+		return x.CustomerIds
+	}
+	return nil
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type CreateWebhookSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *WebhookSubscription   `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookSubscriptionResponse) Reset() {
+	*x = CreateWebhookSubscriptionResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *CreateWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateWebhookSubscriptionResponse) GetSubscription() *WebhookSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type QueryWebhookSubscriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	OnlyActive    bool                   `protobuf:"varint,2,opt,name=only_active,json=onlyActive,proto3" json:"only_active,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryWebhookSubscriptionsRequest) Reset() {
+	*x = QueryWebhookSubscriptionsRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryWebhookSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryWebhookSubscriptionsRequest) ProtoMessage() {}
+
+func (x *QueryWebhookSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryWebhookSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*QueryWebhookSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QueryWebhookSubscriptionsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *QueryWebhookSubscriptionsRequest) GetOnlyActive() bool {
+	if x != nil {
+		return x.OnlyActive
+	}
+	return false
+}
+
+func (x *QueryWebhookSubscriptionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *QueryWebhookSubscriptionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type QueryWebhookSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*WebhookSubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryWebhookSubscriptionsResponse) Reset() {
+	*x = QueryWebhookSubscriptionsResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryWebhookSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryWebhookSubscriptionsResponse) ProtoMessage() {}
+
+func (x *QueryWebhookSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryWebhookSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*QueryWebhookSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *QueryWebhookSubscriptionsResponse) GetSubscriptions() []*WebhookSubscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+type UpdateWebhookSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	CustomerIds   []int64                `protobuf:"varint,4,rep,packed,name=customer_ids,json=customerIds,proto3" json:"customer_ids,omitempty"`
+	IsActive      bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	RotateSecret  bool                   `protobuf:"varint,6,opt,name=rotate_secret,json=rotateSecret,proto3" json:"rotate_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookSubscriptionRequest) Reset() {
+	*x = UpdateWebhookSubscriptionRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *UpdateWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebhookSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetCustomerIds() []int64 {
+	if x != nil {
+		return x.CustomerIds
+	}
+	return nil
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetRotateSecret() bool {
+	if x != nil {
+		return x.RotateSecret
+	}
+	return false
+}
+
+type UpdateWebhookSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *WebhookSubscription   `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookSubscriptionResponse) Reset() {
+	*x = UpdateWebhookSubscriptionResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *UpdateWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebhookSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateWebhookSubscriptionResponse) GetSubscription() *WebhookSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type DeleteWebhookSubscriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookSubscriptionsRequest) Reset() {
+	*x = DeleteWebhookSubscriptionsRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookSubscriptionsRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteWebhookSubscriptionsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type DeleteWebhookSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookSubscriptionsResponse) Reset() {
+	*x = DeleteWebhookSubscriptionsResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookSubscriptionsResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{19}
+}
+
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId int64                  `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	EventType      string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	EventId        string                 `protobuf:"bytes,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Attempt        int32                  `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	StatusCode     int32                  `protobuf:"varint,6,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Success        bool                   `protobuf:"varint,7,opt,name=success,proto3" json:"success,omitempty"`
+	Error          string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs     int64                  `protobuf:"varint,9,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WebhookDelivery) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetSubscriptionId() int64 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WebhookDelivery) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type QueryWebhookDeliveriesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionIds []int64                `protobuf:"varint,1,rep,packed,name=subscription_ids,json=subscriptionIds,proto3" json:"subscription_ids,omitempty"`
+	OnlyFailed      bool                   `protobuf:"varint,2,opt,name=only_failed,json=onlyFailed,proto3" json:"only_failed,omitempty"`
+	Page            int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize        int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *QueryWebhookDeliveriesRequest) Reset() {
+	*x = QueryWebhookDeliveriesRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *QueryWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*QueryWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *QueryWebhookDeliveriesRequest) GetSubscriptionIds() []int64 {
+	if x != nil {
+		return x.SubscriptionIds
+	}
+	return nil
+}
+
+func (x *QueryWebhookDeliveriesRequest) GetOnlyFailed() bool {
+	if x != nil {
+		return x.OnlyFailed
+	}
+	return false
+}
+
+func (x *QueryWebhookDeliveriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *QueryWebhookDeliveriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type QueryWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryWebhookDeliveriesResponse) Reset() {
+	*x = QueryWebhookDeliveriesResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *QueryWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*QueryWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *QueryWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type Stock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   int64                  `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved      int32                  `protobuf:"varint,4,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Available     int32                  `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stock) Reset() {
+	*x = Stock{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stock) ProtoMessage() {}
+
+func (x *Stock) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
+func (*Stock) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Stock) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *Stock) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *Stock) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Stock) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *Stock) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *Stock) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Stock) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type StockAdjustment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   int64                  `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Delta         int32                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockAdjustment) Reset() {
+	*x = StockAdjustment{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAdjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAdjustment) ProtoMessage() {}
+
+func (x *StockAdjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAdjustment.ProtoReflect.Descriptor instead.
+func (*StockAdjustment) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StockAdjustment) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type AdjustStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Adjustments   []*StockAdjustment     `protobuf:"bytes,1,rep,name=adjustments,proto3" json:"adjustments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AdjustStockRequest) GetAdjustments() []*StockAdjustment {
+	if x != nil {
+		return x.Adjustments
+	}
+	return nil
+}
+
+type AdjustStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocks        []*Stock               `protobuf:"bytes,1,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockResponse) ProtoMessage() {}
+
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AdjustStockResponse) GetStocks() []*Stock {
+	if x != nil {
+		return x.Stocks
+	}
+	return nil
+}
+
+type QueryStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []int64                `protobuf:"varint,1,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	WarehouseIds  []int64                `protobuf:"varint,2,rep,packed,name=warehouse_ids,json=warehouseIds,proto3" json:"warehouse_ids,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryStockRequest) Reset() {
+	*x = QueryStockRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryStockRequest) ProtoMessage() {}
+
+func (x *QueryStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryStockRequest.ProtoReflect.Descriptor instead.
+func (*QueryStockRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QueryStockRequest) GetProductIds() []int64 {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *QueryStockRequest) GetWarehouseIds() []int64 {
+	if x != nil {
+		return x.WarehouseIds
+	}
+	return nil
+}
+
+func (x *QueryStockRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *QueryStockRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type QueryStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocks        []*Stock               `protobuf:"bytes,1,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryStockResponse) Reset() {
+	*x = QueryStockResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryStockResponse) ProtoMessage() {}
+
+func (x *QueryStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryStockResponse.ProtoReflect.Descriptor instead.
+func (*QueryStockResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *QueryStockResponse) GetStocks() []*Stock {
+	if x != nil {
+		return x.Stocks
+	}
+	return nil
+}
+
+type Product struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	PriceCents    int64                  `protobuf:"varint,4,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	PriceCurrency string                 `protobuf:"bytes,5,opt,name=price_currency,json=priceCurrency,proto3" json:"price_currency,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Product) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Product) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Product) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Product) GetPriceCents() int64 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+func (x *Product) GetPriceCurrency() string {
+	if x != nil {
+		return x.PriceCurrency
+	}
+	return ""
+}
+
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type UpsertProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertProductsRequest) Reset() {
+	*x = UpsertProductsRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertProductsRequest) ProtoMessage() {}
+
+func (x *UpsertProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertProductsRequest.ProtoReflect.Descriptor instead.
+func (*UpsertProductsRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpsertProductsRequest) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type UpsertProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertProductsResponse) Reset() {
+	*x = UpsertProductsResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertProductsResponse) ProtoMessage() {}
+
+func (x *UpsertProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertProductsResponse.ProtoReflect.Descriptor instead.
+func (*UpsertProductsResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpsertProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type QueryProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryProductsRequest) Reset() {
+	*x = QueryProductsRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryProductsRequest) ProtoMessage() {}
+
+func (x *QueryProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryProductsRequest.ProtoReflect.Descriptor instead.
+func (*QueryProductsRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *QueryProductsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *QueryProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *QueryProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type QueryProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryProductsResponse) Reset() {
+	*x = QueryProductsResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryProductsResponse) ProtoMessage() {}
+
+func (x *QueryProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryProductsResponse.ProtoReflect.Descriptor instead.
+func (*QueryProductsResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *QueryProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type DeleteProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductsRequest) Reset() {
+	*x = DeleteProductsRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductsRequest) ProtoMessage() {}
+
+func (x *DeleteProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductsRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteProductsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type DeleteProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductsResponse) Reset() {
+	*x = DeleteProductsResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductsResponse) ProtoMessage() {}
+
+func (x *DeleteProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductsResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{35}
+}
+
+type Payment struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId           int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Provider          string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	ProviderPaymentId string                 `protobuf:"bytes,4,opt,name=provider_payment_id,json=providerPaymentId,proto3" json:"provider_payment_id,omitempty"`
+	AmountCents       int64                  `protobuf:"varint,5,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	Currency          string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	FailureReason     string                 `protobuf:"bytes,8,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Payment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Payment) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Payment) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Payment) GetProviderPaymentId() string {
+	if x != nil {
+		return x.ProviderPaymentId
+	}
+	return ""
+}
+
+func (x *Payment) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *Payment) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Payment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Payment) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Payment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type StartPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartPaymentRequest) Reset() {
+	*x = StartPaymentRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPaymentRequest) ProtoMessage() {}
+
+func (x *StartPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPaymentRequest.ProtoReflect.Descriptor instead.
+func (*StartPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StartPaymentRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type StartPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartPaymentResponse) Reset() {
+	*x = StartPaymentResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPaymentResponse) ProtoMessage() {}
+
+func (x *StartPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPaymentResponse.ProtoReflect.Descriptor instead.
+func (*StartPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StartPaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type QueryPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	OrderIds      []int64                `protobuf:"varint,2,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPaymentsRequest) Reset() {
+	*x = QueryPaymentsRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPaymentsRequest) ProtoMessage() {}
+
+func (x *QueryPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*QueryPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *QueryPaymentsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *QueryPaymentsRequest) GetOrderIds() []int64 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *QueryPaymentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *QueryPaymentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type QueryPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPaymentsResponse) Reset() {
+	*x = QueryPaymentsResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPaymentsResponse) ProtoMessage() {}
+
+func (x *QueryPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*QueryPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *QueryPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+type HandlePaymentCallbackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature     string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HandlePaymentCallbackRequest) Reset() {
+	*x = HandlePaymentCallbackRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandlePaymentCallbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandlePaymentCallbackRequest) ProtoMessage() {}
+
+func (x *HandlePaymentCallbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandlePaymentCallbackRequest.ProtoReflect.Descriptor instead.
+func (*HandlePaymentCallbackRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *HandlePaymentCallbackRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *HandlePaymentCallbackRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type HandlePaymentCallbackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HandlePaymentCallbackResponse) Reset() {
+	*x = HandlePaymentCallbackResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandlePaymentCallbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandlePaymentCallbackResponse) ProtoMessage() {}
+
+func (x *HandlePaymentCallbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandlePaymentCallbackResponse.ProtoReflect.Descriptor instead.
+func (*HandlePaymentCallbackResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{42}
+}
+
+type DlqMessage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoutingKey     string                 `protobuf:"bytes,2,opt,name=routing_key,json=routingKey,proto3" json:"routing_key,omitempty"`
+	Headers        map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Body           string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	DeathReason    string                 `protobuf:"bytes,5,opt,name=death_reason,json=deathReason,proto3" json:"death_reason,omitempty"`
+	DeathQueue     string                 `protobuf:"bytes,6,opt,name=death_queue,json=deathQueue,proto3" json:"death_queue,omitempty"`
+	DeathCount     int32                  `protobuf:"varint,7,opt,name=death_count,json=deathCount,proto3" json:"death_count,omitempty"`
+	DeadLetteredAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=dead_lettered_at,json=deadLetteredAt,proto3" json:"dead_lettered_at,omitempty"`
+	RetryCount     int32                  `protobuf:"varint,9,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	RetryReason    string                 `protobuf:"bytes,10,opt,name=retry_reason,json=retryReason,proto3" json:"retry_reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DlqMessage) Reset() {
+	*x = DlqMessage{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DlqMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DlqMessage) ProtoMessage() {}
+
+func (x *DlqMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DlqMessage.ProtoReflect.Descriptor instead.
+func (*DlqMessage) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DlqMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DlqMessage) GetRoutingKey() string {
+	if x != nil {
+		return x.RoutingKey
+	}
+	return ""
+}
+
+func (x *DlqMessage) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *DlqMessage) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *DlqMessage) GetDeathReason() string {
+	if x != nil {
+		return x.DeathReason
+	}
+	return ""
+}
+
+func (x *DlqMessage) GetDeathQueue() string {
+	if x != nil {
+		return x.DeathQueue
+	}
+	return ""
+}
+
+func (x *DlqMessage) GetDeathCount() int32 {
+	if x != nil {
+		return x.DeathCount
+	}
+	return 0
+}
+
+func (x *DlqMessage) GetDeadLetteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeadLetteredAt
+	}
+	return nil
+}
+
+func (x *DlqMessage) GetRetryCount() int32 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *DlqMessage) GetRetryReason() string {
+	if x != nil {
+		return x.RetryReason
+	}
+	return ""
+}
+
+type DlqMessageFilter struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RoutingKey       string                 `protobuf:"bytes,1,opt,name=routing_key,json=routingKey,proto3" json:"routing_key,omitempty"`
+	DeadLetteredFrom *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=dead_lettered_from,json=deadLetteredFrom,proto3" json:"dead_lettered_from,omitempty"`
+	DeadLetteredTo   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=dead_lettered_to,json=deadLetteredTo,proto3" json:"dead_lettered_to,omitempty"`
+	BodyContains     string                 `protobuf:"bytes,4,opt,name=body_contains,json=bodyContains,proto3" json:"body_contains,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DlqMessageFilter) Reset() {
+	*x = DlqMessageFilter{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DlqMessageFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DlqMessageFilter) ProtoMessage() {}
+
+func (x *DlqMessageFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DlqMessageFilter.ProtoReflect.Descriptor instead.
+func (*DlqMessageFilter) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DlqMessageFilter) GetRoutingKey() string {
+	if x != nil {
+		return x.RoutingKey
+	}
+	return ""
+}
+
+func (x *DlqMessageFilter) GetDeadLetteredFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeadLetteredFrom
+	}
+	return nil
+}
+
+func (x *DlqMessageFilter) GetDeadLetteredTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeadLetteredTo
+	}
+	return nil
+}
+
+func (x *DlqMessageFilter) GetBodyContains() string {
+	if x != nil {
+		return x.BodyContains
+	}
+	return ""
+}
+
+type ListDlqMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queue         string                 `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	Filter        *DlqMessageFilter      `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AuditLogOrderBatchCreateRequest) Reset() {
-	*x = AuditLogOrderBatchCreateRequest{}
-	mi := &file_order_service_v1_order_service_proto_msgTypes[7]
+func (x *ListDlqMessagesRequest) Reset() {
+	*x = ListDlqMessagesRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AuditLogOrderBatchCreateRequest) String() string {
+func (x *ListDlqMessagesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AuditLogOrderBatchCreateRequest) ProtoMessage() {}
+func (*ListDlqMessagesRequest) ProtoMessage() {}
 
-func (x *AuditLogOrderBatchCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_service_v1_order_service_proto_msgTypes[7]
+func (x *ListDlqMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,40 +2846,55 @@ func (x *AuditLogOrderBatchCreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AuditLogOrderBatchCreateRequest.ProtoReflect.Descriptor instead.
-func (*AuditLogOrderBatchCreateRequest) Descriptor() ([]byte, []int) {
-	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListDlqMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListDlqMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *AuditLogOrderBatchCreateRequest) GetOrders() []*LogOrder {
+func (x *ListDlqMessagesRequest) GetQueue() string {
 	if x != nil {
-		return x.Orders
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *ListDlqMessagesRequest) GetFilter() *DlqMessageFilter {
+	if x != nil {
+		return x.Filter
 	}
 	return nil
 }
 
-type AuditLogOrderBatchCreateResponse struct {
+func (x *ListDlqMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDlqMessagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*LogOrder            `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Messages      []*DlqMessage          `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Scanned       int32                  `protobuf:"varint,2,opt,name=scanned,proto3" json:"scanned,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AuditLogOrderBatchCreateResponse) Reset() {
-	*x = AuditLogOrderBatchCreateResponse{}
-	mi := &file_order_service_v1_order_service_proto_msgTypes[8]
+func (x *ListDlqMessagesResponse) Reset() {
+	*x = ListDlqMessagesResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AuditLogOrderBatchCreateResponse) String() string {
+func (x *ListDlqMessagesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AuditLogOrderBatchCreateResponse) ProtoMessage() {}
+func (*ListDlqMessagesResponse) ProtoMessage() {}
 
-func (x *AuditLogOrderBatchCreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_service_v1_order_service_proto_msgTypes[8]
+func (x *ListDlqMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -624,41 +2905,51 @@ func (x *AuditLogOrderBatchCreateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AuditLogOrderBatchCreateResponse.ProtoReflect.Descriptor instead.
-func (*AuditLogOrderBatchCreateResponse) Descriptor() ([]byte, []int) {
-	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListDlqMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListDlqMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *AuditLogOrderBatchCreateResponse) GetOrders() []*LogOrder {
+func (x *ListDlqMessagesResponse) GetMessages() []*DlqMessage {
 	if x != nil {
-		return x.Orders
+		return x.Messages
 	}
 	return nil
 }
 
-type UpdateOrdersStatusRequest struct {
+func (x *ListDlqMessagesResponse) GetScanned() int32 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
+}
+
+type ReplayDlqMessagesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderIds      []int64                `protobuf:"varint,1,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
-	NewStatus     string                 `protobuf:"bytes,2,opt,name=new_status,json=newStatus,proto3" json:"new_status,omitempty"`
+	Queue         string                 `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	Filter        *DlqMessageFilter      `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Ids           []string               `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+	All           bool                   `protobuf:"varint,4,opt,name=all,proto3" json:"all,omitempty"`
+	RatePerSecond float64                `protobuf:"fixed64,5,opt,name=rate_per_second,json=ratePerSecond,proto3" json:"rate_per_second,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrdersStatusRequest) Reset() {
-	*x = UpdateOrdersStatusRequest{}
-	mi := &file_order_service_v1_order_service_proto_msgTypes[9]
+func (x *ReplayDlqMessagesRequest) Reset() {
+	*x = ReplayDlqMessagesRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrdersStatusRequest) String() string {
+func (x *ReplayDlqMessagesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrdersStatusRequest) ProtoMessage() {}
+func (*ReplayDlqMessagesRequest) ProtoMessage() {}
 
-func (x *UpdateOrdersStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_service_v1_order_service_proto_msgTypes[9]
+func (x *ReplayDlqMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,46 +2960,198 @@ func (x *UpdateOrdersStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrdersStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrdersStatusRequest) Descriptor() ([]byte, []int) {
-	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ReplayDlqMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ReplayDlqMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *UpdateOrdersStatusRequest) GetOrderIds() []int64 {
+func (x *ReplayDlqMessagesRequest) GetQueue() string {
 	if x != nil {
-		return x.OrderIds
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *ReplayDlqMessagesRequest) GetFilter() *DlqMessageFilter {
+	if x != nil {
+		return x.Filter
 	}
 	return nil
 }
 
-func (x *UpdateOrdersStatusRequest) GetNewStatus() string {
+func (x *ReplayDlqMessagesRequest) GetIds() []string {
 	if x != nil {
-		return x.NewStatus
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ReplayDlqMessagesRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *ReplayDlqMessagesRequest) GetRatePerSecond() float64 {
+	if x != nil {
+		return x.RatePerSecond
+	}
+	return 0
+}
+
+type ReplayDlqMessagesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Replayed int32                  `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	Scanned  int32                  `protobuf:"varint,2,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	// Set when the call ran out of time before the scan finished; call again to continue.
+	HasMore       bool `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayDlqMessagesResponse) Reset() {
+	*x = ReplayDlqMessagesResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayDlqMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDlqMessagesResponse) ProtoMessage() {}
+
+func (x *ReplayDlqMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDlqMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ReplayDlqMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ReplayDlqMessagesResponse) GetReplayed() int32 {
+	if x != nil {
+		return x.Replayed
+	}
+	return 0
+}
+
+func (x *ReplayDlqMessagesResponse) GetScanned() int32 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
+}
+
+func (x *ReplayDlqMessagesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type PurgeDlqMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queue         string                 `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	Filter        *DlqMessageFilter      `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Ids           []string               `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+	All           bool                   `protobuf:"varint,4,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDlqMessagesRequest) Reset() {
+	*x = PurgeDlqMessagesRequest{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDlqMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDlqMessagesRequest) ProtoMessage() {}
+
+func (x *PurgeDlqMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDlqMessagesRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDlqMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PurgeDlqMessagesRequest) GetQueue() string {
+	if x != nil {
+		return x.Queue
 	}
 	return ""
 }
 
-type UpdateOrdersStatusResponse struct {
+func (x *PurgeDlqMessagesRequest) GetFilter() *DlqMessageFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *PurgeDlqMessagesRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *PurgeDlqMessagesRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type PurgeDlqMessagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int32                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	Scanned       int32                  `protobuf:"varint,2,opt,name=scanned,proto3" json:"scanned,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrdersStatusResponse) Reset() {
-	*x = UpdateOrdersStatusResponse{}
-	mi := &file_order_service_v1_order_service_proto_msgTypes[10]
+func (x *PurgeDlqMessagesResponse) Reset() {
+	*x = PurgeDlqMessagesResponse{}
+	mi := &file_order_service_v1_order_service_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrdersStatusResponse) String() string {
+func (x *PurgeDlqMessagesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrdersStatusResponse) ProtoMessage() {}
+func (*PurgeDlqMessagesResponse) ProtoMessage() {}
 
-func (x *UpdateOrdersStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_service_v1_order_service_proto_msgTypes[10]
+func (x *PurgeDlqMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,9 +3162,23 @@ func (x *UpdateOrdersStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrdersStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateOrdersStatusResponse) Descriptor() ([]byte, []int) {
-	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use PurgeDlqMessagesResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDlqMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PurgeDlqMessagesResponse) GetPurged() int32 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+func (x *PurgeDlqMessagesResponse) GetScanned() int32 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
 }
 
 var File_order_service_v1_order_service_proto protoreflect.FileDescriptor
@@ -771,7 +3228,7 @@ const file_order_service_v1_order_service_proto_rawDesc = "" +
 	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12.\n" +
 	"\x13include_order_items\x18\x05 \x01(\bR\x11includeOrderItems\"F\n" +
 	"\x13QueryOrdersResponse\x12/\n" +
-	"\x06orders\x18\x01 \x03(\v2\x17.order_service.v1.OrderR\x06orders\"\xd7\x02\n" +
+	"\x06orders\x18\x01 \x03(\v2\x17.order_service.v1.OrderR\x06orders\"\xed\x02\n" +
 	"\bLogOrder\x12\x1f\n" +
 	"\x02id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x02id\x12*\n" +
 	"\border_id\x18\x02 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\aorderId\x123\n" +
@@ -782,7 +3239,8 @@ const file_order_service_v1_order_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"U\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05actor\x18\b \x01(\tR\x05actor\"U\n" +
 	"\x1fAuditLogOrderBatchCreateRequest\x122\n" +
 	"\x06orders\x18\x01 \x03(\v2\x1a.order_service.v1.LogOrderR\x06orders\"V\n" +
 	" AuditLogOrderBatchCreateResponse\x122\n" +
@@ -791,7 +3249,210 @@ const file_order_service_v1_order_service_proto_rawDesc = "" +
 	"\torder_ids\x18\x01 \x03(\x03R\borderIds\x12\x1d\n" +
 	"\n" +
 	"new_status\x18\x02 \x01(\tR\tnewStatus\"\x1c\n" +
-	"\x1aUpdateOrdersStatusResponse2\xfd\x06\n" +
+	"\x1aUpdateOrdersStatusResponse\"\xa7\x03\n" +
+	"\x13WebhookSubscription\x12\x1f\n" +
+	"\x02id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12!\n" +
+	"\fcustomer_ids\x18\x04 \x03(\x03R\vcustomerIds\x12\x16\n" +
+	"\x06secret\x18\x05 \x01(\tR\x06secret\x12\x1b\n" +
+	"\tis_active\x18\x06 \x01(\bR\bisActive\x121\n" +
+	"\x14consecutive_failures\x18\a \x01(\x05R\x13consecutiveFailures\x12;\n" +
+	"\vdisabled_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"disabledAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x90\x01\n" +
+	" CreateWebhookSubscriptionRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x02 \x03(\tR\n" +
+	"eventTypes\x12!\n" +
+	"\fcustomer_ids\x18\x03 \x03(\x03R\vcustomerIds\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\"n\n" +
+	"!CreateWebhookSubscriptionResponse\x12I\n" +
+	"\fsubscription\x18\x01 \x01(\v2%.order_service.v1.WebhookSubscriptionR\fsubscription\"\x86\x01\n" +
+	" QueryWebhookSubscriptionsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x1f\n" +
+	"\vonly_active\x18\x02 \x01(\bR\n" +
+	"onlyActive\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"p\n" +
+	"!QueryWebhookSubscriptionsResponse\x12K\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2%.order_service.v1.WebhookSubscriptionR\rsubscriptions\"\xdb\x01\n" +
+	" UpdateWebhookSubscriptionRequest\x12\x1f\n" +
+	"\x02id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12!\n" +
+	"\fcustomer_ids\x18\x04 \x03(\x03R\vcustomerIds\x12\x1b\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\x12#\n" +
+	"\rrotate_secret\x18\x06 \x01(\bR\frotateSecret\"n\n" +
+	"!UpdateWebhookSubscriptionResponse\x12I\n" +
+	"\fsubscription\x18\x01 \x01(\v2%.order_service.v1.WebhookSubscriptionR\fsubscription\"5\n" +
+	"!DeleteWebhookSubscriptionsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"$\n" +
+	"\"DeleteWebhookSubscriptionsResponse\"\xed\x02\n" +
+	"\x0fWebhookDelivery\x12\x1f\n" +
+	"\x02id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x02id\x128\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x0esubscriptionId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x19\n" +
+	"\bevent_id\x18\x04 \x01(\tR\aeventId\x12\x18\n" +
+	"\aattempt\x18\x05 \x01(\x05R\aattempt\x12\x1f\n" +
+	"\vstatus_code\x18\x06 \x01(\x05R\n" +
+	"statusCode\x12\x18\n" +
+	"\asuccess\x18\a \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\t \x01(\x03R\n" +
+	"durationMs\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x9c\x01\n" +
+	"\x1dQueryWebhookDeliveriesRequest\x12)\n" +
+	"\x10subscription_ids\x18\x01 \x03(\x03R\x0fsubscriptionIds\x12\x1f\n" +
+	"\vonly_failed\x18\x02 \x01(\bR\n" +
+	"onlyFailed\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"c\n" +
+	"\x1eQueryWebhookDeliveriesResponse\x12A\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2!.order_service.v1.WebhookDeliveryR\n" +
+	"deliveries\"\xb7\x02\n" +
+	"\x05Stock\x122\n" +
+	"\fwarehouse_id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\vwarehouseId\x12.\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x04 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\x05R\tavailable\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x8b\x01\n" +
+	"\x0fStockAdjustment\x122\n" +
+	"\fwarehouse_id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\vwarehouseId\x12.\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\tproductId\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x05R\x05delta\"Y\n" +
+	"\x12AdjustStockRequest\x12C\n" +
+	"\vadjustments\x18\x01 \x03(\v2!.order_service.v1.StockAdjustmentR\vadjustments\"F\n" +
+	"\x13AdjustStockResponse\x12/\n" +
+	"\x06stocks\x18\x01 \x03(\v2\x17.order_service.v1.StockR\x06stocks\"\x8a\x01\n" +
+	"\x11QueryStockRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\x03R\n" +
+	"productIds\x12#\n" +
+	"\rwarehouse_ids\x18\x02 \x03(\x03R\fwarehouseIds\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"E\n" +
+	"\x12QueryStockResponse\x12/\n" +
+	"\x06stocks\x18\x01 \x03(\v2\x17.order_service.v1.StockR\x06stocks\"\xa1\x02\n" +
+	"\aProduct\x12\x1f\n" +
+	"\x02id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x120\n" +
+	"\vprice_cents\x18\x04 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\n" +
+	"priceCents\x12%\n" +
+	"\x0eprice_currency\x18\x05 \x01(\tR\rpriceCurrency\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"N\n" +
+	"\x15UpsertProductsRequest\x125\n" +
+	"\bproducts\x18\x01 \x03(\v2\x19.order_service.v1.ProductR\bproducts\"O\n" +
+	"\x16UpsertProductsResponse\x125\n" +
+	"\bproducts\x18\x01 \x03(\v2\x19.order_service.v1.ProductR\bproducts\"Y\n" +
+	"\x14QueryProductsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"N\n" +
+	"\x15QueryProductsResponse\x125\n" +
+	"\bproducts\x18\x01 \x03(\v2\x19.order_service.v1.ProductR\bproducts\")\n" +
+	"\x15DeleteProductsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"\x18\n" +
+	"\x16DeleteProductsResponse\"\xa7\x03\n" +
+	"\aPayment\x12\x1f\n" +
+	"\x02id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\x02id\x12*\n" +
+	"\border_id\x18\x02 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\aorderId\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12.\n" +
+	"\x13provider_payment_id\x18\x04 \x01(\tR\x11providerPaymentId\x122\n" +
+	"\famount_cents\x18\x05 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\vamountCents\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12%\n" +
+	"\x0efailure_reason\x18\b \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"A\n" +
+	"\x13StartPaymentRequest\x12*\n" +
+	"\border_id\x18\x01 \x01(\x03B\x0f\x92A\f\x9a\x02\x01\x03\xa2\x02\x05int64R\aorderId\"K\n" +
+	"\x14StartPaymentResponse\x123\n" +
+	"\apayment\x18\x01 \x01(\v2\x19.order_service.v1.PaymentR\apayment\"v\n" +
+	"\x14QueryPaymentsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x1b\n" +
+	"\torder_ids\x18\x02 \x03(\x03R\borderIds\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"N\n" +
+	"\x15QueryPaymentsResponse\x125\n" +
+	"\bpayments\x18\x01 \x03(\v2\x19.order_service.v1.PaymentR\bpayments\"V\n" +
+	"\x1cHandlePaymentCallbackRequest\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\fR\apayload\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignature\"\x1f\n" +
+	"\x1dHandlePaymentCallbackResponse\"\xc1\x03\n" +
+	"\n" +
+	"DlqMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vrouting_key\x18\x02 \x01(\tR\n" +
+	"routingKey\x12C\n" +
+	"\aheaders\x18\x03 \x03(\v2).order_service.v1.DlqMessage.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12!\n" +
+	"\fdeath_reason\x18\x05 \x01(\tR\vdeathReason\x12\x1f\n" +
+	"\vdeath_queue\x18\x06 \x01(\tR\n" +
+	"deathQueue\x12\x1f\n" +
+	"\vdeath_count\x18\a \x01(\x05R\n" +
+	"deathCount\x12D\n" +
+	"\x10dead_lettered_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0edeadLetteredAt\x12\x1f\n" +
+	"\vretry_count\x18\t \x01(\x05R\n" +
+	"retryCount\x12!\n" +
+	"\fretry_reason\x18\n" +
+	" \x01(\tR\vretryReason\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe8\x01\n" +
+	"\x10DlqMessageFilter\x12\x1f\n" +
+	"\vrouting_key\x18\x01 \x01(\tR\n" +
+	"routingKey\x12H\n" +
+	"\x12dead_lettered_from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x10deadLetteredFrom\x12D\n" +
+	"\x10dead_lettered_to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0edeadLetteredTo\x12#\n" +
+	"\rbody_contains\x18\x04 \x01(\tR\fbodyContains\"\x80\x01\n" +
+	"\x16ListDlqMessagesRequest\x12\x14\n" +
+	"\x05queue\x18\x01 \x01(\tR\x05queue\x12:\n" +
+	"\x06filter\x18\x02 \x01(\v2\".order_service.v1.DlqMessageFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"m\n" +
+	"\x17ListDlqMessagesResponse\x128\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1c.order_service.v1.DlqMessageR\bmessages\x12\x18\n" +
+	"\ascanned\x18\x02 \x01(\x05R\ascanned\"\xb8\x01\n" +
+	"\x18ReplayDlqMessagesRequest\x12\x14\n" +
+	"\x05queue\x18\x01 \x01(\tR\x05queue\x12:\n" +
+	"\x06filter\x18\x02 \x01(\v2\".order_service.v1.DlqMessageFilterR\x06filter\x12\x10\n" +
+	"\x03ids\x18\x03 \x03(\tR\x03ids\x12\x10\n" +
+	"\x03all\x18\x04 \x01(\bR\x03all\x12&\n" +
+	"\x0frate_per_second\x18\x05 \x01(\x01R\rratePerSecond\"l\n" +
+	"\x19ReplayDlqMessagesResponse\x12\x1a\n" +
+	"\breplayed\x18\x01 \x01(\x05R\breplayed\x12\x18\n" +
+	"\ascanned\x18\x02 \x01(\x05R\ascanned\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"\x8f\x01\n" +
+	"\x17PurgeDlqMessagesRequest\x12\x14\n" +
+	"\x05queue\x18\x01 \x01(\tR\x05queue\x12:\n" +
+	"\x06filter\x18\x02 \x01(\v2\".order_service.v1.DlqMessageFilterR\x06filter\x12\x10\n" +
+	"\x03ids\x18\x03 \x03(\tR\x03ids\x12\x10\n" +
+	"\x03all\x18\x04 \x01(\bR\x03all\"L\n" +
+	"\x18PurgeDlqMessagesResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x05R\x06purged\x12\x18\n" +
+	"\ascanned\x18\x02 \x01(\x05R\ascanned2\xfd\x06\n" +
 	"\fOrderService\x12\xc2\x01\n" +
 	"\vBatchCreate\x12$.order_service.v1.BatchCreateRequest\x1a%.order_service.v1.BatchCreateResponse\"f\x92A>\n" +
 	"\x06orders\x12\x13Create orders batch\x1a\x1fCreates orders with order items\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/order/batch-create\x12\xbd\x01\n" +
@@ -801,7 +3462,45 @@ const file_order_service_v1_order_service_proto_rawDesc = "" +
 	"\n" +
 	"audit-logs\x12\"Create audit logs for orders batch\x1a\x1dCreates audit logs for orders\x82\xd3\xe4\x93\x02):\x01*\"$/api/v1/audit-log/order/batch-create\x12\xdf\x01\n" +
 	"\x12UpdateOrdersStatus\x12+.order_service.v1.UpdateOrdersStatusRequest\x1a,.order_service.v1.UpdateOrdersStatusResponse\"n\x92AE\n" +
-	"\x06orders\x12\x14Update orders status\x1a%Updates the status of multiple orders\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/order/update-statusB\xbe\x01\x92Ak\x121\n" +
+	"\x06orders\x12\x14Update orders status\x1a%Updates the status of multiple orders\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/order/update-status2\xaf\v\n" +
+	"\x0eWebhookService\x12\x9e\x02\n" +
+	"\x19CreateWebhookSubscription\x122.order_service.v1.CreateWebhookSubscriptionRequest\x1a3.order_service.v1.CreateWebhookSubscriptionResponse\"\x97\x01\x92Af\n" +
+	"\bwebhooks\x12\x1bCreate webhook subscription\x1a=Creates a webhook subscription and returns its signing secret\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/webhook/subscription/create\x12\x8d\x02\n" +
+	"\x19QueryWebhookSubscriptions\x122.order_service.v1.QueryWebhookSubscriptionsRequest\x1a3.order_service.v1.QueryWebhookSubscriptionsResponse\"\x86\x01\x92AV\n" +
+	"\bwebhooks\x12\x1bQuery webhook subscriptions\x1a-Returns webhook subscriptions without secrets\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/webhook/subscription/query\x12\xc8\x02\n" +
+	"\x19UpdateWebhookSubscription\x122.order_service.v1.UpdateWebhookSubscriptionRequest\x1a3.order_service.v1.UpdateWebhookSubscriptionResponse\"\xc1\x01\x92A\x8f\x01\n" +
+	"\bwebhooks\x12\x1bUpdate webhook subscription\x1afReplaces url, event types and customer filter, enables/disables the subscription or rotates its secret\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/webhook/subscription/update\x12\x9a\x02\n" +
+	"\x1aDeleteWebhookSubscriptions\x123.order_service.v1.DeleteWebhookSubscriptionsRequest\x1a4.order_service.v1.DeleteWebhookSubscriptionsResponse\"\x90\x01\x92A_\n" +
+	"\bwebhooks\x12\x1cDelete webhook subscriptions\x1a5Deletes webhook subscriptions with their delivery log\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/webhook/subscription/delete\x12\x83\x02\n" +
+	"\x16QueryWebhookDeliveries\x12/.order_service.v1.QueryWebhookDeliveriesRequest\x1a0.order_service.v1.QueryWebhookDeliveriesResponse\"\x85\x01\x92AY\n" +
+	"\bwebhooks\x12\x18Query webhook deliveries\x1a3Returns the delivery log with one entry per attempt\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/webhook/delivery/query2\xd6\x03\n" +
+	"\x10InventoryService\x12\xe7\x01\n" +
+	"\vAdjustStock\x12$.order_service.v1.AdjustStockRequest\x1a%.order_service.v1.AdjustStockResponse\"\x8a\x01\x92A^\n" +
+	"\tinventory\x12\x13Adjust stock levels\x1a<Adds or removes on-hand quantities per product and warehouse\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/inventory/stock/adjust\x12\xd7\x01\n" +
+	"\n" +
+	"QueryStock\x12#.order_service.v1.QueryStockRequest\x1a$.order_service.v1.QueryStockResponse\"~\x92AS\n" +
+	"\tinventory\x12\x12Query stock levels\x1a2Returns on-hand, reserved and available quantities\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/inventory/stock/query2\xe5\x04\n" +
+	"\x0eProductService\x12\xce\x01\n" +
+	"\x0eUpsertProducts\x12'.order_service.v1.UpsertProductsRequest\x1a(.order_service.v1.UpsertProductsResponse\"i\x92AE\n" +
+	"\aproduct\x12\x0fUpsert products\x1a)Creates or updates catalog products by id\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/product/upsert\x12\xb8\x01\n" +
+	"\rQueryProducts\x12&.order_service.v1.QueryProductsRequest\x1a'.order_service.v1.QueryProductsResponse\"V\x92A3\n" +
+	"\aproduct\x12\x0eQuery products\x1a\x18Returns catalog products\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/product/query\x12\xc6\x01\n" +
+	"\x0eDeleteProducts\x12'.order_service.v1.DeleteProductsRequest\x1a(.order_service.v1.DeleteProductsResponse\"a\x92A=\n" +
+	"\aproduct\x12\x0fDelete products\x1a!Removes products from the catalog\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/product/delete2\xc8\x04\n" +
+	"\x0ePaymentService\x12\xfe\x01\n" +
+	"\fStartPayment\x12%.order_service.v1.StartPaymentRequest\x1a&.order_service.v1.StartPaymentResponse\"\x9e\x01\x92A{\n" +
+	"\apayment\x12\rStart payment\x1aaAuthorizes the order total with the payment provider and captures it when auto capture is enabled\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/payment/start\x12\xba\x01\n" +
+	"\rQueryPayments\x12&.order_service.v1.QueryPaymentsRequest\x1a'.order_service.v1.QueryPaymentsResponse\"X\x92A5\n" +
+	"\apayment\x12\x0eQuery payments\x1a\x1aReturns payments of orders\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/payment/query\x12x\n" +
+	"\x15HandlePaymentCallback\x12..order_service.v1.HandlePaymentCallbackRequest\x1a/.order_service.v1.HandlePaymentCallbackResponse2\xee\x05\n" +
+	"\n" +
+	"DlqService\x12\xfa\x01\n" +
+	"\x0fListDlqMessages\x12(.order_service.v1.ListDlqMessagesRequest\x1a).order_service.v1.ListDlqMessagesResponse\"\x91\x01\x92Am\n" +
+	"\x03dlq\x12\x1bList dead-lettered messages\x1aIReturns DLQ messages with headers and death reasons without removing them\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/admin/dlq/list\x12\x89\x02\n" +
+	"\x11ReplayDlqMessages\x12*.order_service.v1.ReplayDlqMessagesRequest\x1a+.order_service.v1.ReplayDlqMessagesResponse\"\x9a\x01\x92At\n" +
+	"\x03dlq\x12\x1dReplay dead-lettered messages\x1aNMoves the selected DLQ messages back to the queue they were dead-lettered from\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/admin/dlq/replay\x12\xd6\x01\n" +
+	"\x10PurgeDlqMessages\x12).order_service.v1.PurgeDlqMessagesRequest\x1a*.order_service.v1.PurgeDlqMessagesResponse\"k\x92AF\n" +
+	"\x03dlq\x12\x1cPurge dead-lettered messages\x1a!Deletes the selected DLQ messages\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/admin/dlq/purgeB\xbe\x01\x92Ak\x121\n" +
 	"\x11Order Service API\x12\x17API for managing orders2\x031.0\x1a\x0elocalhost:5000*\x02\x01\x022\x10application/json:\x10application/jsonZNgithub.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1;orderv1b\x06proto3"
 
 var (
@@ -816,47 +3515,150 @@ func file_order_service_v1_order_service_proto_rawDescGZIP() []byte {
 	return file_order_service_v1_order_service_proto_rawDescData
 }
 
-var file_order_service_v1_order_service_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_order_service_v1_order_service_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_order_service_v1_order_service_proto_goTypes = []any{
-	(*OrderItem)(nil),                        // 0: order_service.v1.OrderItem
-	(*Order)(nil),                            // 1: order_service.v1.Order
-	(*BatchCreateRequest)(nil),               // 2: order_service.v1.BatchCreateRequest
-	(*BatchCreateResponse)(nil),              // 3: order_service.v1.BatchCreateResponse
-	(*QueryOrdersRequest)(nil),               // 4: order_service.v1.QueryOrdersRequest
-	(*QueryOrdersResponse)(nil),              // 5: order_service.v1.QueryOrdersResponse
-	(*LogOrder)(nil),                         // 6: order_service.v1.LogOrder
-	(*AuditLogOrderBatchCreateRequest)(nil),  // 7: order_service.v1.AuditLogOrderBatchCreateRequest
-	(*AuditLogOrderBatchCreateResponse)(nil), // 8: order_service.v1.AuditLogOrderBatchCreateResponse
-	(*UpdateOrdersStatusRequest)(nil),        // 9: order_service.v1.UpdateOrdersStatusRequest
-	(*UpdateOrdersStatusResponse)(nil),       // 10: order_service.v1.UpdateOrdersStatusResponse
-	(*timestamppb.Timestamp)(nil),            // 11: google.protobuf.Timestamp
+	(*OrderItem)(nil),                          // 0: order_service.v1.OrderItem
+	(*Order)(nil),                              // 1: order_service.v1.Order
+	(*BatchCreateRequest)(nil),                 // 2: order_service.v1.BatchCreateRequest
+	(*BatchCreateResponse)(nil),                // 3: order_service.v1.BatchCreateResponse
+	(*QueryOrdersRequest)(nil),                 // 4: order_service.v1.QueryOrdersRequest
+	(*QueryOrdersResponse)(nil),                // 5: order_service.v1.QueryOrdersResponse
+	(*LogOrder)(nil),                           // 6: order_service.v1.LogOrder
+	(*AuditLogOrderBatchCreateRequest)(nil),    // 7: order_service.v1.AuditLogOrderBatchCreateRequest
+	(*AuditLogOrderBatchCreateResponse)(nil),   // 8: order_service.v1.AuditLogOrderBatchCreateResponse
+	(*UpdateOrdersStatusRequest)(nil),          // 9: order_service.v1.UpdateOrdersStatusRequest
+	(*UpdateOrdersStatusResponse)(nil),         // 10: order_service.v1.UpdateOrdersStatusResponse
+	(*WebhookSubscription)(nil),                // 11: order_service.v1.WebhookSubscription
+	(*CreateWebhookSubscriptionRequest)(nil),   // 12: order_service.v1.CreateWebhookSubscriptionRequest
+	(*CreateWebhookSubscriptionResponse)(nil),  // 13: order_service.v1.CreateWebhookSubscriptionResponse
+	(*QueryWebhookSubscriptionsRequest)(nil),   // 14: order_service.v1.QueryWebhookSubscriptionsRequest
+	(*QueryWebhookSubscriptionsResponse)(nil),  // 15: order_service.v1.QueryWebhookSubscriptionsResponse
+	(*UpdateWebhookSubscriptionRequest)(nil),   // 16: order_service.v1.UpdateWebhookSubscriptionRequest
+	(*UpdateWebhookSubscriptionResponse)(nil),  // 17: order_service.v1.UpdateWebhookSubscriptionResponse
+	(*DeleteWebhookSubscriptionsRequest)(nil),  // 18: order_service.v1.DeleteWebhookSubscriptionsRequest
+	(*DeleteWebhookSubscriptionsResponse)(nil), // 19: order_service.v1.DeleteWebhookSubscriptionsResponse
+	(*WebhookDelivery)(nil),                    // 20: order_service.v1.WebhookDelivery
+	(*QueryWebhookDeliveriesRequest)(nil),      // 21: order_service.v1.QueryWebhookDeliveriesRequest
+	(*QueryWebhookDeliveriesResponse)(nil),     // 22: order_service.v1.QueryWebhookDeliveriesResponse
+	(*Stock)(nil),                              // 23: order_service.v1.Stock
+	(*StockAdjustment)(nil),                    // 24: order_service.v1.StockAdjustment
+	(*AdjustStockRequest)(nil),                 // 25: order_service.v1.AdjustStockRequest
+	(*AdjustStockResponse)(nil),                // 26: order_service.v1.AdjustStockResponse
+	(*QueryStockRequest)(nil),                  // 27: order_service.v1.QueryStockRequest
+	(*QueryStockResponse)(nil),                 // 28: order_service.v1.QueryStockResponse
+	(*Product)(nil),                            // 29: order_service.v1.Product
+	(*UpsertProductsRequest)(nil),              // 30: order_service.v1.UpsertProductsRequest
+	(*UpsertProductsResponse)(nil),             // 31: order_service.v1.UpsertProductsResponse
+	(*QueryProductsRequest)(nil),               // 32: order_service.v1.QueryProductsRequest
+	(*QueryProductsResponse)(nil),              // 33: order_service.v1.QueryProductsResponse
+	(*DeleteProductsRequest)(nil),              // 34: order_service.v1.DeleteProductsRequest
+	(*DeleteProductsResponse)(nil),             // 35: order_service.v1.DeleteProductsResponse
+	(*Payment)(nil),                            // 36: order_service.v1.Payment
+	(*StartPaymentRequest)(nil),                // 37: order_service.v1.StartPaymentRequest
+	(*StartPaymentResponse)(nil),               // 38: order_service.v1.StartPaymentResponse
+	(*QueryPaymentsRequest)(nil),               // 39: order_service.v1.QueryPaymentsRequest
+	(*QueryPaymentsResponse)(nil),              // 40: order_service.v1.QueryPaymentsResponse
+	(*HandlePaymentCallbackRequest)(nil),       // 41: order_service.v1.HandlePaymentCallbackRequest
+	(*HandlePaymentCallbackResponse)(nil),      // 42: order_service.v1.HandlePaymentCallbackResponse
+	(*DlqMessage)(nil),                         // 43: order_service.v1.DlqMessage
+	(*DlqMessageFilter)(nil),                   // 44: order_service.v1.DlqMessageFilter
+	(*ListDlqMessagesRequest)(nil),             // 45: order_service.v1.ListDlqMessagesRequest
+	(*ListDlqMessagesResponse)(nil),            // 46: order_service.v1.ListDlqMessagesResponse
+	(*ReplayDlqMessagesRequest)(nil),           // 47: order_service.v1.ReplayDlqMessagesRequest
+	(*ReplayDlqMessagesResponse)(nil),          // 48: order_service.v1.ReplayDlqMessagesResponse
+	(*PurgeDlqMessagesRequest)(nil),            // 49: order_service.v1.PurgeDlqMessagesRequest
+	(*PurgeDlqMessagesResponse)(nil),           // 50: order_service.v1.PurgeDlqMessagesResponse
+	nil,                                        // 51: order_service.v1.DlqMessage.HeadersEntry
+	(*timestamppb.Timestamp)(nil),              // 52: google.protobuf.Timestamp
 }
 var file_order_service_v1_order_service_proto_depIdxs = []int32{
-	11, // 0: order_service.v1.OrderItem.created_at:type_name -> google.protobuf.Timestamp
-	11, // 1: order_service.v1.OrderItem.updated_at:type_name -> google.protobuf.Timestamp
-	11, // 2: order_service.v1.Order.created_at:type_name -> google.protobuf.Timestamp
-	11, // 3: order_service.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	52, // 0: order_service.v1.OrderItem.created_at:type_name -> google.protobuf.Timestamp
+	52, // 1: order_service.v1.OrderItem.updated_at:type_name -> google.protobuf.Timestamp
+	52, // 2: order_service.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	52, // 3: order_service.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 4: order_service.v1.Order.order_items:type_name -> order_service.v1.OrderItem
 	1,  // 5: order_service.v1.BatchCreateRequest.orders:type_name -> order_service.v1.Order
 	1,  // 6: order_service.v1.BatchCreateResponse.orders:type_name -> order_service.v1.Order
 	1,  // 7: order_service.v1.QueryOrdersResponse.orders:type_name -> order_service.v1.Order
-	11, // 8: order_service.v1.LogOrder.created_at:type_name -> google.protobuf.Timestamp
-	11, // 9: order_service.v1.LogOrder.updated_at:type_name -> google.protobuf.Timestamp
+	52, // 8: order_service.v1.LogOrder.created_at:type_name -> google.protobuf.Timestamp
+	52, // 9: order_service.v1.LogOrder.updated_at:type_name -> google.protobuf.Timestamp
 	6,  // 10: order_service.v1.AuditLogOrderBatchCreateRequest.orders:type_name -> order_service.v1.LogOrder
 	6,  // 11: order_service.v1.AuditLogOrderBatchCreateResponse.orders:type_name -> order_service.v1.LogOrder
-	2,  // 12: order_service.v1.OrderService.BatchCreate:input_type -> order_service.v1.BatchCreateRequest
-	4,  // 13: order_service.v1.OrderService.QueryOrders:input_type -> order_service.v1.QueryOrdersRequest
-	7,  // 14: order_service.v1.OrderService.AuditLogOrderBatchCreate:input_type -> order_service.v1.AuditLogOrderBatchCreateRequest
-	9,  // 15: order_service.v1.OrderService.UpdateOrdersStatus:input_type -> order_service.v1.UpdateOrdersStatusRequest
-	3,  // 16: order_service.v1.OrderService.BatchCreate:output_type -> order_service.v1.BatchCreateResponse
-	5,  // 17: order_service.v1.OrderService.QueryOrders:output_type -> order_service.v1.QueryOrdersResponse
-	8,  // 18: order_service.v1.OrderService.AuditLogOrderBatchCreate:output_type -> order_service.v1.AuditLogOrderBatchCreateResponse
-	10, // 19: order_service.v1.OrderService.UpdateOrdersStatus:output_type -> order_service.v1.UpdateOrdersStatusResponse
-	16, // [16:20] is the sub-list for method output_type
-	12, // [12:16] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	52, // 12: order_service.v1.WebhookSubscription.disabled_at:type_name -> google.protobuf.Timestamp
+	52, // 13: order_service.v1.WebhookSubscription.created_at:type_name -> google.protobuf.Timestamp
+	52, // 14: order_service.v1.WebhookSubscription.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 15: order_service.v1.CreateWebhookSubscriptionResponse.subscription:type_name -> order_service.v1.WebhookSubscription
+	11, // 16: order_service.v1.QueryWebhookSubscriptionsResponse.subscriptions:type_name -> order_service.v1.WebhookSubscription
+	11, // 17: order_service.v1.UpdateWebhookSubscriptionResponse.subscription:type_name -> order_service.v1.WebhookSubscription
+	52, // 18: order_service.v1.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	20, // 19: order_service.v1.QueryWebhookDeliveriesResponse.deliveries:type_name -> order_service.v1.WebhookDelivery
+	52, // 20: order_service.v1.Stock.created_at:type_name -> google.protobuf.Timestamp
+	52, // 21: order_service.v1.Stock.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 22: order_service.v1.AdjustStockRequest.adjustments:type_name -> order_service.v1.StockAdjustment
+	23, // 23: order_service.v1.AdjustStockResponse.stocks:type_name -> order_service.v1.Stock
+	23, // 24: order_service.v1.QueryStockResponse.stocks:type_name -> order_service.v1.Stock
+	52, // 25: order_service.v1.Product.created_at:type_name -> google.protobuf.Timestamp
+	52, // 26: order_service.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
+	29, // 27: order_service.v1.UpsertProductsRequest.products:type_name -> order_service.v1.Product
+	29, // 28: order_service.v1.UpsertProductsResponse.products:type_name -> order_service.v1.Product
+	29, // 29: order_service.v1.QueryProductsResponse.products:type_name -> order_service.v1.Product
+	52, // 30: order_service.v1.Payment.created_at:type_name -> google.protobuf.Timestamp
+	52, // 31: order_service.v1.Payment.updated_at:type_name -> google.protobuf.Timestamp
+	36, // 32: order_service.v1.StartPaymentResponse.payment:type_name -> order_service.v1.Payment
+	36, // 33: order_service.v1.QueryPaymentsResponse.payments:type_name -> order_service.v1.Payment
+	51, // 34: order_service.v1.DlqMessage.headers:type_name -> order_service.v1.DlqMessage.HeadersEntry
+	52, // 35: order_service.v1.DlqMessage.dead_lettered_at:type_name -> google.protobuf.Timestamp
+	52, // 36: order_service.v1.DlqMessageFilter.dead_lettered_from:type_name -> google.protobuf.Timestamp
+	52, // 37: order_service.v1.DlqMessageFilter.dead_lettered_to:type_name -> google.protobuf.Timestamp
+	44, // 38: order_service.v1.ListDlqMessagesRequest.filter:type_name -> order_service.v1.DlqMessageFilter
+	43, // 39: order_service.v1.ListDlqMessagesResponse.messages:type_name -> order_service.v1.DlqMessage
+	44, // 40: order_service.v1.ReplayDlqMessagesRequest.filter:type_name -> order_service.v1.DlqMessageFilter
+	44, // 41: order_service.v1.PurgeDlqMessagesRequest.filter:type_name -> order_service.v1.DlqMessageFilter
+	2,  // 42: order_service.v1.OrderService.BatchCreate:input_type -> order_service.v1.BatchCreateRequest
+	4,  // 43: order_service.v1.OrderService.QueryOrders:input_type -> order_service.v1.QueryOrdersRequest
+	7,  // 44: order_service.v1.OrderService.AuditLogOrderBatchCreate:input_type -> order_service.v1.AuditLogOrderBatchCreateRequest
+	9,  // 45: order_service.v1.OrderService.UpdateOrdersStatus:input_type -> order_service.v1.UpdateOrdersStatusRequest
+	12, // 46: order_service.v1.WebhookService.CreateWebhookSubscription:input_type -> order_service.v1.CreateWebhookSubscriptionRequest
+	14, // 47: order_service.v1.WebhookService.QueryWebhookSubscriptions:input_type -> order_service.v1.QueryWebhookSubscriptionsRequest
+	16, // 48: order_service.v1.WebhookService.UpdateWebhookSubscription:input_type -> order_service.v1.UpdateWebhookSubscriptionRequest
+	18, // 49: order_service.v1.WebhookService.DeleteWebhookSubscriptions:input_type -> order_service.v1.DeleteWebhookSubscriptionsRequest
+	21, // 50: order_service.v1.WebhookService.QueryWebhookDeliveries:input_type -> order_service.v1.QueryWebhookDeliveriesRequest
+	25, // 51: order_service.v1.InventoryService.AdjustStock:input_type -> order_service.v1.AdjustStockRequest
+	27, // 52: order_service.v1.InventoryService.QueryStock:input_type -> order_service.v1.QueryStockRequest
+	30, // 53: order_service.v1.ProductService.UpsertProducts:input_type -> order_service.v1.UpsertProductsRequest
+	32, // 54: order_service.v1.ProductService.QueryProducts:input_type -> order_service.v1.QueryProductsRequest
+	34, // 55: order_service.v1.ProductService.DeleteProducts:input_type -> order_service.v1.DeleteProductsRequest
+	37, // 56: order_service.v1.PaymentService.StartPayment:input_type -> order_service.v1.StartPaymentRequest
+	39, // 57: order_service.v1.PaymentService.QueryPayments:input_type -> order_service.v1.QueryPaymentsRequest
+	41, // 58: order_service.v1.PaymentService.HandlePaymentCallback:input_type -> order_service.v1.HandlePaymentCallbackRequest
+	45, // 59: order_service.v1.DlqService.ListDlqMessages:input_type -> order_service.v1.ListDlqMessagesRequest
+	47, // 60: order_service.v1.DlqService.ReplayDlqMessages:input_type -> order_service.v1.ReplayDlqMessagesRequest
+	49, // 61: order_service.v1.DlqService.PurgeDlqMessages:input_type -> order_service.v1.PurgeDlqMessagesRequest
+	3,  // 62: order_service.v1.OrderService.BatchCreate:output_type -> order_service.v1.BatchCreateResponse
+	5,  // 63: order_service.v1.OrderService.QueryOrders:output_type -> order_service.v1.QueryOrdersResponse
+	8,  // 64: order_service.v1.OrderService.AuditLogOrderBatchCreate:output_type -> order_service.v1.AuditLogOrderBatchCreateResponse
+	10, // 65: order_service.v1.OrderService.UpdateOrdersStatus:output_type -> order_service.v1.UpdateOrdersStatusResponse
+	13, // 66: order_service.v1.WebhookService.CreateWebhookSubscription:output_type -> order_service.v1.CreateWebhookSubscriptionResponse
+	15, // 67: order_service.v1.WebhookService.QueryWebhookSubscriptions:output_type -> order_service.v1.QueryWebhookSubscriptionsResponse
+	17, // 68: order_service.v1.WebhookService.UpdateWebhookSubscription:output_type -> order_service.v1.UpdateWebhookSubscriptionResponse
+	19, // 69: order_service.v1.WebhookService.DeleteWebhookSubscriptions:output_type -> order_service.v1.DeleteWebhookSubscriptionsResponse
+	22, // 70: order_service.v1.WebhookService.QueryWebhookDeliveries:output_type -> order_service.v1.QueryWebhookDeliveriesResponse
+	26, // 71: order_service.v1.InventoryService.AdjustStock:output_type -> order_service.v1.AdjustStockResponse
+	28, // 72: order_service.v1.InventoryService.QueryStock:output_type -> order_service.v1.QueryStockResponse
+	31, // 73: order_service.v1.ProductService.UpsertProducts:output_type -> order_service.v1.UpsertProductsResponse
+	33, // 74: order_service.v1.ProductService.QueryProducts:output_type -> order_service.v1.QueryProductsResponse
+	35, // 75: order_service.v1.ProductService.DeleteProducts:output_type -> order_service.v1.DeleteProductsResponse
+	38, // 76: order_service.v1.PaymentService.StartPayment:output_type -> order_service.v1.StartPaymentResponse
+	40, // 77: order_service.v1.PaymentService.QueryPayments:output_type -> order_service.v1.QueryPaymentsResponse
+	42, // 78: order_service.v1.PaymentService.HandlePaymentCallback:output_type -> order_service.v1.HandlePaymentCallbackResponse
+	46, // 79: order_service.v1.DlqService.ListDlqMessages:output_type -> order_service.v1.ListDlqMessagesResponse
+	48, // 80: order_service.v1.DlqService.ReplayDlqMessages:output_type -> order_service.v1.ReplayDlqMessagesResponse
+	50, // 81: order_service.v1.DlqService.PurgeDlqMessages:output_type -> order_service.v1.PurgeDlqMessagesResponse
+	62, // [62:82] is the sub-list for method output_type
+	42, // [42:62] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_order_service_v1_order_service_proto_init() }
@@ -870,9 +3672,9 @@ func file_order_service_v1_order_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_service_v1_order_service_proto_rawDesc), len(file_order_service_v1_order_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   52,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   6,
 		},
 		GoTypes:           file_order_service_v1_order_service_proto_goTypes,
 		DependencyIndexes: file_order_service_v1_order_service_proto_depIdxs,
@@ -1,13 +1,23 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
 	client "github.com/ZaiiiRan/backend_labs/order-service/internal/client/grpc"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/config"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/consumer"
 	dalconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
 	rabbitmqconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer/rabbitmq"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/rabbitmq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/health"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/logger"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/metrics"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/runtimesettings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tracing"
 	"go.uber.org/zap"
 )
 
@@ -15,6 +25,8 @@ type ConsumerApp struct {
 	cfg *config.ConsumerConfig
 	log *zap.SugaredLogger
 
+	shutdownTracing func(context.Context) error
+
 	orderCreatedRabbitmqClient       *rabbitmq.RabbitMqClient
 	orderStatusChangedRabbitmqClient *rabbitmq.RabbitMqClient
 
@@ -24,6 +36,10 @@ type ConsumerApp struct {
 	orderStatusChangedMessageProcessor dalconsumer.MessageProcessor
 
 	omsClient *client.OmsGrpcClient
+
+	metricsServer *metrics.Server
+
+	runtimeSettings *runtimesettings.Manager
 }
 
 func NewConsumerApp() (*ConsumerApp, error) {
@@ -32,7 +48,7 @@ func NewConsumerApp() (*ConsumerApp, error) {
 		return nil, err
 	}
 
-	log, err := logger.NewLogger()
+	log, err := logger.NewLogger(cfg.Logging)
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +57,9 @@ func NewConsumerApp() (*ConsumerApp, error) {
 }
 
 func (a *ConsumerApp) Run() error {
+	if err := a.initTracing(); err != nil {
+		return err
+	}
 	if err := a.initOmsGrpcClient(); err != nil {
 		return err
 	}
@@ -58,6 +77,11 @@ func (a *ConsumerApp) Run() error {
 	if err := a.startOrderStatusChangedConsumer(); err != nil {
 		return err
 	}
+	a.initRuntimeSettings()
+	a.startMetricsServer()
+	if err := a.watchConfig(); err != nil {
+		return err
+	}
 
 	a.log.Infow("app.started")
 	return nil
@@ -66,17 +90,54 @@ func (a *ConsumerApp) Run() error {
 func (a *ConsumerApp) Stop() {
 	a.log.Infow("app.stopping")
 
-	a.orderCreatedConsumer.Stop()
+	// Both consumers drain before the OMS client goes away, since their last batches
+	// still call it.
+	var wg sync.WaitGroup
+	for _, c := range []*rabbitmqconsumer.Consumer{a.orderCreatedConsumer, a.orderStatusChangedConsumer} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			drainConsumer(a.log, c)
+		}()
+	}
+	wg.Wait()
+
 	a.orderCreatedRabbitmqClient.Close()
-	a.orderStatusChangedConsumer.Stop()
 	a.orderStatusChangedRabbitmqClient.Close()
 	a.omsClient.Close()
 
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	a.metricsServer.Stop(ctx)
+	if err := a.shutdownTracing(ctx); err != nil {
+		a.log.Warnw("app.tracing_shutdown_failed", "err", err)
+	}
+
 	a.log.Infow("app.stopped")
 }
 
+// drainConsumer stops c and waits up to its DrainTimeoutSeconds for buffered and
+// in-flight batches to be processed and settled.
+func drainConsumer(log *zap.SugaredLogger, c *rabbitmqconsumer.Consumer) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.DrainTimeout())
+	defer cancel()
+	if err := c.Stop(ctx); err != nil {
+		log.Warnw("app.consumer_drain_incomplete", "err", err)
+	}
+}
+
+func (a *ConsumerApp) initTracing() error {
+	shutdown, err := tracing.Init(context.Background(), a.cfg.Tracing, "order-service-consumer")
+	if err != nil {
+		a.log.Errorw("app.tracing_init_failed", "err", err)
+		return err
+	}
+	a.shutdownTracing = shutdown
+	return nil
+}
+
 func (a *ConsumerApp) initOmsGrpcClient() error {
-	client, err := client.NewOmsGrpcClient(a.cfg.OmsClientGrpcSettings)
+	client, err := client.NewOmsGrpcClient(a.cfg.OmsClientGrpcSettings, a.log.Named("oms_client"))
 	if err != nil {
 		a.log.Errorw("app.create_oms_grpc_client_failed", "err", err)
 		return err
@@ -86,11 +147,11 @@ func (a *ConsumerApp) initOmsGrpcClient() error {
 }
 
 func (a *ConsumerApp) initOrderCreatedMessageProcessor() {
-	a.orderCreatedMessageProcessor = consumer.NewOrderCreatedMessageProcessor(a.omsClient, a.log)
+	a.orderCreatedMessageProcessor = consumer.NewOrderCreatedMessageProcessor(a.omsClient, a.log.Named("processor"))
 }
 
 func (a *ConsumerApp) initOrderStatusChangedMessageProcessor() {
-	a.orderStatusChangedMessageProcessor = consumer.NewOrderStatusChangedMessageProcessor(a.omsClient, a.log)
+	a.orderStatusChangedMessageProcessor = consumer.NewOrderStatusChangedMessageProcessor(a.omsClient, a.log.Named("processor"))
 }
 
 func (a *ConsumerApp) initOrderCreatedConsumer() error {
@@ -101,7 +162,7 @@ func (a *ConsumerApp) initOrderCreatedConsumer() error {
 	a.orderCreatedRabbitmqClient = rabbitMqClient
 
 	orderCreatedConsumer, err := rabbitmqconsumer.NewConsumer(&a.cfg.OrderCreatedRabbitMqConsumerSettings, a.orderCreatedRabbitmqClient,
-		a.orderCreatedMessageProcessor, a.log)
+		a.orderCreatedMessageProcessor, a.log.Named("consumer"))
 	if err != nil {
 		a.log.Errorw("app.create_order_created_consumer_failed", "err", err)
 	}
@@ -117,7 +178,7 @@ func (a *ConsumerApp) initOrderStatusChangedConsumer() error {
 	a.orderStatusChangedRabbitmqClient = rabbitMqClient
 
 	orderStatusChangedConsumer, err := rabbitmqconsumer.NewConsumer(&a.cfg.OrderStatusChangedRabbitMqConsumerSettings, a.orderStatusChangedRabbitmqClient,
-		a.orderStatusChangedMessageProcessor, a.log)
+		a.orderStatusChangedMessageProcessor, a.log.Named("consumer"))
 	if err != nil {
 		a.log.Errorw("app.create_order_status_changed_consumer_failed", "err", err)
 	}
@@ -127,7 +188,7 @@ func (a *ConsumerApp) initOrderStatusChangedConsumer() error {
 
 func (a *ConsumerApp) startOrderCreatedConsumer() error {
 	go func() {
-		if err := a.orderCreatedConsumer.Start(); err != nil {
+		if err := a.orderCreatedConsumer.Start(); err != nil && !errors.Is(err, rabbitmqconsumer.ErrStopped) {
 			a.log.Fatalw("app.start_order_created_consumer_failed", "err", err)
 		}
 	}()
@@ -136,9 +197,69 @@ func (a *ConsumerApp) startOrderCreatedConsumer() error {
 
 func (a *ConsumerApp) startOrderStatusChangedConsumer() error {
 	go func() {
-		if err := a.orderStatusChangedConsumer.Start(); err != nil {
+		if err := a.orderStatusChangedConsumer.Start(); err != nil && !errors.Is(err, rabbitmqconsumer.ErrStopped) {
 			a.log.Fatalw("app.start_order_status_changed_consumer_failed", "err", err)
 		}
 	}()
 	return nil
 }
+
+func (a *ConsumerApp) startMetricsServer() {
+	checker := health.NewChecker()
+	checker.Add("order_created_consumer", func(context.Context) error {
+		return a.orderCreatedConsumer.Ping()
+	})
+	checker.Add("order_status_changed_consumer", func(context.Context) error {
+		return a.orderStatusChangedConsumer.Ping()
+	})
+
+	handlers := map[string]http.Handler{
+		"/healthz": checker.LivenessHandler(),
+		"/readyz":  checker.ReadinessHandler(),
+	}
+	for path, h := range adminHandlers(a.runtimeSettings, a.cfg.Admin) {
+		handlers[path] = h
+	}
+	a.metricsServer = metrics.NewServer(a.cfg.Metrics.Port, handlers)
+	go func() {
+		a.log.Infow("app.metrics.serve_start", "port", a.cfg.Metrics.Port)
+		if err := a.metricsServer.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.log.Fatalw("app.metrics.serve_error", "err", err)
+		}
+	}()
+}
+
+func runtimeSettingsFromConsumerConfig(cfg *config.ConsumerConfig) runtimesettings.Settings {
+	return runtimesettings.Settings{
+		LogLevel: cfg.Logging.Level,
+		Consumers: map[string]runtimesettings.ConsumerBatch{
+			"OrderCreated":       consumerBatch(cfg.OrderCreatedRabbitMqConsumerSettings),
+			"OrderStatusChanged": consumerBatch(cfg.OrderStatusChangedRabbitMqConsumerSettings),
+		},
+	}
+}
+
+func (a *ConsumerApp) initRuntimeSettings() {
+	consumers := map[string]*rabbitmqconsumer.Consumer{
+		"OrderCreated":       a.orderCreatedConsumer,
+		"OrderStatusChanged": a.orderStatusChangedConsumer,
+	}
+	a.runtimeSettings = runtimesettings.NewManager(runtimeSettingsFromConsumerConfig(a.cfg), func(s runtimesettings.Settings) error {
+		return applyCommonRuntimeSettings(s, consumers)
+	}, a.log)
+}
+
+func (a *ConsumerApp) watchConfig() error {
+	err := config.WatchConsumerConfig(func(cfg *config.ConsumerConfig) {
+		if err := a.runtimeSettings.FileChanged(runtimeSettingsFromConsumerConfig(cfg)); err != nil {
+			a.log.Errorw("app.runtime_settings_apply_failed", "err", err)
+		}
+	}, func(err error) {
+		a.log.Errorw("app.config_reload_failed", "err", err)
+	})
+	if err != nil {
+		a.log.Errorw("app.config_watch_failed", "err", err)
+		return err
+	}
+	return nil
+}
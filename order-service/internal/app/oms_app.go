@@ -3,31 +3,71 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/auth"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/cache"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/client/payment"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/client/shipping"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/config"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/consumer"
+	rabbitmqconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer/rabbitmq"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
 	publisher "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/publisher/rabbitmq"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/rabbitmq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dlq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/health"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/logger"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/metrics"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/runtimesettings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/saga"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/scheduler"
 	grpcserver "github.com/ZaiiiRan/backend_labs/order-service/internal/server/grpc"
+	grpcinterceptors "github.com/ZaiiiRan/backend_labs/order-service/internal/server/grpc/interceptors"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/server/grpc/services"
 	grpcgateway "github.com/ZaiiiRan/backend_labs/order-service/internal/server/grpc_gateway"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tlsconfig"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type OmsApp struct {
 	cfg *config.ServerConfig
 	log *zap.SugaredLogger
 
+	shutdownTracing func(context.Context) error
+
 	postgresClient *postgres.PostgresClient
 	rabbitmqClient *rabbitmq.RabbitMqClient
 
 	omsPublisher *publisher.Publisher
 
-	orderService *services.OrderService
+	orderService     *services.OrderService
+	webhookService   *services.WebhookService
+	inventoryService *services.InventoryService
+	productService   *services.ProductService
+	paymentService   *services.PaymentService
+	dlqService       *services.DlqService
+
+	productCache    *cache.ProductCache
+	paymentProvider payment.PaymentProvider
+
+	sagaRabbitmqClient *rabbitmq.RabbitMqClient
+	sagaOrchestrator   *saga.Orchestrator
+	sagaConsumer       *rabbitmqconsumer.Consumer
+
+	staleOrderCanceller *scheduler.StaleOrderCanceller
+
+	healthChecker *health.Checker
+
+	rateLimiter     *grpcinterceptors.RateLimiter
+	runtimeSettings *runtimesettings.Manager
 
 	grpcServer  *grpcserver.Server
 	grpcGateway *grpcgateway.Server
@@ -39,7 +79,7 @@ func NewOmsApp() (*OmsApp, error) {
 		return nil, err
 	}
 
-	log, err := logger.NewLogger()
+	log, err := logger.NewLogger(cfg.Logging)
 	if err != nil {
 		return nil, err
 	}
@@ -48,6 +88,9 @@ func NewOmsApp() (*OmsApp, error) {
 }
 
 func (a *OmsApp) Run(ctx context.Context) error {
+	if err := a.initTracing(ctx); err != nil {
+		return err
+	}
 	if err := a.initPostgresClient(ctx); err != nil {
 		return err
 	}
@@ -57,15 +100,44 @@ func (a *OmsApp) Run(ctx context.Context) error {
 	if err := a.initPublishers(); err != nil {
 		return err
 	}
+	a.initProductCache()
 	a.initOrderService()
+	a.initWebhookService()
+	a.initInventoryService()
+	a.initProductService()
+	a.initDlqService()
+	if err := a.initPaymentService(); err != nil {
+		return err
+	}
+	if a.cfg.Saga.Enabled {
+		if err := a.initSagaOrchestrator(); err != nil {
+			return err
+		}
+		if err := a.initSagaConsumer(); err != nil {
+			return err
+		}
+		a.startSagaOrchestrator(ctx)
+	}
+	if a.cfg.StaleOrderScheduler.Enabled {
+		if err := a.initStaleOrderCanceller(); err != nil {
+			return err
+		}
+		a.startStaleOrderCanceller(ctx)
+	}
+	a.initHealthChecker()
 	if err := a.initGrpcServer(); err != nil {
 		return err
 	}
 	a.startGrpcServer()
+	a.startHealthWatch(ctx)
+	a.initRuntimeSettings()
 	if err := a.initGrpcGateway(ctx); err != nil {
 		return err
 	}
 	a.startGrpcGateway()
+	if err := a.watchConfig(); err != nil {
+		return err
+	}
 	a.log.Infow("app.started")
 	return nil
 }
@@ -76,6 +148,11 @@ func (a *OmsApp) Stop(ctx context.Context) {
 	shCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	if a.sagaConsumer != nil {
+		drainConsumer(a.log, a.sagaConsumer)
+		a.sagaRabbitmqClient.Close()
+	}
+
 	a.postgresClient.Close()
 	a.omsPublisher.Close()
 	a.rabbitmqClient.Close()
@@ -83,9 +160,23 @@ func (a *OmsApp) Stop(ctx context.Context) {
 	a.grpcServer.Stop(shCtx)
 	a.grpcGateway.Stop(shCtx)
 
+	if err := a.shutdownTracing(shCtx); err != nil {
+		a.log.Warnw("app.tracing_shutdown_failed", "err", err)
+	}
+
 	a.log.Infow("app.stopped")
 }
 
+func (a *OmsApp) initTracing(ctx context.Context) error {
+	shutdown, err := tracing.Init(ctx, a.cfg.Tracing, "order-service")
+	if err != nil {
+		a.log.Errorw("app.tracing_init_failed", "err", err)
+		return err
+	}
+	a.shutdownTracing = shutdown
+	return nil
+}
+
 func (a *OmsApp) initPostgresClient(ctx context.Context) error {
 	pgClient, err := postgres.NewPostgresClient(ctx, a.cfg.DbSettings.ConnectionString)
 	if err != nil {
@@ -93,6 +184,11 @@ func (a *OmsApp) initPostgresClient(ctx context.Context) error {
 		return err
 	}
 	a.postgresClient = pgClient
+
+	if err := metrics.RegisterPgxPool(pgClient.Stat); err != nil {
+		a.log.Errorw("app.register_pgxpool_metrics_failed", "err", err)
+		return err
+	}
 	return nil
 }
 
@@ -116,11 +212,202 @@ func (a *OmsApp) initPublishers() error {
 }
 
 func (a *OmsApp) initOrderService() {
-	a.orderService = services.NewOrderService(a.postgresClient, a.omsPublisher, a.log)
+	a.orderService = services.NewOrderService(a.postgresClient, a.omsPublisher, a.cfg.Inventory, a.cfg.ProductCatalog, a.productCache, a.log.Named("grpc"))
+}
+
+func (a *OmsApp) initWebhookService() {
+	a.webhookService = services.NewWebhookService(a.postgresClient, a.log.Named("grpc"))
+}
+
+func (a *OmsApp) initProductCache() {
+	a.productCache = cache.NewProductCache(time.Duration(a.cfg.ProductCatalog.CacheTtlSeconds) * time.Second)
+}
+
+func (a *OmsApp) initProductService() {
+	a.productService = services.NewProductService(a.postgresClient, a.productCache, a.log.Named("grpc"))
+}
+
+func (a *OmsApp) initDlqService() {
+	a.dlqService = services.NewDlqService(
+		dlq.NewManager(a.rabbitmqClient, a.cfg.Dlq),
+		time.Duration(a.cfg.Dlq.ReplayCallTimeoutSeconds)*time.Second,
+		a.log.Named("grpc"),
+	)
+}
+
+func (a *OmsApp) initInventoryService() {
+	a.inventoryService = services.NewInventoryService(a.postgresClient, a.cfg.Inventory, a.log.Named("grpc"))
+}
+
+func (a *OmsApp) initPaymentService() error {
+	paymentProvider, err := newPaymentProvider(&a.cfg.PaymentProvider)
+	if err != nil {
+		a.log.Errorw("app.create_payment_provider_failed", "err", err)
+		return err
+	}
+	a.paymentProvider = paymentProvider
+	a.paymentService = services.NewPaymentService(a.postgresClient, a.omsPublisher, paymentProvider, a.cfg.PaymentProvider, a.cfg.Inventory, a.log.Named("grpc"))
+	return nil
+}
+
+func (a *OmsApp) initSagaOrchestrator() error {
+	shippingProvider, err := newShippingProvider(&a.cfg.ShippingProvider)
+	if err != nil {
+		a.log.Errorw("app.create_shipping_provider_failed", "err", err)
+		return err
+	}
+
+	steps := []saga.Step{
+		saga.NewReserveStockStep(a.postgresClient, a.cfg.Inventory.DefaultWarehouseId, a.log.Named("saga")),
+		saga.NewAuthorizePaymentStep(a.postgresClient, a.paymentProvider, a.log.Named("saga")),
+		saga.NewCreateShipmentStep(shippingProvider),
+	}
+
+	orchestrator, err := saga.NewOrchestrator(&a.cfg.Saga, a.cfg.Inventory, a.postgresClient, a.omsPublisher, steps, a.log.Named("saga"))
+	if err != nil {
+		a.log.Errorw("app.create_saga_orchestrator_failed", "err", err)
+		return err
+	}
+	a.sagaOrchestrator = orchestrator
+	return nil
+}
+
+func (a *OmsApp) initSagaConsumer() error {
+	rabbitMqClient, err := rabbitmq.NewRabbitMqClient(&a.cfg.SagaRabbitMqConsumerSettings.RabbitMqSettings)
+	if err != nil {
+		a.log.Errorw("app.rabbitmq_connect_failed", "err", err)
+		return err
+	}
+	a.sagaRabbitmqClient = rabbitMqClient
+
+	processor := consumer.NewSagaMessageProcessor(a.sagaOrchestrator, a.log.Named("processor"))
+	sagaConsumer, err := rabbitmqconsumer.NewConsumer(&a.cfg.SagaRabbitMqConsumerSettings, a.sagaRabbitmqClient, processor, a.log.Named("consumer"))
+	if err != nil {
+		a.log.Errorw("app.create_saga_consumer_failed", "err", err)
+		return err
+	}
+	a.sagaConsumer = sagaConsumer
+	return nil
+}
+
+func (a *OmsApp) startSagaOrchestrator(ctx context.Context) {
+	go func() {
+		if err := a.sagaOrchestrator.Resume(ctx); err != nil {
+			a.log.Errorw("app.resume_sagas_failed", "err", err)
+		}
+	}()
+	go func() {
+		if err := a.sagaConsumer.Start(); err != nil && !errors.Is(err, rabbitmqconsumer.ErrStopped) {
+			a.log.Fatalw("app.start_saga_consumer_failed", "err", err)
+		}
+	}()
+}
+
+func (a *OmsApp) initStaleOrderCanceller() error {
+	canceller, err := scheduler.NewStaleOrderCanceller(&a.cfg.StaleOrderScheduler, a.cfg.Inventory, a.postgresClient, a.omsPublisher, a.log.Named("scheduler"))
+	if err != nil {
+		a.log.Errorw("app.create_stale_order_canceller_failed", "err", err)
+		return err
+	}
+	a.staleOrderCanceller = canceller
+	return nil
+}
+
+func (a *OmsApp) startStaleOrderCanceller(ctx context.Context) {
+	go a.staleOrderCanceller.Run(ctx)
+}
+
+func newPaymentProvider(cfg *settings.PaymentProviderSettings) (payment.PaymentProvider, error) {
+	switch cfg.Provider {
+	case "fake":
+		return payment.NewFakeProvider(cfg.FakeDeclineAboveCents, time.Duration(cfg.FakeLatencyMs)*time.Millisecond), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Provider)
+	}
+}
+
+func newShippingProvider(cfg *settings.ShippingProviderSettings) (shipping.ShippingProvider, error) {
+	switch cfg.Provider {
+	case "fake":
+		return shipping.NewFakeProvider(cfg.FakeRejectAddressSubstring, time.Duration(cfg.FakeLatencyMs)*time.Millisecond), nil
+	default:
+		return nil, fmt.Errorf("unknown shipping provider %q", cfg.Provider)
+	}
+}
+
+func (a *OmsApp) initHealthChecker() {
+	a.healthChecker = health.NewChecker()
+	a.healthChecker.Add("postgres", a.postgresClient.Ping)
+	a.healthChecker.Add("rabbitmq", func(context.Context) error {
+		return a.omsPublisher.Ping()
+	})
+	if a.sagaConsumer != nil {
+		a.healthChecker.Add("saga_consumer", func(context.Context) error {
+			return a.sagaConsumer.Ping()
+		})
+	}
+}
+
+func (a *OmsApp) startHealthWatch(ctx context.Context) {
+	go a.healthChecker.Watch(ctx, a.grpcServer.SetServing)
 }
 
 func (a *OmsApp) initGrpcServer() error {
-	srv, err := grpcserver.NewServer(a.cfg.Grpc.Port, a.orderService)
+	// Services log through the request logger set up here, so they share the component.
+	log := a.log.Named("grpc")
+	unary := []grpc.UnaryServerInterceptor{
+		grpcinterceptors.RequestIDUnaryInterceptor(log),
+		grpcinterceptors.MetricsUnaryInterceptor(),
+		grpcinterceptors.AccessLogUnaryInterceptor(log),
+		grpcinterceptors.RecoveryUnaryInterceptor(log),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		grpcinterceptors.RequestIDStreamInterceptor(log),
+		grpcinterceptors.MetricsStreamInterceptor(),
+		grpcinterceptors.AccessLogStreamInterceptor(log),
+		grpcinterceptors.RecoveryStreamInterceptor(log),
+	}
+	if a.cfg.Auth.Enabled {
+		verifier, err := auth.NewVerifier(&a.cfg.Auth)
+		if err != nil {
+			a.log.Errorw("app.grpc.create_token_verifier_failed", "err", err)
+			return err
+		}
+		unary = append(unary, grpcinterceptors.AuthUnaryInterceptor(verifier, log))
+		stream = append(stream, grpcinterceptors.AuthStreamInterceptor(verifier, log))
+	}
+	// Always installed so rate limiting can be switched on at runtime.
+	a.rateLimiter = grpcinterceptors.NewRateLimiter(a.cfg.RateLimit, log)
+	unary = append(unary, a.rateLimiter.UnaryInterceptor())
+	if a.cfg.Auth.Enabled {
+		// Validation requires a policy file with auth on, so a valid token alone never
+		// grants access to every method.
+		policy, err := auth.LoadPolicy(a.cfg.Auth.PolicyFile)
+		if err != nil {
+			a.log.Errorw("app.grpc.load_rbac_policy_failed", "err", err)
+			return err
+		}
+		unary = append(unary, grpcinterceptors.RbacUnaryInterceptor(policy, log))
+		stream = append(stream, grpcinterceptors.RbacStreamInterceptor(policy, log))
+	}
+	unary = append(unary, grpcinterceptors.TenantUnaryInterceptor(a.cfg.Tenancy, log))
+
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+
+	if a.cfg.Grpc.Tls.Enabled {
+		tlsCfg, err := tlsconfig.NewServerConfig(a.cfg.Grpc.Tls)
+		if err != nil {
+			a.log.Errorw("app.grpc.tls_config_failed", "err", err)
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+
+	srv, err := grpcserver.NewServer(a.cfg.Grpc.Port, opts, a.orderService, a.webhookService, a.inventoryService, a.productService, a.paymentService, a.dlqService)
 	if err != nil {
 		a.log.Errorw("app.grpc.server_init_failed", "err", err)
 		return err
@@ -140,7 +427,7 @@ func (a *OmsApp) startGrpcServer() {
 }
 
 func (a *OmsApp) initGrpcGateway(ctx context.Context) error {
-	srv, err := grpcgateway.NewServer(ctx, a.cfg.Http.Port, a.cfg.Grpc.Port)
+	srv, err := grpcgateway.NewServer(ctx, a.cfg.Http, a.cfg.Grpc, a.healthChecker, adminHandlers(a.runtimeSettings, a.cfg.Admin))
 	if err != nil {
 		a.log.Errorw("app.http.gateway_init_failed", "err", err)
 		return err
@@ -157,3 +444,46 @@ func (a *OmsApp) startGrpcGateway() {
 		}
 	}()
 }
+
+func (a *OmsApp) runtimeSettingsFrom(cfg *config.ServerConfig) runtimesettings.Settings {
+	rateLimit := cfg.RateLimit
+	s := runtimesettings.Settings{
+		LogLevel:  cfg.Logging.Level,
+		RateLimit: &rateLimit,
+	}
+	if a.sagaConsumer != nil {
+		s.Consumers = map[string]runtimesettings.ConsumerBatch{
+			"Saga": consumerBatch(cfg.SagaRabbitMqConsumerSettings),
+		}
+	}
+	return s
+}
+
+func (a *OmsApp) initRuntimeSettings() {
+	consumers := map[string]*rabbitmqconsumer.Consumer{}
+	if a.sagaConsumer != nil {
+		consumers["Saga"] = a.sagaConsumer
+	}
+	a.runtimeSettings = runtimesettings.NewManager(a.runtimeSettingsFrom(a.cfg), func(s runtimesettings.Settings) error {
+		if err := applyCommonRuntimeSettings(s, consumers); err != nil {
+			return err
+		}
+		a.rateLimiter.Update(*s.RateLimit)
+		return nil
+	}, a.log)
+}
+
+func (a *OmsApp) watchConfig() error {
+	err := config.WatchServerConfig(func(cfg *config.ServerConfig) {
+		if err := a.runtimeSettings.FileChanged(a.runtimeSettingsFrom(cfg)); err != nil {
+			a.log.Errorw("app.runtime_settings_apply_failed", "err", err)
+		}
+	}, func(err error) {
+		a.log.Errorw("app.config_reload_failed", "err", err)
+	})
+	if err != nil {
+		a.log.Errorw("app.config_watch_failed", "err", err)
+		return err
+	}
+	return nil
+}
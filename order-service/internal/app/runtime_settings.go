@@ -0,0 +1,39 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	rabbitmqconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer/rabbitmq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/logger"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/runtimesettings"
+)
+
+const adminSettingsPath = "/admin/settings"
+
+func consumerBatch(cfg settings.RabbitMqConsumerSettings) runtimesettings.ConsumerBatch {
+	return runtimesettings.ConsumerBatch{
+		BatchSize:           cfg.BatchSize,
+		BatchTimeoutSeconds: cfg.BatchTimeoutSeconds,
+	}
+}
+
+func applyCommonRuntimeSettings(s runtimesettings.Settings, consumers map[string]*rabbitmqconsumer.Consumer) error {
+	if err := logger.SetLevel(s.LogLevel); err != nil {
+		return err
+	}
+	for name, batch := range s.Consumers {
+		if c, ok := consumers[name]; ok {
+			c.UpdateBatchSettings(batch.BatchSize, batch.BatchTimeoutSeconds)
+		}
+	}
+	return nil
+}
+
+// adminHandlers is empty when no admin token is configured, which keeps the endpoint off.
+func adminHandlers(m *runtimesettings.Manager, cfg settings.AdminSettings) map[string]http.Handler {
+	if cfg.Token == "" {
+		return nil
+	}
+	return map[string]http.Handler{adminSettingsPath: m.Handler(cfg.Token)}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/runtimesettings"
+	"go.uber.org/zap"
+)
+
+func TestAdminHandlersAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		method   string
+		header   *string
+		body     string
+		wantCode int
+	}{
+		{"disabled without a token", "", http.MethodGet, nil, "", http.StatusNotFound},
+		{"disabled without a token even for an empty header", "", http.MethodGet, ptr(""), "", http.StatusNotFound},
+		{"missing token", "s3cret", http.MethodGet, nil, "", http.StatusUnauthorized},
+		{"empty token", "s3cret", http.MethodGet, ptr(""), "", http.StatusUnauthorized},
+		{"wrong token", "s3cret", http.MethodGet, ptr("guess"), "", http.StatusUnauthorized},
+		{"wrong token can't update", "s3cret", http.MethodPut, ptr("guess"), `{"LogLevel":"debug"}`, http.StatusUnauthorized},
+		{"right token reads", "s3cret", http.MethodGet, ptr("s3cret"), "", http.StatusOK},
+		{"right token updates", "s3cret", http.MethodPut, ptr("s3cret"), `{"LogLevel":"debug"}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var applied []runtimesettings.Settings
+			m := runtimesettings.NewManager(runtimesettings.Settings{LogLevel: "info"}, func(s runtimesettings.Settings) error {
+				applied = append(applied, s)
+				return nil
+			}, zap.NewNop().Sugar())
+			mux := http.NewServeMux()
+			for path, h := range adminHandlers(m, settings.AdminSettings{Token: tt.token}) {
+				mux.Handle(path, h)
+			}
+
+			req := httptest.NewRequest(tt.method, adminSettingsPath, strings.NewReader(tt.body))
+			if tt.header != nil {
+				req.Header.Set(runtimesettings.ADMIN_TOKEN_HEADER, *tt.header)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK && len(applied) != 0 {
+				t.Errorf("settings applied without access: %+v", applied)
+			}
+			if tt.wantCode == http.StatusOK && tt.method == http.MethodPut && m.Current().LogLevel != "debug" {
+				t.Errorf("log level = %s, want debug", m.Current().LogLevel)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
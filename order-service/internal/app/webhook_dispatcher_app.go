@@ -0,0 +1,198 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	httpclient "github.com/ZaiiiRan/backend_labs/order-service/internal/client/http"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/consumer"
+	rabbitmqconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer/rabbitmq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/rabbitmq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/health"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/logger"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/metrics"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/runtimesettings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tracing"
+	"go.uber.org/zap"
+)
+
+type WebhookDispatcherApp struct {
+	cfg *config.WebhookDispatcherConfig
+	log *zap.SugaredLogger
+
+	shutdownTracing func(context.Context) error
+
+	postgresClient *postgres.PostgresClient
+	rabbitmqClient *rabbitmq.RabbitMqClient
+
+	webhookConsumer *rabbitmqconsumer.Consumer
+
+	metricsServer *metrics.Server
+
+	runtimeSettings *runtimesettings.Manager
+}
+
+func NewWebhookDispatcherApp() (*WebhookDispatcherApp, error) {
+	cfg, err := config.LoadWebhookDispatcherConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := logger.NewLogger(cfg.Logging)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookDispatcherApp{cfg: cfg, log: log}, nil
+}
+
+func (a *WebhookDispatcherApp) Run(ctx context.Context) error {
+	if err := a.initTracing(ctx); err != nil {
+		return err
+	}
+	if err := a.initPostgresClient(ctx); err != nil {
+		return err
+	}
+	if err := a.initWebhookConsumer(); err != nil {
+		return err
+	}
+	a.startWebhookConsumer()
+	a.initRuntimeSettings()
+	a.startMetricsServer()
+	if err := a.watchConfig(); err != nil {
+		return err
+	}
+
+	a.log.Infow("app.started")
+	return nil
+}
+
+func (a *WebhookDispatcherApp) Stop() {
+	a.log.Infow("app.stopping")
+
+	drainConsumer(a.log, a.webhookConsumer)
+	a.rabbitmqClient.Close()
+	a.postgresClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	a.metricsServer.Stop(ctx)
+	if err := a.shutdownTracing(ctx); err != nil {
+		a.log.Warnw("app.tracing_shutdown_failed", "err", err)
+	}
+
+	a.log.Infow("app.stopped")
+}
+
+func (a *WebhookDispatcherApp) initTracing(ctx context.Context) error {
+	shutdown, err := tracing.Init(ctx, a.cfg.Tracing, "order-service-webhook-dispatcher")
+	if err != nil {
+		a.log.Errorw("app.tracing_init_failed", "err", err)
+		return err
+	}
+	a.shutdownTracing = shutdown
+	return nil
+}
+
+func (a *WebhookDispatcherApp) initPostgresClient(ctx context.Context) error {
+	pgClient, err := postgres.NewPostgresClient(ctx, a.cfg.DbSettings.ConnectionString)
+	if err != nil {
+		a.log.Errorw("app.postgres_connect_failed", "err", err)
+		return err
+	}
+	a.postgresClient = pgClient
+
+	if err := metrics.RegisterPgxPool(pgClient.Stat); err != nil {
+		a.log.Errorw("app.register_pgxpool_metrics_failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (a *WebhookDispatcherApp) initWebhookConsumer() error {
+	rabbitMqClient, err := rabbitmq.NewRabbitMqClient(&a.cfg.WebhookRabbitMqConsumerSettings.RabbitMqSettings)
+	if err != nil {
+		a.log.Errorw("app.rabbitmq_connect_failed", "err", err)
+		return err
+	}
+	a.rabbitmqClient = rabbitMqClient
+
+	webhookClient := httpclient.NewWebhookClient(time.Duration(a.cfg.Dispatcher.RequestTimeoutSeconds)*time.Second, a.cfg.Dispatcher.AllowPrivateTargets)
+	processor := consumer.NewWebhookMessageProcessor(&a.cfg.Dispatcher, a.postgresClient, webhookClient, a.log.Named("processor"))
+
+	webhookConsumer, err := rabbitmqconsumer.NewConsumer(&a.cfg.WebhookRabbitMqConsumerSettings, a.rabbitmqClient, processor, a.log.Named("consumer"))
+	if err != nil {
+		a.log.Errorw("app.create_webhook_consumer_failed", "err", err)
+		return err
+	}
+	a.webhookConsumer = webhookConsumer
+	return nil
+}
+
+func (a *WebhookDispatcherApp) startWebhookConsumer() {
+	go func() {
+		if err := a.webhookConsumer.Start(); err != nil && !errors.Is(err, rabbitmqconsumer.ErrStopped) {
+			a.log.Fatalw("app.start_webhook_consumer_failed", "err", err)
+		}
+	}()
+}
+
+func (a *WebhookDispatcherApp) startMetricsServer() {
+	checker := health.NewChecker()
+	checker.Add("postgres", a.postgresClient.Ping)
+	checker.Add("webhook_consumer", func(context.Context) error {
+		return a.webhookConsumer.Ping()
+	})
+
+	handlers := map[string]http.Handler{
+		"/healthz": checker.LivenessHandler(),
+		"/readyz":  checker.ReadinessHandler(),
+	}
+	for path, h := range adminHandlers(a.runtimeSettings, a.cfg.Admin) {
+		handlers[path] = h
+	}
+	a.metricsServer = metrics.NewServer(a.cfg.Metrics.Port, handlers)
+	go func() {
+		a.log.Infow("app.metrics.serve_start", "port", a.cfg.Metrics.Port)
+		if err := a.metricsServer.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.log.Fatalw("app.metrics.serve_error", "err", err)
+		}
+	}()
+}
+
+func runtimeSettingsFromWebhookDispatcherConfig(cfg *config.WebhookDispatcherConfig) runtimesettings.Settings {
+	return runtimesettings.Settings{
+		LogLevel: cfg.Logging.Level,
+		Consumers: map[string]runtimesettings.ConsumerBatch{
+			"Webhook": consumerBatch(cfg.WebhookRabbitMqConsumerSettings),
+		},
+	}
+}
+
+func (a *WebhookDispatcherApp) initRuntimeSettings() {
+	consumers := map[string]*rabbitmqconsumer.Consumer{
+		"Webhook": a.webhookConsumer,
+	}
+	a.runtimeSettings = runtimesettings.NewManager(runtimeSettingsFromWebhookDispatcherConfig(a.cfg), func(s runtimesettings.Settings) error {
+		return applyCommonRuntimeSettings(s, consumers)
+	}, a.log)
+}
+
+func (a *WebhookDispatcherApp) watchConfig() error {
+	err := config.WatchWebhookDispatcherConfig(func(cfg *config.WebhookDispatcherConfig) {
+		if err := a.runtimeSettings.FileChanged(runtimeSettingsFromWebhookDispatcherConfig(cfg)); err != nil {
+			a.log.Errorw("app.runtime_settings_apply_failed", "err", err)
+		}
+	}, func(err error) {
+		a.log.Errorw("app.config_reload_failed", "err", err)
+	})
+	if err != nil {
+		a.log.Errorw("app.config_watch_failed", "err", err)
+		return err
+	}
+	return nil
+}
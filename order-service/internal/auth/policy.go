@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/spf13/viper"
+)
+
+type RolePolicy struct {
+	// Methods are full gRPC method names; "/package.Service/*" matches the whole service.
+	Methods []string `mapstructure:"Methods"`
+	// OwnOrdersOnly limits the role to orders of the customer_id claim.
+	OwnOrdersOnly   bool     `mapstructure:"OwnOrdersOnly"`
+	AllowedStatuses []string `mapstructure:"AllowedStatuses"`
+}
+
+type Policy struct {
+	Roles map[string]RolePolicy `mapstructure:"Roles"`
+}
+
+func LoadPolicy(path string) (*Policy, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read policy: %w", err)
+	}
+
+	var p Policy
+	if err := v.Unmarshal(&p); err != nil {
+		return nil, fmt.Errorf("unmarshal policy: %w", err)
+	}
+	if len(p.Roles) == 0 {
+		return nil, fmt.Errorf("policy %s defines no roles", path)
+	}
+	return &p, nil
+}
+
+// Authorize reports whether any of the roles may call method. When every granting role
+// is limited to own orders, the returned scope carries those limits; nil means unrestricted.
+func (p *Policy) Authorize(roles []string, method string, customerID int64) (bool, *utils.AccessScope) {
+	allowed := false
+	var statuses []string
+	for _, role := range roles {
+		rp, ok := p.Roles[strings.ToLower(role)]
+		if !ok || !matchesMethod(rp.Methods, method) {
+			continue
+		}
+		if !rp.OwnOrdersOnly {
+			return true, nil
+		}
+		allowed = true
+		for _, st := range rp.AllowedStatuses {
+			if !slices.Contains(statuses, st) {
+				statuses = append(statuses, st)
+			}
+		}
+	}
+	if !allowed || customerID <= 0 {
+		return false, nil
+	}
+
+	return true, &utils.AccessScope{CustomerID: customerID, AllowedStatuses: statuses}
+}
+
+func matchesMethod(patterns []string, method string) bool {
+	for _, p := range patterns {
+		if p == method || p == "*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(p, "*"); ok && strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
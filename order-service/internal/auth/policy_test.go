@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const (
+	batchCreate = "/order_service.v1.OrderService/BatchCreate"
+	queryOrders = "/order_service.v1.OrderService/QueryOrders"
+	auditCreate = "/order_service.v1.OrderService/AuditLogOrderBatchCreate"
+	replayDlq   = "/order_service.v1.DlqService/ReplayDlqMessages"
+)
+
+func testPolicy() *Policy {
+	return &Policy{Roles: map[string]RolePolicy{
+		"customer": {
+			Methods:         []string{batchCreate, queryOrders},
+			OwnOrdersOnly:   true,
+			AllowedStatuses: []string{"cancelled"},
+		},
+		"support": {
+			Methods:         []string{queryOrders},
+			OwnOrdersOnly:   true,
+			AllowedStatuses: []string{"cancelled", "delivered"},
+		},
+		"operator": {Methods: []string{"/order_service.v1.OrderService/*"}},
+		"admin":    {Methods: []string{"/order_service.v1.DlqService/*"}},
+		"root":     {Methods: []string{"*"}},
+	}}
+}
+
+func TestMatchesMethod(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		method   string
+		want     bool
+	}{
+		{[]string{batchCreate}, batchCreate, true},
+		{[]string{batchCreate}, queryOrders, false},
+		{[]string{"/order_service.v1.OrderService/*"}, batchCreate, true},
+		{[]string{"/order_service.v1.OrderService/*"}, replayDlq, false},
+		{[]string{"/order_service.v1.Order*"}, batchCreate, true},
+		{[]string{"*"}, replayDlq, true},
+		{[]string{"/order_service.v1.OrderService/batchcreate"}, batchCreate, false},
+		{nil, batchCreate, false},
+	}
+	for _, tt := range tests {
+		if got := matchesMethod(tt.patterns, tt.method); got != tt.want {
+			t.Errorf("matchesMethod(%v, %s) = %v, want %v", tt.patterns, tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name         string
+		roles        []string
+		method       string
+		customerID   int64
+		wantAllowed  bool
+		wantScoped   bool
+		wantStatuses []string
+	}{
+		{"no roles", nil, queryOrders, 42, false, false, nil},
+		{"unknown role", []string{"guest"}, queryOrders, 42, false, false, nil},
+		{"method not granted", []string{"customer"}, auditCreate, 42, false, false, nil},
+		{"dlq needs admin", []string{"operator"}, replayDlq, 0, false, false, nil},
+		{"service wildcard", []string{"operator"}, auditCreate, 0, true, false, nil},
+		{"admin", []string{"admin"}, replayDlq, 0, true, false, nil},
+		{"global wildcard", []string{"root"}, replayDlq, 0, true, false, nil},
+		{"role names are case insensitive", []string{"Admin"}, replayDlq, 0, true, false, nil},
+		{"own orders only", []string{"customer"}, batchCreate, 42, true, true, []string{"cancelled"}},
+		{"own orders only without customer id", []string{"customer"}, batchCreate, 0, false, false, nil},
+		{"statuses of every scoped role", []string{"customer", "support"}, queryOrders, 42, true, true, []string{"cancelled", "delivered"}},
+		{"scoped role doesn't grant the method", []string{"support"}, batchCreate, 42, false, false, nil},
+		{"unrestricted role wins", []string{"customer", "operator"}, batchCreate, 42, true, false, nil},
+	}
+	p := testPolicy()
+	for _, tt := range tests {
+		allowed, scope := p.Authorize(tt.roles, tt.method, tt.customerID)
+		if allowed != tt.wantAllowed || (scope != nil) != tt.wantScoped {
+			t.Errorf("%s: Authorize = %v, %+v; want %v, scoped %v", tt.name, allowed, scope, tt.wantAllowed, tt.wantScoped)
+			continue
+		}
+		if scope != nil && (scope.CustomerID != tt.customerID || !slices.Equal(scope.AllowedStatuses, tt.wantStatuses)) {
+			t.Errorf("%s: scope = %+v, want customer %d with %v", tt.name, scope, tt.customerID, tt.wantStatuses)
+		}
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	p, err := LoadPolicy(write("policy.yaml", "Roles:\n  admin:\n    Methods:\n      - /order_service.v1.DlqService/*\n"))
+	if err != nil {
+		t.Fatalf("LoadPolicy = %v", err)
+	}
+	if allowed, _ := p.Authorize([]string{"admin"}, replayDlq, 0); !allowed {
+		t.Error("loaded policy doesn't grant admin the dlq service")
+	}
+
+	if _, err := LoadPolicy(write("empty.yaml", "Roles: {}\n")); err == nil {
+		t.Error("policy without roles: want error")
+	}
+	if _, err := LoadPolicy(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("missing policy: want error")
+	}
+}
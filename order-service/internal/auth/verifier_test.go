@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://auth.local"
+	testAudience = "order-service"
+)
+
+type testKeys struct {
+	ec  *ecdsa.PrivateKey
+	rsa *rsa.PrivateKey
+}
+
+func b64(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+// newTestVerifier writes a JWKS with an EC key "ec" and an RSA key "rsa" and builds a
+// verifier that expects testIssuer and testAudience.
+func newTestVerifier(t *testing.T, clockSkewSeconds int) (*Verifier, testKeys) {
+	t.Helper()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks, err := json.Marshal(map[string][]jwk{"keys": {
+		{Kid: "ec", Kty: "EC", Crv: "P-256", X: b64(ecKey.X), Y: b64(ecKey.Y), Use: "sig"},
+		{Kid: "rsa", Kty: "RSA", N: b64(rsaKey.N), E: b64(big.NewInt(int64(rsaKey.E)))},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := NewVerifier(&settings.AuthSettings{
+		JwksFile:         path,
+		Issuer:           testIssuer,
+		Audience:         testAudience,
+		ClockSkewSeconds: clockSkewSeconds,
+	})
+	if err != nil {
+		t.Fatalf("NewVerifier = %v", err)
+	}
+	return v, testKeys{ec: ecKey, rsa: rsaKey}
+}
+
+func validClaims() Claims {
+	now := time.Now()
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "order-generator",
+			Issuer:    testIssuer,
+			Audience:  jwt.ClaimStrings{testAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Roles:      []string{"customer"},
+		CustomerID: 42,
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	raw, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return raw
+}
+
+func TestVerify(t *testing.T) {
+	v, keys := newTestVerifier(t, 30)
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		token  func() string
+		wantOk bool
+	}{
+		{"valid ES256", func() string {
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, validClaims())
+		}, true},
+		{"valid RS256", func() string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, validClaims())
+		}, true},
+		{"wrong issuer", func() string {
+			c := validClaims()
+			c.Issuer = "https://evil.local"
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, false},
+		{"missing issuer", func() string {
+			c := validClaims()
+			c.Issuer = ""
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, false},
+		{"wrong audience", func() string {
+			c := validClaims()
+			c.Audience = jwt.ClaimStrings{"other-service"}
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, false},
+		{"audience among several", func() string {
+			c := validClaims()
+			c.Audience = jwt.ClaimStrings{"other-service", testAudience}
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, true},
+		{"expired", func() string {
+			c := validClaims()
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Hour))
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, false},
+		{"expired within clock skew", func() string {
+			c := validClaims()
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-10 * time.Second))
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, true},
+		{"expired beyond clock skew", func() string {
+			c := validClaims()
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, false},
+		{"not yet valid within clock skew", func() string {
+			c := validClaims()
+			c.NotBefore = jwt.NewNumericDate(now.Add(10 * time.Second))
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, true},
+		{"not yet valid beyond clock skew", func() string {
+			c := validClaims()
+			c.NotBefore = jwt.NewNumericDate(now.Add(time.Minute))
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, false},
+		{"no expiry", func() string {
+			c := validClaims()
+			c.ExpiresAt = nil
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, false},
+		{"no subject", func() string {
+			c := validClaims()
+			c.Subject = ""
+			return sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)
+		}, false},
+		{"unknown kid", func() string {
+			return sign(t, jwt.SigningMethodES256, "other", keys.ec, validClaims())
+		}, false},
+		{"signed by another key", func() string {
+			other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			return sign(t, jwt.SigningMethodES256, "ec", other, validClaims())
+		}, false},
+		{"alg none", func() string {
+			return sign(t, jwt.SigningMethodNone, "ec", jwt.UnsafeAllowNoneSignatureType, validClaims())
+		}, false},
+		{"HS256 keyed with the public key", func() string {
+			return sign(t, jwt.SigningMethodHS256, "rsa", keys.rsa.N.Bytes(), validClaims())
+		}, false},
+		{"RS384 with a known key", func() string {
+			return sign(t, jwt.SigningMethodRS384, "rsa", keys.rsa, validClaims())
+		}, false},
+		{"garbage", func() string { return "not.a.token" }, false},
+	}
+	for _, tt := range tests {
+		claims, err := v.Verify(tt.token())
+		if tt.wantOk {
+			if err != nil {
+				t.Errorf("%s: Verify = %v", tt.name, err)
+			} else if claims.Subject != "order-generator" || claims.CustomerID != 42 || len(claims.Roles) != 1 {
+				t.Errorf("%s: claims = %+v", tt.name, claims)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: Verify = %v, want ErrInvalidToken", tt.name, err)
+		}
+	}
+}
+
+func TestVerifyWithoutClockSkew(t *testing.T) {
+	v, keys := newTestVerifier(t, 0)
+	c := validClaims()
+	c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-2 * time.Second))
+
+	if _, err := v.Verify(sign(t, jwt.SigningMethodES256, "ec", keys.ec, c)); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify of a just expired token = %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,75 @@
+package bllerrors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const transientRetryAfter = 200 * time.Millisecond
+
+// Classify turns infrastructure errors into typed errors. Errors that are already typed,
+// or that it doesn't recognize, are returned unchanged.
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if classified := classifyPgError(pgErr, err); classified != nil {
+			return classified
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return &Error{Kind: KIND_CANCELED, Reason: "CANCELED", Message: "request canceled", Err: err}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Error{Kind: KIND_DEADLINE_EXCEEDED, Reason: "DEADLINE_EXCEEDED", Message: "request timed out", Err: err}
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return Unavailable("DATABASE_UNAVAILABLE", time.Second, err)
+	}
+	return err
+}
+
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html.
+func classifyPgError(pgErr *pgconn.PgError, err error) error {
+	switch pgErr.Code {
+	case "23505": // unique_violation
+		return (&Error{Kind: KIND_CONFLICT, Reason: "ALREADY_EXISTS", Message: "resource already exists", Err: err}).
+			With("constraint", pgErr.ConstraintName)
+	case "23503": // foreign_key_violation
+		return (&Error{Kind: KIND_PRECONDITION_FAILED, Reason: "REFERENCED_RESOURCE_MISSING", Message: "referenced resource does not exist", Err: err}).
+			With("constraint", pgErr.ConstraintName)
+	case "23514": // check_violation
+		return (&Error{Kind: KIND_PRECONDITION_FAILED, Reason: "CONSTRAINT_VIOLATED", Message: "operation violates a data constraint", Err: err}).
+			With("constraint", pgErr.ConstraintName)
+	case "40001", "40P01", "55P03": // serialization_failure, deadlock_detected, lock_not_available
+		return Unavailable("CONCURRENT_UPDATE", transientRetryAfter, err)
+	case "57014": // query_canceled, also raised by statement_timeout
+		return &Error{Kind: KIND_DEADLINE_EXCEEDED, Reason: "QUERY_CANCELED", Message: "request timed out", Err: err}
+	case "53300", "57P01", "57P02", "57P03": // too_many_connections, admin/crash shutdown, cannot_connect_now
+		return Unavailable("DATABASE_UNAVAILABLE", time.Second, err)
+	}
+	if strings.HasPrefix(pgErr.Code, "08") { // connection_exception class
+		return Unavailable("DATABASE_UNAVAILABLE", time.Second, err)
+	}
+	return nil
+}
+
+// IsUniqueViolation reports whether err is a unique_violation of the named constraint or index.
+func IsUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraint
+}
@@ -0,0 +1,71 @@
+package bllerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestClassify(t *testing.T) {
+	unknown := errors.New("boom")
+	typed := NotFound("ORDER_NOT_FOUND", "order %d not found", 1)
+	tests := []struct {
+		name       string
+		err        error
+		wantKind   Kind
+		wantReason string
+		wantRetry  bool
+	}{
+		{"unique violation", &pgconn.PgError{Code: "23505", ConstraintName: "orders_pkey"}, KIND_CONFLICT, "ALREADY_EXISTS", false},
+		{"foreign key violation", &pgconn.PgError{Code: "23503"}, KIND_PRECONDITION_FAILED, "REFERENCED_RESOURCE_MISSING", false},
+		{"check violation", &pgconn.PgError{Code: "23514"}, KIND_PRECONDITION_FAILED, "CONSTRAINT_VIOLATED", false},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, KIND_UNAVAILABLE, "CONCURRENT_UPDATE", true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, KIND_UNAVAILABLE, "CONCURRENT_UPDATE", true},
+		{"lock not available", &pgconn.PgError{Code: "55P03"}, KIND_UNAVAILABLE, "CONCURRENT_UPDATE", true},
+		{"statement timeout", &pgconn.PgError{Code: "57014"}, KIND_DEADLINE_EXCEEDED, "QUERY_CANCELED", false},
+		{"too many connections", &pgconn.PgError{Code: "53300"}, KIND_UNAVAILABLE, "DATABASE_UNAVAILABLE", true},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, KIND_UNAVAILABLE, "DATABASE_UNAVAILABLE", true},
+		{"connection exception class", &pgconn.PgError{Code: "08006"}, KIND_UNAVAILABLE, "DATABASE_UNAVAILABLE", true},
+		{"wrapped pg error", fmt.Errorf("insert orders: %w", &pgconn.PgError{Code: "23505"}), KIND_CONFLICT, "ALREADY_EXISTS", false},
+		{"context canceled", fmt.Errorf("query: %w", context.Canceled), KIND_CANCELED, "CANCELED", false},
+		{"context deadline", context.DeadlineExceeded, KIND_DEADLINE_EXCEEDED, "DEADLINE_EXCEEDED", false},
+		{"connect error", &pgconn.ConnectError{}, KIND_UNAVAILABLE, "DATABASE_UNAVAILABLE", true},
+		{"typed error", typed, KIND_NOT_FOUND, "ORDER_NOT_FOUND", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e *Error
+			if !errors.As(Classify(tt.err), &e) {
+				t.Fatalf("Classify(%v) is not typed", tt.err)
+			}
+			if e.Kind != tt.wantKind || e.Reason != tt.wantReason {
+				t.Errorf("Classify(%v) = %s %s, want %s %s", tt.err, e.Kind, e.Reason, tt.wantKind, tt.wantReason)
+			}
+			if (e.RetryAfter > 0) != tt.wantRetry {
+				t.Errorf("RetryAfter = %v, want retry %v", e.RetryAfter, tt.wantRetry)
+			}
+		})
+	}
+
+	for _, err := range []error{nil, unknown, &pgconn.PgError{Code: "42P01"}} {
+		if got := Classify(err); got != err {
+			t.Errorf("Classify(%v) = %v, want it unchanged", err, got)
+		}
+	}
+	if got := Classify(typed); got != error(typed) {
+		t.Errorf("Classify of a typed error = %v, want it unchanged", got)
+	}
+}
+
+func TestClassifyKeepsConstraint(t *testing.T) {
+	e := As(&pgconn.PgError{Code: "23505", ConstraintName: "idx_payment_live_order_id"})
+	if got := e.Metadata["constraint"]; got != "idx_payment_live_order_id" {
+		t.Errorf("constraint metadata = %q", got)
+	}
+	if As(errors.New("boom")).Kind != KIND_INTERNAL {
+		t.Error("unclassified error is not internal")
+	}
+}
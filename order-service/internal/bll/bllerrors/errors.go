@@ -0,0 +1,118 @@
+package bllerrors
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Kind int
+
+const (
+	KIND_INTERNAL Kind = iota
+	KIND_NOT_FOUND
+	KIND_CONFLICT
+	KIND_PRECONDITION_FAILED
+	KIND_UNAVAILABLE
+	KIND_CANCELED
+	KIND_DEADLINE_EXCEEDED
+	KIND_ABORTED
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KIND_NOT_FOUND:
+		return "NotFound"
+	case KIND_CONFLICT:
+		return "Conflict"
+	case KIND_PRECONDITION_FAILED:
+		return "PreconditionFailed"
+	case KIND_UNAVAILABLE:
+		return "Unavailable"
+	case KIND_CANCELED:
+		return "Canceled"
+	case KIND_DEADLINE_EXCEEDED:
+		return "DeadlineExceeded"
+	case KIND_ABORTED:
+		return "Aborted"
+	default:
+		return "Internal"
+	}
+}
+
+// Error is a business error that the transport layer can map without inspecting messages.
+// Reason is a stable UPPER_SNAKE_CASE identifier clients can branch on; Message is safe
+// to show to the caller. Err, if set, is only logged.
+type Error struct {
+	Kind       Kind
+	Reason     string
+	Message    string
+	Metadata   map[string]string
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Kind, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Kind, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) With(key, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+	e.Metadata[key] = value
+	return e
+}
+
+func NotFound(reason, format string, args ...any) *Error {
+	return &Error{Kind: KIND_NOT_FOUND, Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+func Conflict(reason, format string, args ...any) *Error {
+	return &Error{Kind: KIND_CONFLICT, Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+func PreconditionFailed(reason, format string, args ...any) *Error {
+	return &Error{Kind: KIND_PRECONDITION_FAILED, Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// Aborted means the request arrived before something it depends on; retrying it later
+// is expected to succeed.
+func Aborted(reason, format string, args ...any) *Error {
+	return &Error{Kind: KIND_ABORTED, Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+func Unavailable(reason string, retryAfter time.Duration, err error) *Error {
+	return &Error{
+		Kind:       KIND_UNAVAILABLE,
+		Reason:     reason,
+		Message:    "service temporarily unavailable, retry later",
+		RetryAfter: retryAfter,
+		Err:        err,
+	}
+}
+
+// As classifies err and returns it as an *Error. Errors that can't be classified come
+// back as KIND_INTERNAL wrapping the original.
+func As(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(Classify(err), &e) {
+		return e
+	}
+	return &Error{Kind: KIND_INTERNAL, Reason: "INTERNAL", Message: "Internal server error", Err: err}
+}
+
+func IsKind(err error, kind Kind) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Kind == kind
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+)
+
+type productEntry struct {
+	product   bll.Product
+	expiresAt time.Time
+}
+
+// ProductCache keeps catalog products in memory. Entries are dropped on local changes
+// and expire after ttl so that changes made by other replicas are picked up.
+type ProductCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[int64]productEntry
+}
+
+func NewProductCache(ttl time.Duration) *ProductCache {
+	return &ProductCache{
+		ttl:     ttl,
+		entries: make(map[int64]productEntry),
+	}
+}
+
+func (c *ProductCache) Get(ids []int64) (map[int64]bll.Product, []int64) {
+	now := time.Now()
+	found := make(map[int64]bll.Product, len(ids))
+	seen := make(map[int64]struct{}, len(ids))
+	var missing []int64
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		e, ok := c.entries[id]
+		if !ok || now.After(e.expiresAt) {
+			missing = append(missing, id)
+			continue
+		}
+		found[id] = e.product
+	}
+	return found, missing
+}
+
+func (c *ProductCache) Set(products []bll.Product) {
+	expiresAt := time.Now().Add(c.ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range products {
+		c.entries[p.ID] = productEntry{product: p, expiresAt: expiresAt}
+	}
+}
+
+func (c *ProductCache) Invalidate(ids []int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range ids {
+		delete(c.entries, id)
+	}
+}
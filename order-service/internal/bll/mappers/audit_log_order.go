@@ -16,6 +16,8 @@ func DalAuditLogOrderToBll(i dal.V1AuditLogOrderDal) bll.AuditLogOrder {
 		OrderStatus: bll.StringToOrderStatus(i.OrderStatus),
 		CreatedAt:   i.CreatedAt,
 		UpdatedAt:   i.UpdatedAt,
+		Actor:       i.Actor,
+		TenantID:    i.TenantID,
 	}
 }
 
@@ -28,6 +30,8 @@ func BllAuditLogOrderToDal(i bll.AuditLogOrder) dal.V1AuditLogOrderDal {
 		OrderStatus: i.OrderStatus.String(),
 		CreatedAt:   i.CreatedAt,
 		UpdatedAt:   i.UpdatedAt,
+		Actor:       i.Actor,
+		TenantID:    i.TenantID,
 	}
 }
 
@@ -40,6 +44,7 @@ func PbAuditLogOrderToBll(i *pb.LogOrder) bll.AuditLogOrder {
 		OrderStatus: bll.StringToOrderStatus(i.OrderStatus),
 		CreatedAt:   i.CreatedAt.AsTime(),
 		UpdatedAt:   i.UpdatedAt.AsTime(),
+		Actor:       i.Actor,
 	}
 }
 
@@ -55,5 +60,6 @@ func BllAuditLogOrderToPb(i bll.AuditLogOrder) *pb.LogOrder {
 		OrderStatus: i.OrderStatus.String(),
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
+		Actor:       i.Actor,
 	}
 }
@@ -19,6 +19,7 @@ func DalOrderToBll(o dal.V1OrderDal, items []bll.OrderItemUnit) bll.OrderUnit {
 		UpdatedAt:       o.UpdatedAt,
 		OrderItems:      items,
 		Status:          bll.StringToOrderStatus(o.Status),
+		TenantID:        o.TenantID,
 	}
 }
 
@@ -32,6 +33,7 @@ func BllOrderToDal(o bll.OrderUnit) dal.V1OrderDal {
 		CreatedAt:       o.CreatedAt.UTC(),
 		UpdatedAt:       o.UpdatedAt.UTC(),
 		Status:          o.Status.String(),
+		TenantID:        o.TenantID,
 	}
 }
 
@@ -91,5 +93,6 @@ func BllOrderToOrderCreatedMessage(o bll.OrderUnit) *messages.OrderCreatedMessag
 		CreatedAt:       o.CreatedAt,
 		UpdatedAt:       o.UpdatedAt,
 		OrderItems:      items,
+		TenantId:        o.TenantID,
 	}
 }
@@ -0,0 +1,64 @@
+package mappers
+
+import (
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func DalPaymentToBll(p dal.V1PaymentDal) bll.Payment {
+	return bll.Payment{
+		ID:                p.ID,
+		OrderID:           p.OrderID,
+		Provider:          p.Provider,
+		ProviderPaymentID: p.ProviderPaymentID,
+		AmountCents:       p.AmountCents,
+		Currency:          p.Currency,
+		Status:            bll.StringToPaymentStatus(p.Status),
+		FailureReason:     p.FailureReason,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+		TenantID:          p.TenantID,
+	}
+}
+
+func BllPaymentToDal(p bll.Payment) dal.V1PaymentDal {
+	return dal.V1PaymentDal{
+		ID:                p.ID,
+		OrderID:           p.OrderID,
+		Provider:          p.Provider,
+		ProviderPaymentID: p.ProviderPaymentID,
+		AmountCents:       p.AmountCents,
+		Currency:          p.Currency,
+		Status:            p.Status.String(),
+		FailureReason:     p.FailureReason,
+		CreatedAt:         p.CreatedAt.UTC(),
+		UpdatedAt:         p.UpdatedAt.UTC(),
+		TenantID:          p.TenantID,
+	}
+}
+
+func BllPaymentToPb(p bll.Payment) *pb.Payment {
+	return &pb.Payment{
+		Id:                p.ID,
+		OrderId:           p.OrderID,
+		Provider:          p.Provider,
+		ProviderPaymentId: p.ProviderPaymentID,
+		AmountCents:       p.AmountCents,
+		Currency:          p.Currency,
+		Status:            p.Status.String(),
+		FailureReason:     p.FailureReason,
+		CreatedAt:         timestamppb.New(p.CreatedAt),
+		UpdatedAt:         timestamppb.New(p.UpdatedAt),
+	}
+}
+
+func PbQueryPaymentsToBll(q *pb.QueryPaymentsRequest) bll.QueryPaymentsModel {
+	return bll.QueryPaymentsModel{
+		IDs:      q.Ids,
+		OrderIDs: q.OrderIds,
+		Page:     int(q.Page),
+		PageSize: int(q.PageSize),
+	}
+}
@@ -0,0 +1,62 @@
+package mappers
+
+import (
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func DalProductToBll(p dal.V1ProductDal) bll.Product {
+	return bll.Product{
+		ID:            p.ID,
+		Title:         p.Title,
+		URL:           p.URL,
+		PriceCents:    p.PriceCents,
+		PriceCurrency: p.PriceCurrency,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+}
+
+func BllProductToDal(p bll.Product) dal.V1ProductDal {
+	return dal.V1ProductDal{
+		ID:            p.ID,
+		Title:         p.Title,
+		URL:           p.URL,
+		PriceCents:    p.PriceCents,
+		PriceCurrency: p.PriceCurrency,
+		CreatedAt:     p.CreatedAt.UTC(),
+		UpdatedAt:     p.UpdatedAt.UTC(),
+	}
+}
+
+func PbProductToBll(p *pb.Product) bll.Product {
+	return bll.Product{
+		ID:            p.Id,
+		Title:         p.Title,
+		URL:           p.Url,
+		PriceCents:    p.PriceCents,
+		PriceCurrency: p.PriceCurrency,
+	}
+}
+
+func BllProductToPb(p bll.Product) *pb.Product {
+	return &pb.Product{
+		Id:            p.ID,
+		Title:         p.Title,
+		Url:           p.URL,
+		PriceCents:    p.PriceCents,
+		PriceCurrency: p.PriceCurrency,
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+	}
+}
+
+func PbQueryProductsToBll(q *pb.QueryProductsRequest) bll.QueryProductsModel {
+	return bll.QueryProductsModel{
+		IDs:      q.Ids,
+		Page:     int(q.Page),
+		PageSize: int(q.PageSize),
+	}
+}
@@ -0,0 +1,53 @@
+package mappers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+)
+
+func DalSagaToBll(s dal.V1SagaDal) (bll.Saga, error) {
+	data := make(map[string]string)
+	if s.Data != "" {
+		if err := json.Unmarshal([]byte(s.Data), &data); err != nil {
+			return bll.Saga{}, fmt.Errorf("unmarshal saga data: %w", err)
+		}
+	}
+
+	return bll.Saga{
+		ID:          s.ID,
+		OrderID:     s.OrderID,
+		Status:      bll.StringToSagaStatus(s.Status),
+		Steps:       s.Steps,
+		CurrentStep: s.CurrentStep,
+		Data:        data,
+		Error:       s.Error,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}, nil
+}
+
+func BllSagaToDal(s bll.Saga) (dal.V1SagaDal, error) {
+	data := s.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return dal.V1SagaDal{}, fmt.Errorf("marshal saga data: %w", err)
+	}
+
+	return dal.V1SagaDal{
+		ID:          s.ID,
+		OrderID:     s.OrderID,
+		Status:      s.Status.String(),
+		Steps:       s.Steps,
+		CurrentStep: s.CurrentStep,
+		Data:        string(raw),
+		Error:       s.Error,
+		CreatedAt:   s.CreatedAt.UTC(),
+		UpdatedAt:   s.UpdatedAt.UTC(),
+	}, nil
+}
@@ -0,0 +1,87 @@
+package mappers
+
+import (
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func DalStockToBll(s dal.V1StockDal) bll.Stock {
+	return bll.Stock{
+		WarehouseID: s.WarehouseID,
+		ProductID:   s.ProductID,
+		Quantity:    s.Quantity,
+		Reserved:    s.Reserved,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func BllStockToDal(s bll.Stock) dal.V1StockDal {
+	return dal.V1StockDal{
+		WarehouseID: s.WarehouseID,
+		ProductID:   s.ProductID,
+		Quantity:    s.Quantity,
+		Reserved:    s.Reserved,
+		CreatedAt:   s.CreatedAt.UTC(),
+		UpdatedAt:   s.UpdatedAt.UTC(),
+	}
+}
+
+func BllStockToPb(s bll.Stock) *pb.Stock {
+	return &pb.Stock{
+		WarehouseId: s.WarehouseID,
+		ProductId:   s.ProductID,
+		Quantity:    int32(s.Quantity),
+		Reserved:    int32(s.Reserved),
+		Available:   int32(s.Available()),
+		CreatedAt:   timestamppb.New(s.CreatedAt),
+		UpdatedAt:   timestamppb.New(s.UpdatedAt),
+	}
+}
+
+func PbStockAdjustmentToBll(a *pb.StockAdjustment) bll.StockAdjustment {
+	return bll.StockAdjustment{
+		WarehouseID: a.WarehouseId,
+		ProductID:   a.ProductId,
+		Delta:       int(a.Delta),
+	}
+}
+
+func PbQueryStockToBll(q *pb.QueryStockRequest) bll.QueryStockModel {
+	return bll.QueryStockModel{
+		ProductIDs:   q.ProductIds,
+		WarehouseIDs: q.WarehouseIds,
+		Page:         int(q.Page),
+		PageSize:     int(q.PageSize),
+	}
+}
+
+func DalStockReservationToBll(r dal.V1StockReservationDal) bll.StockReservation {
+	return bll.StockReservation{
+		ID:          r.ID,
+		OrderID:     r.OrderID,
+		OrderItemID: r.OrderItemID,
+		WarehouseID: r.WarehouseID,
+		ProductID:   r.ProductID,
+		Quantity:    r.Quantity,
+		Status:      bll.StringToReservationStatus(r.Status),
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func BllStockReservationToDal(r bll.StockReservation) dal.V1StockReservationDal {
+	return dal.V1StockReservationDal{
+		ID:          r.ID,
+		OrderID:     r.OrderID,
+		OrderItemID: r.OrderItemID,
+		WarehouseID: r.WarehouseID,
+		ProductID:   r.ProductID,
+		Quantity:    r.Quantity,
+		Status:      r.Status.String(),
+		CreatedAt:   r.CreatedAt.UTC(),
+		UpdatedAt:   r.UpdatedAt.UTC(),
+	}
+}
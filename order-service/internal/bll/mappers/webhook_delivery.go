@@ -0,0 +1,66 @@
+package mappers
+
+import (
+	"time"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func DalWebhookDeliveryToBll(d dal.V1WebhookDeliveryDal) bll.WebhookDelivery {
+	return bll.WebhookDelivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventType:      bll.StringToWebhookEventType(d.EventType),
+		EventID:        d.EventID,
+		Attempt:        d.Attempt,
+		StatusCode:     d.StatusCode,
+		Success:        d.Success,
+		Error:          d.Error,
+		Duration:       time.Duration(d.DurationMs) * time.Millisecond,
+		CreatedAt:      d.CreatedAt,
+		TenantID:       d.TenantID,
+	}
+}
+
+func BllWebhookDeliveryToDal(d bll.WebhookDelivery) dal.V1WebhookDeliveryDal {
+	return dal.V1WebhookDeliveryDal{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventType:      d.EventType.String(),
+		EventID:        d.EventID,
+		Attempt:        d.Attempt,
+		StatusCode:     d.StatusCode,
+		Success:        d.Success,
+		Error:          d.Error,
+		DurationMs:     d.Duration.Milliseconds(),
+		CreatedAt:      d.CreatedAt.UTC(),
+		TenantID:       d.TenantID,
+	}
+}
+
+func PbQueryWebhookDeliveriesToBll(q *pb.QueryWebhookDeliveriesRequest) bll.QueryWebhookDeliveriesModel {
+	return bll.QueryWebhookDeliveriesModel{
+		SubscriptionIDs: q.SubscriptionIds,
+		OnlyFailed:      q.OnlyFailed,
+		Page:            int(q.Page),
+		PageSize:        int(q.PageSize),
+	}
+}
+
+func BllWebhookDeliveryToPb(d bll.WebhookDelivery) *pb.WebhookDelivery {
+	return &pb.WebhookDelivery{
+		Id:             d.ID,
+		SubscriptionId: d.SubscriptionID,
+		EventType:      d.EventType.String(),
+		EventId:        d.EventID,
+		Attempt:        int32(d.Attempt),
+		StatusCode:     int32(d.StatusCode),
+		Success:        d.Success,
+		Error:          d.Error,
+		DurationMs:     d.Duration.Milliseconds(),
+		CreatedAt:      timestamppb.New(d.CreatedAt),
+	}
+}
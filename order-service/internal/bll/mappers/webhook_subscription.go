@@ -0,0 +1,123 @@
+package mappers
+
+import (
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func DalWebhookSubscriptionToBll(s dal.V1WebhookSubscriptionDal) bll.WebhookSubscription {
+	eventTypes := make([]bll.WebhookEventType, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		eventTypes = append(eventTypes, bll.StringToWebhookEventType(t))
+	}
+
+	return bll.WebhookSubscription{
+		ID:                  s.ID,
+		URL:                 s.URL,
+		EventTypes:          eventTypes,
+		CustomerIDs:         s.CustomerIDs,
+		Secret:              s.Secret,
+		IsActive:            s.IsActive,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+		CreatedAt:           s.CreatedAt,
+		UpdatedAt:           s.UpdatedAt,
+		TenantID:            s.TenantID,
+	}
+}
+
+func BllWebhookSubscriptionToDal(s bll.WebhookSubscription) dal.V1WebhookSubscriptionDal {
+	eventTypes := make([]string, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		eventTypes = append(eventTypes, t.String())
+	}
+	customerIDs := s.CustomerIDs
+	if customerIDs == nil {
+		customerIDs = []int64{}
+	}
+
+	return dal.V1WebhookSubscriptionDal{
+		ID:                  s.ID,
+		URL:                 s.URL,
+		EventTypes:          eventTypes,
+		CustomerIDs:         customerIDs,
+		Secret:              s.Secret,
+		IsActive:            s.IsActive,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+		CreatedAt:           s.CreatedAt.UTC(),
+		UpdatedAt:           s.UpdatedAt.UTC(),
+		TenantID:            s.TenantID,
+	}
+}
+
+func PbCreateWebhookSubscriptionToBll(req *pb.CreateWebhookSubscriptionRequest) bll.WebhookSubscription {
+	eventTypes := make([]bll.WebhookEventType, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		eventTypes = append(eventTypes, bll.StringToWebhookEventType(t))
+	}
+
+	return bll.WebhookSubscription{
+		URL:         req.Url,
+		EventTypes:  eventTypes,
+		CustomerIDs: req.CustomerIds,
+		Secret:      req.Secret,
+		IsActive:    true,
+	}
+}
+
+func PbUpdateWebhookSubscriptionToBll(req *pb.UpdateWebhookSubscriptionRequest) bll.WebhookSubscription {
+	eventTypes := make([]bll.WebhookEventType, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		eventTypes = append(eventTypes, bll.StringToWebhookEventType(t))
+	}
+
+	return bll.WebhookSubscription{
+		ID:          req.Id,
+		URL:         req.Url,
+		EventTypes:  eventTypes,
+		CustomerIDs: req.CustomerIds,
+		IsActive:    req.IsActive,
+	}
+}
+
+func PbQueryWebhookSubscriptionsToBll(q *pb.QueryWebhookSubscriptionsRequest) bll.QueryWebhookSubscriptionsModel {
+	return bll.QueryWebhookSubscriptionsModel{
+		IDs:        q.Ids,
+		OnlyActive: q.OnlyActive,
+		Page:       int(q.Page),
+		PageSize:   int(q.PageSize),
+	}
+}
+
+func BllWebhookSubscriptionToPb(s bll.WebhookSubscription, withSecret bool) *pb.WebhookSubscription {
+	eventTypes := make([]string, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		eventTypes = append(eventTypes, t.String())
+	}
+
+	var disabledAt *timestamppb.Timestamp
+	if s.DisabledAt != nil {
+		disabledAt = timestamppb.New(*s.DisabledAt)
+	}
+
+	var secret string
+	if withSecret {
+		secret = s.Secret
+	}
+
+	return &pb.WebhookSubscription{
+		Id:                  s.ID,
+		Url:                 s.URL,
+		EventTypes:          eventTypes,
+		CustomerIds:         s.CustomerIDs,
+		Secret:              secret,
+		IsActive:            s.IsActive,
+		ConsecutiveFailures: int32(s.ConsecutiveFailures),
+		DisabledAt:          disabledAt,
+		CreatedAt:           timestamppb.New(s.CreatedAt),
+		UpdatedAt:           timestamppb.New(s.UpdatedAt),
+	}
+}
@@ -10,4 +10,6 @@ type AuditLogOrder struct {
 	OrderStatus OrderStatus
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	Actor       string
+	TenantID    string
 }
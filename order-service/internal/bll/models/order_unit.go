@@ -12,4 +12,5 @@ type OrderUnit struct {
 	UpdatedAt       time.Time
 	OrderItems      []OrderItemUnit
 	Status          OrderStatus
+	TenantID        string
 }
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type Payment struct {
+	ID                int64
+	OrderID           int64
+	Provider          string
+	ProviderPaymentID string
+	AmountCents       int64
+	Currency          string
+	Status            PaymentStatus
+	FailureReason     string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	TenantID          string
+}
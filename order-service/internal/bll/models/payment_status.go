@@ -0,0 +1,55 @@
+package models
+
+type PaymentStatus string
+
+const (
+	PAYMENT_STATUS_PENDING    PaymentStatus = "pending"
+	PAYMENT_STATUS_AUTHORIZED PaymentStatus = "authorized"
+	PAYMENT_STATUS_CAPTURED   PaymentStatus = "captured"
+	PAYMENT_STATUS_VOIDED     PaymentStatus = "voided"
+	PAYMENT_STATUS_REFUNDED   PaymentStatus = "refunded"
+	PAYMENT_STATUS_FAILED     PaymentStatus = "failed"
+)
+
+func (s PaymentStatus) String() string {
+	switch s {
+	case PAYMENT_STATUS_PENDING:
+		return "pending"
+	case PAYMENT_STATUS_AUTHORIZED:
+		return "authorized"
+	case PAYMENT_STATUS_CAPTURED:
+		return "captured"
+	case PAYMENT_STATUS_VOIDED:
+		return "voided"
+	case PAYMENT_STATUS_REFUNDED:
+		return "refunded"
+	case PAYMENT_STATUS_FAILED:
+		return "failed"
+	default:
+		return ""
+	}
+}
+
+// IsFinal reports whether no further provider operations are expected.
+func (s PaymentStatus) IsFinal() bool {
+	return s == PAYMENT_STATUS_VOIDED || s == PAYMENT_STATUS_REFUNDED || s == PAYMENT_STATUS_FAILED
+}
+
+func StringToPaymentStatus(str string) PaymentStatus {
+	switch str {
+	case "pending":
+		return PAYMENT_STATUS_PENDING
+	case "authorized":
+		return PAYMENT_STATUS_AUTHORIZED
+	case "captured":
+		return PAYMENT_STATUS_CAPTURED
+	case "voided":
+		return PAYMENT_STATUS_VOIDED
+	case "refunded":
+		return PAYMENT_STATUS_REFUNDED
+	case "failed":
+		return PAYMENT_STATUS_FAILED
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type Product struct {
+	ID            int64
+	Title         string
+	URL           string
+	PriceCents    int64
+	PriceCurrency string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
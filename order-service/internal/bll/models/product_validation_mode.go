@@ -0,0 +1,24 @@
+package models
+
+// ProductValidationMode controls how order items are checked against the product catalog.
+type ProductValidationMode string
+
+const (
+	// Items are accepted as sent by the client.
+	PRODUCT_VALIDATION_MODE_OFF ProductValidationMode = "off"
+	// Unknown products and price mismatches are rejected; title and url are taken from the catalog.
+	PRODUCT_VALIDATION_MODE_VERIFY ProductValidationMode = "verify"
+	// Unknown products are rejected; title, url and price are taken from the catalog.
+	PRODUCT_VALIDATION_MODE_OVERRIDE ProductValidationMode = "override"
+)
+
+func StringToProductValidationMode(str string) ProductValidationMode {
+	switch str {
+	case "verify":
+		return PRODUCT_VALIDATION_MODE_VERIFY
+	case "override":
+		return PRODUCT_VALIDATION_MODE_OVERRIDE
+	default:
+		return PRODUCT_VALIDATION_MODE_OFF
+	}
+}
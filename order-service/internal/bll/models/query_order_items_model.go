@@ -1,8 +1,12 @@
 package models
 
+import "time"
+
 type QueryOrderItemsModel struct {
 	IDs               []int64
 	CustomerIDs       []int64
+	Statuses          []OrderStatus
+	UpdatedBefore     time.Time
 	Page              int
 	PageSize          int
 	IncludeOrderItems bool
@@ -0,0 +1,8 @@
+package models
+
+type QueryPaymentsModel struct {
+	IDs      []int64
+	OrderIDs []int64
+	Page     int
+	PageSize int
+}
@@ -0,0 +1,7 @@
+package models
+
+type QueryProductsModel struct {
+	IDs      []int64
+	Page     int
+	PageSize int
+}
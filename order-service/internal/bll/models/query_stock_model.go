@@ -0,0 +1,8 @@
+package models
+
+type QueryStockModel struct {
+	ProductIDs   []int64
+	WarehouseIDs []int64
+	Page         int
+	PageSize     int
+}
@@ -0,0 +1,8 @@
+package models
+
+type QueryWebhookDeliveriesModel struct {
+	SubscriptionIDs []int64
+	OnlyFailed      bool
+	Page            int
+	PageSize        int
+}
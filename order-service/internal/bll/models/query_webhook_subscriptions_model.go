@@ -0,0 +1,8 @@
+package models
+
+type QueryWebhookSubscriptionsModel struct {
+	IDs        []int64
+	OnlyActive bool
+	Page       int
+	PageSize   int
+}
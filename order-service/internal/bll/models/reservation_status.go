@@ -0,0 +1,35 @@
+package models
+
+type ReservationStatus string
+
+const (
+	RESERVATION_STATUS_RESERVED  ReservationStatus = "reserved"
+	RESERVATION_STATUS_RELEASED  ReservationStatus = "released"
+	RESERVATION_STATUS_COMMITTED ReservationStatus = "committed"
+)
+
+func (s ReservationStatus) String() string {
+	switch s {
+	case RESERVATION_STATUS_RESERVED:
+		return "reserved"
+	case RESERVATION_STATUS_RELEASED:
+		return "released"
+	case RESERVATION_STATUS_COMMITTED:
+		return "committed"
+	default:
+		return ""
+	}
+}
+
+func StringToReservationStatus(str string) ReservationStatus {
+	switch str {
+	case "reserved":
+		return RESERVATION_STATUS_RESERVED
+	case "released":
+		return RESERVATION_STATUS_RELEASED
+	case "committed":
+		return RESERVATION_STATUS_COMMITTED
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Saga struct {
+	ID          int64
+	OrderID     int64
+	Status      SagaStatus
+	Steps       []string
+	CurrentStep int
+	Data        map[string]string
+	Error       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,40 @@
+package models
+
+type SagaStatus string
+
+const (
+	SAGA_STATUS_RUNNING      SagaStatus = "running"
+	SAGA_STATUS_COMPENSATING SagaStatus = "compensating"
+	SAGA_STATUS_COMPLETED    SagaStatus = "completed"
+	SAGA_STATUS_FAILED       SagaStatus = "failed"
+)
+
+func (s SagaStatus) String() string {
+	switch s {
+	case SAGA_STATUS_RUNNING:
+		return "running"
+	case SAGA_STATUS_COMPENSATING:
+		return "compensating"
+	case SAGA_STATUS_COMPLETED:
+		return "completed"
+	case SAGA_STATUS_FAILED:
+		return "failed"
+	default:
+		return ""
+	}
+}
+
+func StringToSagaStatus(str string) SagaStatus {
+	switch str {
+	case "running":
+		return SAGA_STATUS_RUNNING
+	case "compensating":
+		return SAGA_STATUS_COMPENSATING
+	case "completed":
+		return SAGA_STATUS_COMPLETED
+	case "failed":
+		return SAGA_STATUS_FAILED
+	default:
+		return ""
+	}
+}
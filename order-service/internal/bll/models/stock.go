@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Stock struct {
+	WarehouseID int64
+	ProductID   int64
+	Quantity    int
+	Reserved    int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (s Stock) Available() int {
+	return s.Quantity - s.Reserved
+}
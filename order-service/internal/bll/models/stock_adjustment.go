@@ -0,0 +1,7 @@
+package models
+
+type StockAdjustment struct {
+	WarehouseID int64
+	ProductID   int64
+	Delta       int
+}
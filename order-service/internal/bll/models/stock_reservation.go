@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type StockReservation struct {
+	ID          int64
+	OrderID     int64
+	OrderItemID int64
+	WarehouseID int64
+	ProductID   int64
+	Quantity    int
+	Status      ReservationStatus
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type StockReservationRequest struct {
+	Field       string
+	OrderID     int64
+	OrderItemID int64
+	ProductID   int64
+	Quantity    int
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventType      WebhookEventType
+	EventID        string
+	Attempt        int
+	StatusCode     int
+	Success        bool
+	Error          string
+	Duration       time.Duration
+	CreatedAt      time.Time
+	TenantID       string
+}
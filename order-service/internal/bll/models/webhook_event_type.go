@@ -0,0 +1,30 @@
+package models
+
+type WebhookEventType string
+
+const (
+	WEBHOOK_EVENT_ORDER_CREATED        WebhookEventType = "order.created"
+	WEBHOOK_EVENT_ORDER_STATUS_CHANGED WebhookEventType = "order.status.changed"
+)
+
+func (t WebhookEventType) String() string {
+	switch t {
+	case WEBHOOK_EVENT_ORDER_CREATED:
+		return "order.created"
+	case WEBHOOK_EVENT_ORDER_STATUS_CHANGED:
+		return "order.status.changed"
+	default:
+		return ""
+	}
+}
+
+func StringToWebhookEventType(str string) WebhookEventType {
+	switch str {
+	case "order.created":
+		return WEBHOOK_EVENT_ORDER_CREATED
+	case "order.status.changed":
+		return WEBHOOK_EVENT_ORDER_STATUS_CHANGED
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+type WebhookSubscription struct {
+	ID                  int64
+	URL                 string
+	EventTypes          []WebhookEventType
+	CustomerIDs         []int64
+	Secret              string
+	IsActive            bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	TenantID            string
+}
+
+func (s WebhookSubscription) Matches(eventType WebhookEventType, customerID int64) bool {
+	if !s.IsActive {
+		return false
+	}
+
+	matchesEvent := false
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			matchesEvent = true
+			break
+		}
+	}
+	if !matchesEvent {
+		return false
+	}
+
+	if len(s.CustomerIDs) == 0 {
+		return true
+	}
+	for _, id := range s.CustomerIDs {
+		if id == customerID {
+			return true
+		}
+	}
+	return false
+}
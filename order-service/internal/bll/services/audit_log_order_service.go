@@ -2,24 +2,28 @@ package services
 
 import (
 	"context"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/bllerrors"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
 	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
 	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
-	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
 	"go.uber.org/zap"
 )
 
 type AuditLogOrderService struct {
-	uow                   *unitofwork.UnitOfWork
+	uow                   interfaces.UnitOfWork
 	auditLogOrderItemRepo interfaces.AuditLogOrderRepository
 	log                   *zap.SugaredLogger
 }
 
 func NewAuditLogOrderService(
-	uow *unitofwork.UnitOfWork,
+	uow interfaces.UnitOfWork,
 	auditLogOrderItemRepo interfaces.AuditLogOrderRepository,
 	log *zap.SugaredLogger,
 ) *AuditLogOrderService {
@@ -46,11 +50,18 @@ func (s *AuditLogOrderService) BatchInsert(ctx context.Context, logs []bll.Audit
 		}
 	}()
 
+	tenantID := utils.TenantOrDefault(ctx)
+	if err = s.checkOrdering(ctx, logs); err != nil {
+		s.log.Warnw("audit_log_order_service.out_of_order", "err", err)
+		return nil, err
+	}
+
 	var dalLogs []dal.V1AuditLogOrderDal
 	for _, l := range logs {
 		d := mappers.BllAuditLogOrderToDal(l)
 		d.CreatedAt = now
 		d.UpdatedAt = now
+		d.TenantID = tenantID
 		dalLogs = append(dalLogs, d)
 	}
 
@@ -74,6 +85,44 @@ func (s *AuditLogOrderService) BatchInsert(ctx context.Context, logs []bll.Audit
 	return result, nil
 }
 
-func (s *AuditLogOrderService) UnitOfWork() *unitofwork.UnitOfWork {
+// checkOrdering rejects status entries for orders whose "created" entry hasn't been
+// recorded yet. The created and status-changed events come from different queues, so
+// either can be processed first. The error lists the early orders, so the caller can send
+// the rest again at once and retry only those later.
+func (s *AuditLogOrderService) checkOrdering(ctx context.Context, logs []bll.AuditLogOrder) error {
+	created := make(map[int64]bool)
+	var pending []int64
+	for _, l := range logs {
+		if l.OrderStatus == bll.ORDER_STATUS_CREATED {
+			created[l.OrderID] = true
+		}
+	}
+	for _, l := range logs {
+		if !created[l.OrderID] && !slices.Contains(pending, l.OrderID) {
+			pending = append(pending, l.OrderID)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	ids, err := s.auditLogOrderItemRepo.QueryCreatedOrderIDs(ctx, pending)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, id := range pending {
+		if !slices.Contains(ids, id) {
+			missing = append(missing, strconv.FormatInt(id, 10))
+		}
+	}
+	if len(missing) > 0 {
+		return bllerrors.Aborted("ORDER_NOT_CREATED_YET", "orders have no created entry yet").
+			With("order_ids", strings.Join(missing, ","))
+	}
+	return nil
+}
+
+func (s *AuditLogOrderService) UnitOfWork() interfaces.UnitOfWork {
 	return s.uow
 }
@@ -0,0 +1,438 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/validators"
+	"go.uber.org/zap"
+)
+
+type stockKey struct {
+	warehouseID int64
+	productID   int64
+}
+
+type InventoryService struct {
+	uow                interfaces.UnitOfWork
+	stockRepo          interfaces.StockRepository
+	reservationRepo    interfaces.StockReservationRepository
+	defaultWarehouseID int64
+	log                *zap.SugaredLogger
+}
+
+func NewInventoryService(
+	uow interfaces.UnitOfWork,
+	stockRepo interfaces.StockRepository,
+	reservationRepo interfaces.StockReservationRepository,
+	defaultWarehouseID int64,
+	log *zap.SugaredLogger,
+) *InventoryService {
+	return &InventoryService{
+		uow:                uow,
+		stockRepo:          stockRepo,
+		reservationRepo:    reservationRepo,
+		defaultWarehouseID: defaultWarehouseID,
+		log:                log,
+	}
+}
+
+func (s *InventoryService) AdjustStock(ctx context.Context, adjustments []bll.StockAdjustment) ([]bll.Stock, error) {
+	now := time.Now().UTC()
+	s.log.Infow("inventory_service.adjust_stock_start", "adjustments_count", len(adjustments))
+
+	_, err := s.uow.BeginTransaction(ctx)
+	if err != nil {
+		s.log.Errorw("inventory_service.begin_transaction_failed", "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			s.uow.Rollback(ctx)
+			s.log.Warnw("inventory_service.transaction_rollback", "err", err)
+		}
+	}()
+
+	var productIDs, warehouseIDs []int64
+	for i := range adjustments {
+		if adjustments[i].WarehouseID == 0 {
+			adjustments[i].WarehouseID = s.defaultWarehouseID
+		}
+		productIDs = append(productIDs, adjustments[i].ProductID)
+		warehouseIDs = append(warehouseIDs, adjustments[i].WarehouseID)
+	}
+
+	existing, err := s.stockRepo.Query(ctx, dal.QueryStocksDalModel{
+		ProductIDs:   productIDs,
+		WarehouseIDs: warehouseIDs,
+		ForUpdate:    true,
+	})
+	if err != nil {
+		s.log.Errorw("inventory_service.query_stocks_failed", "err", err)
+		return nil, err
+	}
+
+	stocks := make(map[stockKey]*bll.Stock, len(existing))
+	for _, st := range existing {
+		stock := mappers.DalStockToBll(st)
+		stocks[stockKey{stock.WarehouseID, stock.ProductID}] = &stock
+	}
+
+	touched := make(map[stockKey]*bll.Stock)
+	errs := make(validators.ValidationErrors)
+	for i, a := range adjustments {
+		key := stockKey{a.WarehouseID, a.ProductID}
+		stock, ok := stocks[key]
+		if !ok {
+			stock = &bll.Stock{WarehouseID: a.WarehouseID, ProductID: a.ProductID, CreatedAt: now}
+			stocks[key] = stock
+		}
+
+		if stock.Quantity+a.Delta < stock.Reserved {
+			errs[fmt.Sprintf("adjustments[%d].delta", i)] = fmt.Sprintf("resulting quantity %d is less than reserved %d", stock.Quantity+a.Delta, stock.Reserved)
+			continue
+		}
+		stock.Quantity += a.Delta
+		stock.UpdatedAt = now
+		touched[key] = stock
+	}
+	if len(errs) > 0 {
+		s.log.Warnw("inventory_service.adjust_stock_failed", "errs", errs)
+		err = errs.ToStatus()
+		return nil, err
+	}
+
+	dalStocks := make([]dal.V1StockDal, 0, len(touched))
+	for _, st := range touched {
+		dalStocks = append(dalStocks, mappers.BllStockToDal(*st))
+	}
+
+	upserted, err := s.stockRepo.BulkUpsert(ctx, dalStocks)
+	if err != nil {
+		s.log.Errorw("inventory_service.bulk_upsert_stocks_failed", "err", err)
+		return nil, err
+	}
+
+	if err = s.uow.Commit(ctx); err != nil {
+		s.log.Errorw("inventory_service.commit_transaction_failed", "err", err)
+		return nil, err
+	}
+
+	result := make([]bll.Stock, 0, len(upserted))
+	for _, st := range upserted {
+		result = append(result, mappers.DalStockToBll(st))
+	}
+
+	s.log.Infow("inventory_service.adjust_stock_success", "updated_stocks_count", len(result))
+	return result, nil
+}
+
+func (s *InventoryService) GetStocks(ctx context.Context, query bll.QueryStockModel) ([]bll.Stock, error) {
+	s.log.Infow("inventory_service.get_stocks_start", "query", query)
+
+	stocks, err := s.stockRepo.Query(ctx, dal.QueryStocksDalModel{
+		ProductIDs:   query.ProductIDs,
+		WarehouseIDs: query.WarehouseIDs,
+		Limit:        query.PageSize,
+		Offset:       query.PageSize * (query.Page - 1),
+	})
+	if err != nil {
+		s.log.Errorw("inventory_service.query_stocks_failed", "err", err)
+		return nil, err
+	}
+
+	result := make([]bll.Stock, 0, len(stocks))
+	for _, st := range stocks {
+		result = append(result, mappers.DalStockToBll(st))
+	}
+
+	s.log.Infow("inventory_service.get_stocks_success", "returned_stocks_count", len(result))
+	return result, nil
+}
+
+// ReserveOrder reserves stock for an already persisted order in its own transaction.
+// Orders that already hold reservations are left untouched.
+func (s *InventoryService) ReserveOrder(ctx context.Context, order bll.OrderUnit) (validators.ValidationErrors, error) {
+	now := time.Now().UTC()
+	s.log.Infow("inventory_service.reserve_order_start", "order_id", order.ID)
+
+	_, err := s.uow.BeginTransaction(ctx)
+	if err != nil {
+		s.log.Errorw("inventory_service.begin_transaction_failed", "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			s.uow.Rollback(ctx)
+			s.log.Warnw("inventory_service.transaction_rollback", "err", err)
+		}
+	}()
+
+	existing, err := s.reservationRepo.Query(ctx, dal.QueryStockReservationsDalModel{
+		OrderIDs: []int64{order.ID},
+		Statuses: []string{bll.RESERVATION_STATUS_RESERVED.String()},
+	})
+	if err != nil {
+		s.log.Errorw("inventory_service.query_reservations_failed", "err", err)
+		return nil, err
+	}
+	if len(existing) > 0 {
+		s.log.Infow("inventory_service.reserve_order_already_reserved", "order_id", order.ID)
+		err = s.uow.Commit(ctx)
+		return nil, err
+	}
+
+	requests := make([]bll.StockReservationRequest, 0, len(order.OrderItems))
+	for j, it := range order.OrderItems {
+		requests = append(requests, bll.StockReservationRequest{
+			Field:       fmt.Sprintf("order_items[%d].quantity", j),
+			OrderID:     order.ID,
+			OrderItemID: it.ID,
+			ProductID:   it.ProductID,
+			Quantity:    it.Quantity,
+		})
+	}
+
+	var errs validators.ValidationErrors
+	errs, err = s.Reserve(ctx, requests, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		s.uow.Rollback(ctx)
+		return errs, nil
+	}
+
+	if err = s.uow.Commit(ctx); err != nil {
+		s.log.Errorw("inventory_service.commit_transaction_failed", "err", err)
+		return nil, err
+	}
+
+	s.log.Infow("inventory_service.reserve_order_success", "order_id", order.ID)
+	return nil, nil
+}
+
+// ReleaseOrders releases reservations of the orders in its own transaction.
+func (s *InventoryService) ReleaseOrders(ctx context.Context, orderIDs []int64) error {
+	_, err := s.uow.BeginTransaction(ctx)
+	if err != nil {
+		s.log.Errorw("inventory_service.begin_transaction_failed", "err", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			s.uow.Rollback(ctx)
+			s.log.Warnw("inventory_service.transaction_rollback", "err", err)
+		}
+	}()
+
+	if err = s.Release(ctx, orderIDs, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	if err = s.uow.Commit(ctx); err != nil {
+		s.log.Errorw("inventory_service.commit_transaction_failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+// Reserve must be called inside a transaction already opened on the shared unit of work.
+// Stock rows are locked for the rest of that transaction.
+func (s *InventoryService) Reserve(ctx context.Context, requests []bll.StockReservationRequest, now time.Time) (validators.ValidationErrors, error) {
+	s.log.Infow("inventory_service.reserve_start", "requests_count", len(requests))
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[int64]struct{})
+	var productIDs []int64
+	for _, r := range requests {
+		if _, ok := seen[r.ProductID]; ok {
+			continue
+		}
+		seen[r.ProductID] = struct{}{}
+		productIDs = append(productIDs, r.ProductID)
+	}
+
+	existing, err := s.stockRepo.Query(ctx, dal.QueryStocksDalModel{ProductIDs: productIDs, ForUpdate: true})
+	if err != nil {
+		s.log.Errorw("inventory_service.query_stocks_failed", "err", err)
+		return nil, err
+	}
+
+	byProduct := make(map[int64][]*bll.Stock)
+	for _, st := range existing {
+		stock := mappers.DalStockToBll(st)
+		byProduct[stock.ProductID] = append(byProduct[stock.ProductID], &stock)
+	}
+
+	touched := make(map[stockKey]*bll.Stock)
+	var reservations []dal.V1StockReservationDal
+	errs := make(validators.ValidationErrors)
+	for _, r := range requests {
+		available := 0
+		for _, stock := range byProduct[r.ProductID] {
+			available += stock.Available()
+		}
+		if available < r.Quantity {
+			errs[r.Field] = fmt.Sprintf("InsufficientStock: requested %d, available %d", r.Quantity, available)
+			continue
+		}
+
+		remaining := r.Quantity
+		for _, stock := range byProduct[r.ProductID] {
+			if remaining == 0 {
+				break
+			}
+			take := min(stock.Available(), remaining)
+			if take == 0 {
+				continue
+			}
+			stock.Reserved += take
+			stock.UpdatedAt = now
+			touched[stockKey{stock.WarehouseID, stock.ProductID}] = stock
+			remaining -= take
+
+			reservations = append(reservations, mappers.BllStockReservationToDal(bll.StockReservation{
+				OrderID:     r.OrderID,
+				OrderItemID: r.OrderItemID,
+				WarehouseID: stock.WarehouseID,
+				ProductID:   stock.ProductID,
+				Quantity:    take,
+				Status:      bll.RESERVATION_STATUS_RESERVED,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}))
+		}
+	}
+	if len(errs) > 0 {
+		s.log.Warnw("inventory_service.reserve_insufficient_stock", "errs", errs)
+		return errs, nil
+	}
+
+	if err := s.saveStocks(ctx, touched); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.reservationRepo.BulkInsert(ctx, reservations); err != nil {
+		s.log.Errorw("inventory_service.bulk_insert_reservations_failed", "err", err)
+		return nil, err
+	}
+
+	s.log.Infow("inventory_service.reserve_success", "reservations_count", len(reservations))
+	return nil, nil
+}
+
+// Release returns reserved quantities of the orders back to available stock.
+// Must be called inside a transaction already opened on the shared unit of work.
+func (s *InventoryService) Release(ctx context.Context, orderIDs []int64, now time.Time) error {
+	return s.settle(ctx, orderIDs, bll.RESERVATION_STATUS_RELEASED, now)
+}
+
+// Commit deducts reserved quantities of the orders from stock on hand.
+// Must be called inside a transaction already opened on the shared unit of work.
+func (s *InventoryService) Commit(ctx context.Context, orderIDs []int64, now time.Time) error {
+	return s.settle(ctx, orderIDs, bll.RESERVATION_STATUS_COMMITTED, now)
+}
+
+func (s *InventoryService) settle(ctx context.Context, orderIDs []int64, target bll.ReservationStatus, now time.Time) error {
+	s.log.Infow("inventory_service.settle_start", "order_ids", orderIDs, "status", target)
+	if len(orderIDs) == 0 {
+		return nil
+	}
+
+	reservationsDal, err := s.reservationRepo.Query(ctx, dal.QueryStockReservationsDalModel{
+		OrderIDs:  orderIDs,
+		Statuses:  []string{bll.RESERVATION_STATUS_RESERVED.String()},
+		ForUpdate: true,
+	})
+	if err != nil {
+		s.log.Errorw("inventory_service.query_reservations_failed", "err", err)
+		return err
+	}
+	if len(reservationsDal) == 0 {
+		s.log.Infow("inventory_service.settle_success", "settled_reservations_count", 0)
+		return nil
+	}
+
+	var productIDs, warehouseIDs []int64
+	for _, r := range reservationsDal {
+		productIDs = append(productIDs, r.ProductID)
+		warehouseIDs = append(warehouseIDs, r.WarehouseID)
+	}
+
+	existing, err := s.stockRepo.Query(ctx, dal.QueryStocksDalModel{
+		ProductIDs:   productIDs,
+		WarehouseIDs: warehouseIDs,
+		ForUpdate:    true,
+	})
+	if err != nil {
+		s.log.Errorw("inventory_service.query_stocks_failed", "err", err)
+		return err
+	}
+
+	stocks := make(map[stockKey]*bll.Stock, len(existing))
+	for _, st := range existing {
+		stock := mappers.DalStockToBll(st)
+		stocks[stockKey{stock.WarehouseID, stock.ProductID}] = &stock
+	}
+
+	touched := make(map[stockKey]*bll.Stock)
+	ids := make([]int64, 0, len(reservationsDal))
+	for _, r := range reservationsDal {
+		ids = append(ids, r.ID)
+
+		key := stockKey{r.WarehouseID, r.ProductID}
+		stock, ok := stocks[key]
+		if !ok {
+			s.log.Warnw("inventory_service.reservation_stock_missing", "reservation_id", r.ID, "warehouse_id", r.WarehouseID, "product_id", r.ProductID)
+			continue
+		}
+
+		stock.Reserved = max(stock.Reserved-r.Quantity, 0)
+		if target == bll.RESERVATION_STATUS_COMMITTED {
+			stock.Quantity = max(stock.Quantity-r.Quantity, stock.Reserved)
+		}
+		stock.UpdatedAt = now
+		touched[key] = stock
+	}
+
+	if err := s.saveStocks(ctx, touched); err != nil {
+		return err
+	}
+
+	if err := s.reservationRepo.UpdateStatus(ctx, ids, target.String(), now); err != nil {
+		s.log.Errorw("inventory_service.update_reservations_status_failed", "err", err)
+		return err
+	}
+
+	s.log.Infow("inventory_service.settle_success", "settled_reservations_count", len(ids))
+	return nil
+}
+
+func (s *InventoryService) saveStocks(ctx context.Context, stocks map[stockKey]*bll.Stock) error {
+	if len(stocks) == 0 {
+		return nil
+	}
+
+	dalStocks := make([]dal.V1StockDal, 0, len(stocks))
+	for _, st := range stocks {
+		dalStocks = append(dalStocks, mappers.BllStockToDal(*st))
+	}
+
+	if _, err := s.stockRepo.BulkUpsert(ctx, dalStocks); err != nil {
+		s.log.Errorw("inventory_service.bulk_upsert_stocks_failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (s *InventoryService) UnitOfWork() interfaces.UnitOfWork {
+	return s.uow
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"go.uber.org/zap"
+)
+
+// fakeStockRepo keeps stock rows in memory, keyed by warehouse and product like the
+// stocks primary key.
+type fakeStockRepo struct {
+	stocks map[stockKey]dal.V1StockDal
+}
+
+func (r *fakeStockRepo) BulkUpsert(ctx context.Context, stocks []dal.V1StockDal) ([]dal.V1StockDal, error) {
+	for _, st := range stocks {
+		r.stocks[stockKey{st.WarehouseID, st.ProductID}] = st
+	}
+	return stocks, nil
+}
+
+func (r *fakeStockRepo) Query(ctx context.Context, q dal.QueryStocksDalModel) ([]dal.V1StockDal, error) {
+	var result []dal.V1StockDal
+	for _, st := range r.stocks {
+		if len(q.ProductIDs) > 0 && !slices.Contains(q.ProductIDs, st.ProductID) ||
+			len(q.WarehouseIDs) > 0 && !slices.Contains(q.WarehouseIDs, st.WarehouseID) {
+			continue
+		}
+		result = append(result, st)
+	}
+	slices.SortFunc(result, func(a, b dal.V1StockDal) int { return int(a.WarehouseID - b.WarehouseID) })
+	return result, nil
+}
+
+type fakeReservationRepo struct {
+	reservations []dal.V1StockReservationDal
+}
+
+func (r *fakeReservationRepo) BulkInsert(ctx context.Context, reservations []dal.V1StockReservationDal) ([]dal.V1StockReservationDal, error) {
+	for i := range reservations {
+		reservations[i].ID = int64(len(r.reservations) + 1)
+		r.reservations = append(r.reservations, reservations[i])
+	}
+	return reservations, nil
+}
+
+func (r *fakeReservationRepo) Query(ctx context.Context, q dal.QueryStockReservationsDalModel) ([]dal.V1StockReservationDal, error) {
+	var result []dal.V1StockReservationDal
+	for _, res := range r.reservations {
+		if len(q.OrderIDs) > 0 && !slices.Contains(q.OrderIDs, res.OrderID) ||
+			len(q.Statuses) > 0 && !slices.Contains(q.Statuses, res.Status) {
+			continue
+		}
+		result = append(result, res)
+	}
+	return result, nil
+}
+
+func (r *fakeReservationRepo) UpdateStatus(ctx context.Context, ids []int64, status string, now time.Time) error {
+	for i := range r.reservations {
+		if slices.Contains(ids, r.reservations[i].ID) {
+			r.reservations[i].Status = status
+			r.reservations[i].UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+// newTestStockRepo holds product 10 in warehouses 1 and 2, with stocks given as
+// quantity and reserved pairs.
+func newTestStockRepo(w1, w2 [2]int) *fakeStockRepo {
+	return &fakeStockRepo{stocks: map[stockKey]dal.V1StockDal{
+		{1, 10}: {WarehouseID: 1, ProductID: 10, Quantity: w1[0], Reserved: w1[1]},
+		{2, 10}: {WarehouseID: 2, ProductID: 10, Quantity: w2[0], Reserved: w2[1]},
+	}}
+}
+
+func TestReserveOrder(t *testing.T) {
+	tests := []struct {
+		name             string
+		w1, w2           [2]int
+		quantity         int
+		wantErrs         bool
+		wantReserved     [2]int
+		wantReservations int
+	}{
+		{"fits in the first warehouse", [2]int{5, 0}, [2]int{5, 0}, 3, false, [2]int{3, 0}, 1},
+		{"spills into the second warehouse", [2]int{5, 3}, [2]int{5, 0}, 4, false, [2]int{5, 2}, 2},
+		{"takes all available", [2]int{5, 3}, [2]int{5, 4}, 3, false, [2]int{5, 5}, 2},
+		{"insufficient stock", [2]int{5, 3}, [2]int{5, 4}, 4, true, [2]int{3, 4}, 0},
+		{"reserved stock is not available", [2]int{5, 5}, [2]int{0, 0}, 1, true, [2]int{5, 0}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := &fakeUnitOfWork{}
+			stockRepo := newTestStockRepo(tt.w1, tt.w2)
+			resRepo := &fakeReservationRepo{}
+			svc := NewInventoryService(uow, stockRepo, resRepo, 1, zap.NewNop().Sugar())
+
+			errs, err := svc.ReserveOrder(context.Background(), bll.OrderUnit{
+				ID:         1,
+				OrderItems: []bll.OrderItemUnit{{ID: 100, ProductID: 10, Quantity: tt.quantity}},
+			})
+			if err != nil {
+				t.Fatalf("ReserveOrder: %v", err)
+			}
+			if (len(errs) > 0) != tt.wantErrs {
+				t.Fatalf("errs = %v, want errors %v", errs, tt.wantErrs)
+			}
+			if tt.wantErrs {
+				if _, ok := errs["order_items[0].quantity"]; !ok {
+					t.Errorf("errs = %v, want order_items[0].quantity", errs)
+				}
+				if uow.rolledBack == 0 || uow.committed != 0 {
+					t.Errorf("transactions = %+v, want rolled back", uow)
+				}
+			}
+			for i, w := range []int64{1, 2} {
+				if got := stockRepo.stocks[stockKey{w, 10}].Reserved; got != tt.wantReserved[i] {
+					t.Errorf("warehouse %d reserved = %d, want %d", w, got, tt.wantReserved[i])
+				}
+			}
+			if len(resRepo.reservations) != tt.wantReservations {
+				t.Errorf("reservations = %d, want %d", len(resRepo.reservations), tt.wantReservations)
+			}
+			reserved := 0
+			for _, r := range resRepo.reservations {
+				reserved += r.Quantity
+			}
+			if !tt.wantErrs && reserved != tt.quantity {
+				t.Errorf("reserved across reservations = %d, want %d", reserved, tt.quantity)
+			}
+		})
+	}
+}
+
+func TestReserveOrderTwiceReservesOnce(t *testing.T) {
+	stockRepo := newTestStockRepo([2]int{5, 0}, [2]int{0, 0})
+	resRepo := &fakeReservationRepo{}
+	svc := NewInventoryService(&fakeUnitOfWork{}, stockRepo, resRepo, 1, zap.NewNop().Sugar())
+	order := bll.OrderUnit{ID: 1, OrderItems: []bll.OrderItemUnit{{ID: 100, ProductID: 10, Quantity: 2}}}
+
+	for range 2 {
+		if errs, err := svc.ReserveOrder(context.Background(), order); err != nil || len(errs) > 0 {
+			t.Fatalf("ReserveOrder = %v, %v", errs, err)
+		}
+	}
+	if got := stockRepo.stocks[stockKey{1, 10}].Reserved; got != 2 {
+		t.Errorf("reserved = %d, want 2", got)
+	}
+	if len(resRepo.reservations) != 1 {
+		t.Errorf("reservations = %d, want 1", len(resRepo.reservations))
+	}
+}
+
+func TestReleaseOrders(t *testing.T) {
+	tests := []struct {
+		name         string
+		reservations []dal.V1StockReservationDal
+		wantReserved [2]int
+		wantStatuses []string
+	}{
+		{
+			name: "reservations go back to available stock",
+			reservations: []dal.V1StockReservationDal{
+				{ID: 1, OrderID: 1, WarehouseID: 1, ProductID: 10, Quantity: 3, Status: "reserved"},
+				{ID: 2, OrderID: 1, WarehouseID: 2, ProductID: 10, Quantity: 2, Status: "reserved"},
+			},
+			wantReserved: [2]int{1, 2},
+			wantStatuses: []string{"released", "released"},
+		},
+		{
+			name: "other orders keep their reservations",
+			reservations: []dal.V1StockReservationDal{
+				{ID: 1, OrderID: 1, WarehouseID: 1, ProductID: 10, Quantity: 3, Status: "reserved"},
+				{ID: 2, OrderID: 2, WarehouseID: 2, ProductID: 10, Quantity: 2, Status: "reserved"},
+			},
+			wantReserved: [2]int{1, 4},
+			wantStatuses: []string{"released", "reserved"},
+		},
+		{
+			name: "settled reservations are not released again",
+			reservations: []dal.V1StockReservationDal{
+				{ID: 1, OrderID: 1, WarehouseID: 1, ProductID: 10, Quantity: 3, Status: "released"},
+				{ID: 2, OrderID: 1, WarehouseID: 2, ProductID: 10, Quantity: 2, Status: "committed"},
+			},
+			wantReserved: [2]int{4, 4},
+			wantStatuses: []string{"released", "committed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := &fakeUnitOfWork{}
+			stockRepo := newTestStockRepo([2]int{5, 4}, [2]int{5, 4})
+			resRepo := &fakeReservationRepo{reservations: tt.reservations}
+			svc := NewInventoryService(uow, stockRepo, resRepo, 1, zap.NewNop().Sugar())
+
+			if err := svc.ReleaseOrders(context.Background(), []int64{1}); err != nil {
+				t.Fatalf("ReleaseOrders: %v", err)
+			}
+
+			for i, w := range []int64{1, 2} {
+				st := stockRepo.stocks[stockKey{w, 10}]
+				if st.Reserved != tt.wantReserved[i] || st.Quantity != 5 {
+					t.Errorf("warehouse %d stock = %d/%d, want 5/%d", w, st.Quantity, st.Reserved, tt.wantReserved[i])
+				}
+			}
+			for i, r := range resRepo.reservations {
+				if r.Status != tt.wantStatuses[i] {
+					t.Errorf("reservation %d status = %s, want %s", r.ID, r.Status, tt.wantStatuses[i])
+				}
+			}
+			if uow.committed != 1 || uow.rolledBack != 0 {
+				t.Errorf("transactions = %+v, want one committed", uow)
+			}
+		})
+	}
+}
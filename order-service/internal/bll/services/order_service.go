@@ -3,40 +3,46 @@ package services
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
 	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
 	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
-	publisher "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/publisher/rabbitmq"
-	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/validators"
 	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
 	"go.uber.org/zap"
 )
 
+const publishTimeout = 5 * time.Second
+
 type OrderService struct {
-	uow                   *unitofwork.UnitOfWork
-	orderRepo             interfaces.OrderRepository
-	orderItemRepo         interfaces.OrderItemRepository
-	omsPublisher *publisher.Publisher
-	log                   *zap.SugaredLogger
+	uow           interfaces.UnitOfWork
+	orderRepo     interfaces.OrderRepository
+	orderItemRepo interfaces.OrderItemRepository
+	inventory     *InventoryService
+	omsPublisher  interfaces.Publisher
+	log           *zap.SugaredLogger
 }
 
+// inventory may be nil, in which case stock reservation is skipped.
 func NewOrderService(
-	uow *unitofwork.UnitOfWork,
+	uow interfaces.UnitOfWork,
 	orderRepo interfaces.OrderRepository,
 	orderItemRepo interfaces.OrderItemRepository,
-	omsPublisher *publisher.Publisher,
+	inventory *InventoryService,
+	omsPublisher interfaces.Publisher,
 	log *zap.SugaredLogger,
 ) *OrderService {
 	return &OrderService{
-		uow:                   uow,
-		orderRepo:             orderRepo,
-		orderItemRepo:         orderItemRepo,
-		omsPublisher: omsPublisher,
-		log:                   log,
+		uow:           uow,
+		orderRepo:     orderRepo,
+		orderItemRepo: orderItemRepo,
+		inventory:     inventory,
+		omsPublisher:  omsPublisher,
+		log:           log,
 	}
 }
 
@@ -44,6 +50,11 @@ func (s *OrderService) BatchInsert(ctx context.Context, orders []bll.OrderUnit)
 	now := time.Now().UTC()
 	s.log.Infow("order_service.batch_insert_start", "orders_count", len(orders))
 
+	if err := s.checkCreateAccess(ctx, orders); err != nil {
+		s.log.Warnw("order_service.batch_insert_denied", "err", err)
+		return nil, err
+	}
+
 	_, err := s.uow.BeginTransaction(ctx)
 	if err != nil {
 		s.log.Errorw("order_service.begin_transaction_failed", "err", err)
@@ -56,11 +67,13 @@ func (s *OrderService) BatchInsert(ctx context.Context, orders []bll.OrderUnit)
 		}
 	}()
 
+	tenantID := utils.TenantOrDefault(ctx)
 	var dalOrders []dal.V1OrderDal
 	for _, o := range orders {
 		d := mappers.BllOrderToDal(o)
 		d.CreatedAt = now
 		d.UpdatedAt = now
+		d.TenantID = tenantID
 		dalOrders = append(dalOrders, d)
 	}
 
@@ -71,12 +84,15 @@ func (s *OrderService) BatchInsert(ctx context.Context, orders []bll.OrderUnit)
 	}
 
 	var dalItems []dal.V1OrderItemDal
+	var itemFields []string
 	for idx, insOrder := range insertedOrders {
-		for _, item := range orders[idx].OrderItems {
+		for itemIdx, item := range orders[idx].OrderItems {
 			d := mappers.BllOrderItemToDal(item, insOrder.ID)
 			d.CreatedAt = now
 			d.UpdatedAt = now
+			d.TenantID = insOrder.TenantID
 			dalItems = append(dalItems, d)
+			itemFields = append(itemFields, fmt.Sprintf("orders[%d].order_items[%d].quantity", idx, itemIdx))
 		}
 	}
 
@@ -86,6 +102,30 @@ func (s *OrderService) BatchInsert(ctx context.Context, orders []bll.OrderUnit)
 		return nil, err
 	}
 
+	if s.inventory != nil {
+		var requests []bll.StockReservationRequest
+		for idx, it := range insertedItems {
+			requests = append(requests, bll.StockReservationRequest{
+				Field:       itemFields[idx],
+				OrderID:     it.OrderID,
+				OrderItemID: it.ID,
+				ProductID:   it.ProductID,
+				Quantity:    it.Quantity,
+			})
+		}
+
+		var errs validators.ValidationErrors
+		errs, err = s.inventory.Reserve(ctx, requests, now)
+		if err != nil {
+			s.log.Errorw("order_service.reserve_stock_failed", "err", err)
+			return nil, err
+		}
+		if len(errs) > 0 {
+			err = errs.ToStatus()
+			return nil, err
+		}
+	}
+
 	if err := s.uow.Commit(ctx); err != nil {
 		s.log.Errorw("order_service.commit_transaction_failed", "err", err)
 		return nil, err
@@ -107,7 +147,7 @@ func (s *OrderService) BatchInsert(ctx context.Context, orders []bll.OrderUnit)
 			msgs = append(msgs, mappers.BllOrderToOrderCreatedMessage(o))
 		}
 
-		ctxPub, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctxPub, cancel := publishContext(ctx)
 		defer cancel()
 
 		if err := s.omsPublisher.PublishBatch(ctxPub, msgs); err != nil {
@@ -123,10 +163,23 @@ func (s *OrderService) UpdateOrdersStatus(ctx context.Context, orderIds []int64,
 	now := time.Now().UTC()
 	s.log.Infow("order_service.update_orders_status_start", "order_ids", orderIds, "new_status", newStatus)
 
+	_, err := s.uow.BeginTransaction(ctx)
+	if err != nil {
+		s.log.Errorw("order_service.begin_transaction_failed", "err", err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			s.uow.Rollback(ctx)
+			s.log.Warnw("order_service.transaction_rollback", "err", err)
+		}
+	}()
+
 	ordersDal, err := s.orderRepo.Query(ctx, dal.QueryOrdersDalModel{
-		IDs:    orderIds,
-		Limit:  len(orderIds),
-		Offset: 0,
+		IDs:       orderIds,
+		Limit:     len(orderIds),
+		Offset:    0,
+		ForUpdate: true,
 	})
 	if err != nil {
 		s.log.Errorw("order_service.query_orders_failed", "err", err)
@@ -134,7 +187,12 @@ func (s *OrderService) UpdateOrdersStatus(ctx context.Context, orderIds []int64,
 	}
 	if len(ordersDal) == 0 {
 		s.log.Infow("order_service.update_orders_status_success", "updated_orders_count", 0)
-		return []bll.OrderUnit{}, nil
+		return []bll.OrderUnit{}, s.uow.Commit(ctx)
+	}
+
+	if err = s.checkStatusChangeAccess(ctx, ordersDal, newStatus); err != nil {
+		s.log.Warnw("order_service.update_orders_status_denied", "err", err)
+		return nil, err
 	}
 
 	var orders []bll.OrderUnit
@@ -152,7 +210,8 @@ func (s *OrderService) UpdateOrdersStatus(ctx context.Context, orderIds []int64,
 	}
 	if len(errs) > 0 {
 		s.log.Warnw("order_service.update_orders_status_failed", "errs", errs)
-		return nil, errs.ToStatus()
+		err = errs.ToStatus()
+		return nil, err
 	}
 
 	var updatedOrdersDal []dal.V1OrderDal
@@ -165,6 +224,30 @@ func (s *OrderService) UpdateOrdersStatus(ctx context.Context, orderIds []int64,
 		return nil, err
 	}
 
+	if s.inventory != nil {
+		ids := make([]int64, 0, len(orders))
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+
+		switch newStatus {
+		case bll.ORDER_STATUS_CANCELLED:
+			err = s.inventory.Release(ctx, ids, now)
+		case bll.ORDER_STATUS_COMPLETED:
+			err = s.inventory.Commit(ctx, ids, now)
+		}
+		if err != nil {
+			s.log.Errorw("order_service.settle_reservations_failed", "err", err)
+			return nil, err
+		}
+	}
+
+	if err = s.uow.Commit(ctx); err != nil {
+		s.log.Errorw("order_service.commit_transaction_failed", "err", err)
+		return nil, err
+	}
+
+	actor := utils.ActorFromContext(ctx)
 	go func() {
 		var msgs []messages.Message
 		for _, o := range orders {
@@ -172,11 +255,14 @@ func (s *OrderService) UpdateOrdersStatus(ctx context.Context, orderIds []int64,
 				OrderId:     o.ID,
 				OrderStatus: o.Status.String(),
 				CustomerId:  o.CustomerID,
+				Actor:       actor,
+				TenantId:    o.TenantID,
+				UpdatedAt:   o.UpdatedAt,
 			}
 			msgs = append(msgs, msg)
 		}
 
-		ctxPub, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctxPub, cancel := publishContext(ctx)
 		defer cancel()
 
 		if err := s.omsPublisher.PublishBatch(ctxPub, msgs); err != nil {
@@ -191,11 +277,35 @@ func (s *OrderService) UpdateOrdersStatus(ctx context.Context, orderIds []int64,
 func (s *OrderService) GetOrders(ctx context.Context, query bll.QueryOrderItemsModel) ([]bll.OrderUnit, error) {
 	s.log.Infow("order_service.get_orders_start", "query", query)
 
+	scope := utils.AccessScopeFromContext(ctx)
+	if scope != nil {
+		errs := make(validators.ValidationErrors)
+		for i, id := range query.CustomerIDs {
+			if id != scope.CustomerID {
+				errs[fmt.Sprintf("customer_ids[%d]", i)] = "orders of another customer"
+			}
+		}
+		if len(errs) > 0 {
+			s.log.Warnw("order_service.get_orders_denied", "errs", errs)
+			return nil, errs.ToPermissionDeniedStatus()
+		}
+		// Other customers' orders are never loaded, so asking for them by id looks the
+		// same as asking for ids that don't exist.
+		query.CustomerIDs = []int64{scope.CustomerID}
+	}
+
+	var statuses []string
+	for _, st := range query.Statuses {
+		statuses = append(statuses, st.String())
+	}
+
 	orders, err := s.orderRepo.Query(ctx, dal.QueryOrdersDalModel{
-		IDs:         query.IDs,
-		CustomerIDs: query.CustomerIDs,
-		Limit:       query.PageSize,
-		Offset:      query.PageSize * (query.Page - 1),
+		IDs:           query.IDs,
+		CustomerIDs:   query.CustomerIDs,
+		Statuses:      statuses,
+		UpdatedBefore: query.UpdatedBefore,
+		Limit:         query.PageSize,
+		Offset:        query.PageSize * (query.Page - 1),
 	})
 	if err != nil {
 		s.log.Errorw("order_service.query_orders_failed", "err", err)
@@ -205,7 +315,6 @@ func (s *OrderService) GetOrders(ctx context.Context, query bll.QueryOrderItemsM
 		s.log.Infow("order_service.get_orders_success", "returned_orders_count", 0)
 		return []bll.OrderUnit{}, nil
 	}
-
 	var items []dal.V1OrderItemDal
 	if query.IncludeOrderItems {
 		ordersIDs := make([]int64, len(orders))
@@ -232,6 +341,54 @@ func (s *OrderService) GetOrders(ctx context.Context, query bll.QueryOrderItemsM
 	return result, nil
 }
 
-func (s *OrderService) UnitOfWork() *unitofwork.UnitOfWork {
+// checkCreateAccess rejects orders created on behalf of another customer when the caller
+// has an access scope.
+func (s *OrderService) checkCreateAccess(ctx context.Context, orders []bll.OrderUnit) error {
+	scope := utils.AccessScopeFromContext(ctx)
+	if scope == nil {
+		return nil
+	}
+
+	errs := make(validators.ValidationErrors)
+	for i, o := range orders {
+		if o.CustomerID != scope.CustomerID {
+			errs[fmt.Sprintf("orders[%d].customer_id", i)] = "orders of another customer"
+		}
+	}
+	if len(errs) > 0 {
+		return errs.ToPermissionDeniedStatus()
+	}
+	return nil
+}
+
+// checkStatusChangeAccess enforces the caller's access scope, if any, on a status change.
+func (s *OrderService) checkStatusChangeAccess(ctx context.Context, orders []dal.V1OrderDal, newStatus bll.OrderStatus) error {
+	scope := utils.AccessScopeFromContext(ctx)
+	if scope == nil {
+		return nil
+	}
+
+	errs := make(validators.ValidationErrors)
+	if !slices.Contains(scope.AllowedStatuses, newStatus.String()) {
+		errs["new_status"] = fmt.Sprintf("changing status to %s is not allowed", newStatus)
+	}
+	for _, o := range orders {
+		if o.CustomerID != scope.CustomerID {
+			errs[fmt.Sprintf("orders[%d]", o.ID)] = "belongs to another customer"
+		}
+	}
+	if len(errs) > 0 {
+		return errs.ToPermissionDeniedStatus()
+	}
+	return nil
+}
+
+func (s *OrderService) UnitOfWork() interfaces.UnitOfWork {
 	return s.uow
 }
+
+// publishContext outlives the request but keeps its values, so the published messages
+// carry the request's trace context and tenant.
+func publishContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), publishTimeout)
+}
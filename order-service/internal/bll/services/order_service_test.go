@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tracing"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
+	"github.com/jackc/pgx/v5"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeOrderRepo filters its orders by ids and customer ids like the postgres query does.
+type fakeOrderRepo struct {
+	interfaces.OrderRepository
+
+	orders  []dal.V1OrderDal
+	queries []dal.QueryOrdersDalModel
+}
+
+func (r *fakeOrderRepo) Query(ctx context.Context, q dal.QueryOrdersDalModel) ([]dal.V1OrderDal, error) {
+	r.queries = append(r.queries, q)
+	var result []dal.V1OrderDal
+	for _, o := range r.orders {
+		if len(q.IDs) > 0 && !slices.Contains(q.IDs, o.ID) {
+			continue
+		}
+		if len(q.CustomerIDs) > 0 && !slices.Contains(q.CustomerIDs, o.CustomerID) {
+			continue
+		}
+		result = append(result, o)
+	}
+	return result, nil
+}
+
+func (r *fakeOrderRepo) BulkUpdate(ctx context.Context, orders []dal.V1OrderDal) ([]dal.V1OrderDal, error) {
+	var updated []dal.V1OrderDal
+	for _, o := range orders {
+		for i := range r.orders {
+			if r.orders[i].ID == o.ID {
+				r.orders[i] = o
+				updated = append(updated, o)
+			}
+		}
+	}
+	return updated, nil
+}
+
+// fakeUnitOfWork counts how transactions end; the fake repositories ignore them.
+type fakeUnitOfWork struct {
+	begun, committed, rolledBack int
+}
+
+func (u *fakeUnitOfWork) BeginTransaction(ctx context.Context) (pgx.Tx, error) {
+	u.begun++
+	return nil, nil
+}
+
+func (u *fakeUnitOfWork) Commit(ctx context.Context) error {
+	u.committed++
+	return nil
+}
+
+func (u *fakeUnitOfWork) Rollback(ctx context.Context) error {
+	u.rolledBack++
+	return nil
+}
+
+func (u *fakeUnitOfWork) Close() {}
+
+type fakePublisher struct{}
+
+func (fakePublisher) PublishBatch(ctx context.Context, msgs []messages.Message) error {
+	return nil
+}
+
+func TestGetOrdersAccessScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    *utils.AccessScope
+		query    bll.QueryOrderItemsModel
+		wantCode codes.Code
+		wantIDs  []int64
+	}{
+		{"unscoped sees every order", nil, bll.QueryOrderItemsModel{IDs: []int64{1, 2}}, codes.OK, []int64{1, 2}},
+		{"scoped without ids sees own orders", &utils.AccessScope{CustomerID: 42}, bll.QueryOrderItemsModel{}, codes.OK, []int64{1}},
+		{"scoped ids of another customer are left out", &utils.AccessScope{CustomerID: 42}, bll.QueryOrderItemsModel{IDs: []int64{1, 2}}, codes.OK, []int64{1}},
+		{"scoped ids of only another customer", &utils.AccessScope{CustomerID: 42}, bll.QueryOrderItemsModel{IDs: []int64{2}}, codes.OK, nil},
+		{"scoped customer ids of another customer", &utils.AccessScope{CustomerID: 42}, bll.QueryOrderItemsModel{CustomerIDs: []int64{7}}, codes.PermissionDenied, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOrderRepo{orders: []dal.V1OrderDal{
+				{ID: 1, CustomerID: 42, Status: "created"},
+				{ID: 2, CustomerID: 7, Status: "created"},
+			}}
+			svc := NewOrderService(nil, repo, nil, nil, nil, zap.NewNop().Sugar())
+			ctx := utils.WithAccessScope(context.Background(), tt.scope)
+			tt.query.Page, tt.query.PageSize = 1, 10
+
+			orders, err := svc.GetOrders(ctx, tt.query)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("GetOrders err = %v, want %v", err, tt.wantCode)
+			}
+			var ids []int64
+			for _, o := range orders {
+				ids = append(ids, o.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("order ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if tt.scope != nil {
+				for _, q := range repo.queries {
+					if !slices.Equal(q.CustomerIDs, []int64{tt.scope.CustomerID}) {
+						t.Errorf("repository queried customer ids %v, want only %d", q.CustomerIDs, tt.scope.CustomerID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPublishContextKeepsTrace(t *testing.T) {
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	reqCtx, cancelReq := context.WithCancel(context.Background())
+	reqCtx, reqSpan := tracing.Tracer().Start(reqCtx, "grpc.request")
+	defer reqSpan.End()
+
+	ctx, cancel := publishContext(reqCtx)
+	defer cancel()
+	// The publish runs after the handler returned and its context was cancelled.
+	cancelReq()
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("publish context cancelled with the request: %v", err)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		t.Error("publish context has no deadline")
+	}
+
+	// What Publisher.PublishBatch does for every message.
+	ctx, span := tracing.Tracer().Start(ctx, "rabbitmq.publish_batch")
+	defer span.End()
+	headers := amqp.Table{}
+	tracing.InjectAmqpHeaders(ctx, headers)
+
+	consumed := tracing.ExtractAmqpHeaders(context.Background(), headers)
+	tracer := otel.GetTracerProvider().Tracer("test")
+	_, consumerSpan := tracer.Start(consumed, "consumer.process_batch")
+	defer consumerSpan.End()
+
+	want := reqSpan.SpanContext().TraceID()
+	if id := consumerSpan.SpanContext().TraceID(); id != want {
+		t.Errorf("published trace id = %s, want the request's %s (headers %v)", id, want, headers)
+	}
+}
@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/bllerrors"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/client/payment"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Backs the one-live-payment-per-order rule, see migration 00013.
+const paymentLiveOrderIndex = "idx_payment_live_order_id"
+
+type PaymentService struct {
+	uow          interfaces.UnitOfWork
+	paymentRepo  interfaces.PaymentRepository
+	orderRepo    interfaces.OrderRepository
+	provider     payment.PaymentProvider
+	orderService *OrderService
+	log          *zap.SugaredLogger
+}
+
+// orderService may be nil, in which case order statuses are left to the caller.
+func NewPaymentService(
+	uow interfaces.UnitOfWork,
+	paymentRepo interfaces.PaymentRepository,
+	orderRepo interfaces.OrderRepository,
+	provider payment.PaymentProvider,
+	orderService *OrderService,
+	log *zap.SugaredLogger,
+) *PaymentService {
+	return &PaymentService{
+		uow:          uow,
+		paymentRepo:  paymentRepo,
+		orderRepo:    orderRepo,
+		provider:     provider,
+		orderService: orderService,
+		log:          log,
+	}
+}
+
+// StartPayment authorizes the order total and captures it when autoCapture is set.
+// A declined payment cancels the order, a captured one moves it to processing.
+func (s *PaymentService) StartPayment(ctx context.Context, orderID int64, autoCapture bool) (bll.Payment, error) {
+	s.log.Infow("payment_service.start_payment_start", "order_id", orderID, "auto_capture", autoCapture)
+
+	orders, err := s.orderService.GetOrders(ctx, bll.QueryOrderItemsModel{IDs: []int64{orderID}, Page: 1, PageSize: 1})
+	if err != nil {
+		return bll.Payment{}, err
+	}
+	if len(orders) == 0 {
+		return bll.Payment{}, bllerrors.NotFound("ORDER_NOT_FOUND", "order %d not found", orderID)
+	}
+	order := orders[0]
+	if order.Status != bll.ORDER_STATUS_CREATED && order.Status != bll.ORDER_STATUS_PROCESSING {
+		return bll.Payment{}, bllerrors.PreconditionFailed("ORDER_STATUS_MISMATCH", "order %d is %s", orderID, order.Status)
+	}
+
+	p, err := s.Authorize(ctx, order)
+	if err != nil {
+		return bll.Payment{}, err
+	}
+
+	switch {
+	case p.Status == bll.PAYMENT_STATUS_FAILED:
+		if err := s.transitionOrder(ctx, orderID, bll.ORDER_STATUS_CANCELLED); err != nil {
+			return bll.Payment{}, err
+		}
+	case autoCapture && p.Status == bll.PAYMENT_STATUS_AUTHORIZED:
+		if p, err = s.Capture(ctx, p); err != nil {
+			return bll.Payment{}, err
+		}
+		if err := s.transitionOrder(ctx, orderID, bll.ORDER_STATUS_PROCESSING); err != nil {
+			return bll.Payment{}, err
+		}
+	}
+
+	s.log.Infow("payment_service.start_payment_success", "payment_id", p.ID, "status", p.Status)
+	return p, nil
+}
+
+// Authorize reuses the order's live payment if there is one. Declines are recorded as
+// failed payments rather than returned as errors.
+func (s *PaymentService) Authorize(ctx context.Context, order bll.OrderUnit) (bll.Payment, error) {
+	s.log.Infow("payment_service.authorize_start", "order_id", order.ID)
+
+	p, err := s.livePayment(ctx, order)
+	if err != nil {
+		return bll.Payment{}, err
+	}
+	if p.Status != bll.PAYMENT_STATUS_PENDING {
+		s.log.Infow("payment_service.authorize_already_done", "payment_id", p.ID, "status", p.Status)
+		return p, nil
+	}
+
+	providerID, err := s.provider.Authorize(ctx, payment.AuthorizeRequest{
+		OrderID:        order.ID,
+		AmountCents:    p.AmountCents,
+		Currency:       p.Currency,
+		IdempotencyKey: fmt.Sprintf("payment-%d", p.ID),
+	})
+	switch {
+	case errors.Is(err, payment.ErrDeclined):
+		s.log.Warnw("payment_service.authorize_declined", "payment_id", p.ID, "err", err)
+		p.Status = bll.PAYMENT_STATUS_FAILED
+		p.FailureReason = err.Error()
+	case err != nil:
+		s.log.Errorw("payment_service.authorize_failed", "payment_id", p.ID, "err", err)
+		return bll.Payment{}, err
+	default:
+		p.Status = bll.PAYMENT_STATUS_AUTHORIZED
+		p.ProviderPaymentID = providerID
+	}
+
+	if p, err = s.save(ctx, p); err != nil {
+		return bll.Payment{}, err
+	}
+
+	s.log.Infow("payment_service.authorize_success", "payment_id", p.ID, "status", p.Status)
+	return p, nil
+}
+
+// livePayment returns the order's live payment, inserting a pending one if there is none.
+// The order row stays locked from the check to the insert, so concurrent callers for the
+// same order end up with the same payment.
+func (s *PaymentService) livePayment(ctx context.Context, order bll.OrderUnit) (p bll.Payment, err error) {
+	now := time.Now().UTC()
+
+	if _, err = s.uow.BeginTransaction(ctx); err != nil {
+		s.log.Errorw("payment_service.begin_transaction_failed", "err", err)
+		return bll.Payment{}, err
+	}
+	defer func() {
+		if err != nil {
+			s.uow.Rollback(ctx)
+			s.log.Warnw("payment_service.transaction_rollback", "err", err)
+		}
+	}()
+
+	orders, err := s.orderRepo.Query(ctx, dal.QueryOrdersDalModel{IDs: []int64{order.ID}, Limit: 1, ForUpdate: true})
+	if err != nil {
+		s.log.Errorw("payment_service.lock_order_failed", "order_id", order.ID, "err", err)
+		return bll.Payment{}, err
+	}
+	if len(orders) == 0 {
+		err = bllerrors.NotFound("ORDER_NOT_FOUND", "order %d not found", order.ID)
+		return bll.Payment{}, err
+	}
+
+	existing, err := s.paymentRepo.Query(ctx, dal.QueryPaymentsDalModel{
+		OrderIDs: []int64{order.ID},
+		Provider: s.provider.Name(),
+		Statuses: []string{
+			bll.PAYMENT_STATUS_PENDING.String(),
+			bll.PAYMENT_STATUS_AUTHORIZED.String(),
+			bll.PAYMENT_STATUS_CAPTURED.String(),
+		},
+	})
+	if err != nil {
+		s.log.Errorw("payment_service.query_payments_failed", "err", err)
+		return bll.Payment{}, err
+	}
+
+	if len(existing) > 0 {
+		p = mappers.DalPaymentToBll(existing[len(existing)-1])
+	} else {
+		inserted, insertErr := s.paymentRepo.BulkInsert(ctx, []dal.V1PaymentDal{mappers.BllPaymentToDal(bll.Payment{
+			OrderID:     order.ID,
+			Provider:    s.provider.Name(),
+			AmountCents: order.TotalPriceCents,
+			Currency:    order.TotalPriceCurr,
+			Status:      bll.PAYMENT_STATUS_PENDING,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			TenantID:    order.TenantID,
+		})})
+		switch {
+		case bllerrors.IsUniqueViolation(insertErr, paymentLiveOrderIndex):
+			// A payment through another provider is already live for the order.
+			s.log.Warnw("payment_service.insert_payment_conflict", "order_id", order.ID, "err", insertErr)
+			err = bllerrors.Conflict("PAYMENT_ALREADY_LIVE", "order %d already has a live payment", order.ID)
+			return bll.Payment{}, err
+		case insertErr != nil:
+			err = insertErr
+			s.log.Errorw("payment_service.insert_payment_failed", "err", err)
+			return bll.Payment{}, err
+		case len(inserted) == 0:
+			err = fmt.Errorf("insert payment: no rows returned")
+			return bll.Payment{}, err
+		}
+		p = mappers.DalPaymentToBll(inserted[0])
+	}
+
+	if err = s.uow.Commit(ctx); err != nil {
+		s.log.Errorw("payment_service.commit_transaction_failed", "err", err)
+		return bll.Payment{}, err
+	}
+	return p, nil
+}
+
+func (s *PaymentService) Capture(ctx context.Context, p bll.Payment) (bll.Payment, error) {
+	s.log.Infow("payment_service.capture_start", "payment_id", p.ID)
+
+	if p.Status == bll.PAYMENT_STATUS_CAPTURED {
+		return p, nil
+	}
+	if p.Status != bll.PAYMENT_STATUS_AUTHORIZED {
+		return bll.Payment{}, bllerrors.PreconditionFailed("PAYMENT_STATUS_MISMATCH", "payment %d is %s", p.ID, p.Status)
+	}
+
+	if err := s.provider.Capture(ctx, p.ProviderPaymentID, p.AmountCents); err != nil {
+		s.log.Errorw("payment_service.capture_failed", "payment_id", p.ID, "err", err)
+		return bll.Payment{}, err
+	}
+
+	p.Status = bll.PAYMENT_STATUS_CAPTURED
+	p, err := s.save(ctx, p)
+	if err != nil {
+		return bll.Payment{}, err
+	}
+
+	s.log.Infow("payment_service.capture_success", "payment_id", p.ID)
+	return p, nil
+}
+
+// Void cancels an authorization, or refunds the payment if it was already captured.
+func (s *PaymentService) Void(ctx context.Context, paymentID int64) error {
+	s.log.Infow("payment_service.void_start", "payment_id", paymentID)
+
+	payments, err := s.paymentRepo.Query(ctx, dal.QueryPaymentsDalModel{IDs: []int64{paymentID}, Limit: 1})
+	if err != nil {
+		s.log.Errorw("payment_service.query_payments_failed", "err", err)
+		return err
+	}
+	if len(payments) == 0 {
+		return bllerrors.NotFound("PAYMENT_NOT_FOUND", "payment %d not found", paymentID)
+	}
+	p := mappers.DalPaymentToBll(payments[0])
+
+	switch p.Status {
+	case bll.PAYMENT_STATUS_AUTHORIZED:
+		if err := s.provider.Void(ctx, p.ProviderPaymentID); err != nil {
+			s.log.Errorw("payment_service.void_failed", "payment_id", p.ID, "err", err)
+			return err
+		}
+		p.Status = bll.PAYMENT_STATUS_VOIDED
+	case bll.PAYMENT_STATUS_CAPTURED:
+		if err := s.provider.Refund(ctx, p.ProviderPaymentID, p.AmountCents); err != nil {
+			s.log.Errorw("payment_service.refund_failed", "payment_id", p.ID, "err", err)
+			return err
+		}
+		p.Status = bll.PAYMENT_STATUS_REFUNDED
+	case bll.PAYMENT_STATUS_PENDING:
+		p.Status = bll.PAYMENT_STATUS_VOIDED
+	default:
+		return nil
+	}
+
+	if _, err := s.save(ctx, p); err != nil {
+		return err
+	}
+
+	s.log.Infow("payment_service.void_success", "payment_id", p.ID, "status", p.Status)
+	return nil
+}
+
+func (s *PaymentService) HandleCallback(ctx context.Context, event payment.CallbackEvent) (bll.Payment, error) {
+	s.log.Infow("payment_service.handle_callback_start", "event", event.Event, "provider_payment_id", event.ProviderPaymentID)
+
+	payments, err := s.paymentRepo.Query(ctx, dal.QueryPaymentsDalModel{
+		Provider:           event.Provider,
+		ProviderPaymentIDs: []string{event.ProviderPaymentID},
+		Limit:              1,
+	})
+	if err != nil {
+		s.log.Errorw("payment_service.query_payments_failed", "err", err)
+		return bll.Payment{}, err
+	}
+	if len(payments) == 0 {
+		return bll.Payment{}, bllerrors.NotFound("PAYMENT_NOT_FOUND", "payment %s not found", event.ProviderPaymentID)
+	}
+	p := mappers.DalPaymentToBll(payments[0])
+	// Callbacks carry no tenant; everything after the lookup acts for the payment's.
+	ctx = utils.WithTenant(ctx, p.TenantID)
+
+	var target bll.PaymentStatus
+	var orderStatus bll.OrderStatus
+	switch event.Event {
+	case payment.CALLBACK_EVENT_CAPTURED:
+		target, orderStatus = bll.PAYMENT_STATUS_CAPTURED, bll.ORDER_STATUS_PROCESSING
+	case payment.CALLBACK_EVENT_FAILED:
+		target, orderStatus = bll.PAYMENT_STATUS_FAILED, bll.ORDER_STATUS_CANCELLED
+	case payment.CALLBACK_EVENT_VOIDED:
+		target = bll.PAYMENT_STATUS_VOIDED
+	case payment.CALLBACK_EVENT_REFUNDED:
+		target = bll.PAYMENT_STATUS_REFUNDED
+	default:
+		return bll.Payment{}, status.Errorf(codes.InvalidArgument, "unknown payment event %q", event.Event)
+	}
+
+	if p.Status == target {
+		s.log.Infow("payment_service.handle_callback_duplicate", "payment_id", p.ID)
+		return p, nil
+	}
+	if p.Status.IsFinal() {
+		s.log.Warnw("payment_service.handle_callback_ignored", "payment_id", p.ID, "status", p.Status, "event", event.Event)
+		return p, nil
+	}
+
+	p.Status = target
+	if target == bll.PAYMENT_STATUS_FAILED {
+		p.FailureReason = event.Reason
+	}
+	if p, err = s.save(ctx, p); err != nil {
+		return bll.Payment{}, err
+	}
+
+	if orderStatus != "" {
+		if err := s.transitionOrder(ctx, p.OrderID, orderStatus); err != nil {
+			return bll.Payment{}, err
+		}
+	}
+
+	s.log.Infow("payment_service.handle_callback_success", "payment_id", p.ID, "status", p.Status)
+	return p, nil
+}
+
+func (s *PaymentService) GetPayments(ctx context.Context, query bll.QueryPaymentsModel) ([]bll.Payment, error) {
+	s.log.Infow("payment_service.get_payments_start", "query", query)
+
+	payments, err := s.paymentRepo.Query(ctx, dal.QueryPaymentsDalModel{
+		IDs:      query.IDs,
+		OrderIDs: query.OrderIDs,
+		Limit:    query.PageSize,
+		Offset:   query.PageSize * (query.Page - 1),
+	})
+	if err != nil {
+		s.log.Errorw("payment_service.query_payments_failed", "err", err)
+		return nil, err
+	}
+
+	result := make([]bll.Payment, 0, len(payments))
+	for _, p := range payments {
+		result = append(result, mappers.DalPaymentToBll(p))
+	}
+
+	s.log.Infow("payment_service.get_payments_success", "returned_payments_count", len(result))
+	return result, nil
+}
+
+func (s *PaymentService) save(ctx context.Context, p bll.Payment) (bll.Payment, error) {
+	p.UpdatedAt = time.Now().UTC()
+
+	updated, err := s.paymentRepo.BulkUpdate(ctx, []dal.V1PaymentDal{mappers.BllPaymentToDal(p)})
+	if err != nil {
+		s.log.Errorw("payment_service.update_payment_failed", "payment_id", p.ID, "err", err)
+		return bll.Payment{}, err
+	}
+	if len(updated) == 0 {
+		return bll.Payment{}, bllerrors.NotFound("PAYMENT_NOT_FOUND", "payment %d not found", p.ID)
+	}
+	return mappers.DalPaymentToBll(updated[0]), nil
+}
+
+// transitionOrder tolerates orders that already moved past the target status.
+func (s *PaymentService) transitionOrder(ctx context.Context, orderID int64, target bll.OrderStatus) error {
+	if s.orderService == nil {
+		return nil
+	}
+	// Payment outcomes move the order regardless of what the caller may do by hand.
+	ctx = utils.WithAccessScope(ctx, nil)
+
+	_, err := s.orderService.UpdateOrdersStatus(ctx, []int64{orderID}, target)
+	if err != nil {
+		if st, stErr := utils.GetGrpcErrStatus(err); stErr == nil && st.Code() == codes.InvalidArgument {
+			s.log.Warnw("payment_service.order_transition_skipped", "order_id", orderID, "target", target, "err", err)
+			return nil
+		}
+		s.log.Errorw("payment_service.order_transition_failed", "order_id", orderID, "target", target, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (s *PaymentService) UnitOfWork() interfaces.UnitOfWork {
+	return s.uow
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/bllerrors"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/client/payment"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// fakePaymentRepo filters its payments like the postgres query does and fails inserts
+// with insertErr when set.
+type fakePaymentRepo struct {
+	payments  []dal.V1PaymentDal
+	insertErr error
+}
+
+func (r *fakePaymentRepo) BulkInsert(ctx context.Context, payments []dal.V1PaymentDal) ([]dal.V1PaymentDal, error) {
+	if r.insertErr != nil {
+		return nil, r.insertErr
+	}
+	var inserted []dal.V1PaymentDal
+	for _, p := range payments {
+		p.ID = int64(len(r.payments) + 1)
+		r.payments = append(r.payments, p)
+		inserted = append(inserted, p)
+	}
+	return inserted, nil
+}
+
+func (r *fakePaymentRepo) BulkUpdate(ctx context.Context, payments []dal.V1PaymentDal) ([]dal.V1PaymentDal, error) {
+	var updated []dal.V1PaymentDal
+	for _, p := range payments {
+		for i := range r.payments {
+			if r.payments[i].ID == p.ID {
+				r.payments[i] = p
+				updated = append(updated, p)
+			}
+		}
+	}
+	return updated, nil
+}
+
+func (r *fakePaymentRepo) Query(ctx context.Context, q dal.QueryPaymentsDalModel) ([]dal.V1PaymentDal, error) {
+	var result []dal.V1PaymentDal
+	for _, p := range r.payments {
+		switch {
+		case len(q.IDs) > 0 && !slices.Contains(q.IDs, p.ID),
+			len(q.OrderIDs) > 0 && !slices.Contains(q.OrderIDs, p.OrderID),
+			q.Provider != "" && q.Provider != p.Provider,
+			len(q.ProviderPaymentIDs) > 0 && !slices.Contains(q.ProviderPaymentIDs, p.ProviderPaymentID),
+			len(q.Statuses) > 0 && !slices.Contains(q.Statuses, p.Status):
+			continue
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func newTestOrderRepo(status bll.OrderStatus) *fakeOrderRepo {
+	return &fakeOrderRepo{orders: []dal.V1OrderDal{
+		{ID: 1, CustomerID: 42, TotalPriceCents: 1000, TotalPriceCurr: "RUB", Status: status.String()},
+	}}
+}
+
+func TestAuthorizeReusesLivePayment(t *testing.T) {
+	tests := []struct {
+		name         string
+		existing     bll.PaymentStatus
+		wantPayments int
+		wantID       int64
+		wantStatus   bll.PaymentStatus
+	}{
+		{"no payment inserts one", "", 1, 1, bll.PAYMENT_STATUS_AUTHORIZED},
+		{"pending payment is authorized", bll.PAYMENT_STATUS_PENDING, 1, 1, bll.PAYMENT_STATUS_AUTHORIZED},
+		{"authorized payment is returned", bll.PAYMENT_STATUS_AUTHORIZED, 1, 1, bll.PAYMENT_STATUS_AUTHORIZED},
+		{"captured payment is returned", bll.PAYMENT_STATUS_CAPTURED, 1, 1, bll.PAYMENT_STATUS_CAPTURED},
+		{"failed payment is not reused", bll.PAYMENT_STATUS_FAILED, 2, 2, bll.PAYMENT_STATUS_AUTHORIZED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := &fakeUnitOfWork{}
+			payRepo := &fakePaymentRepo{}
+			if tt.existing != "" {
+				payRepo.payments = []dal.V1PaymentDal{{
+					ID: 1, OrderID: 1, Provider: payment.FakeProviderName, ProviderPaymentID: "fake-pay-1-0",
+					AmountCents: 1000, Currency: "RUB", Status: tt.existing.String(),
+				}}
+			}
+			orderRepo := newTestOrderRepo(bll.ORDER_STATUS_CREATED)
+			svc := NewPaymentService(uow, payRepo, orderRepo, payment.NewFakeProvider(0, 0), nil, zap.NewNop().Sugar())
+
+			p, err := svc.Authorize(context.Background(), bll.OrderUnit{ID: 1, TotalPriceCents: 1000, TotalPriceCurr: "RUB"})
+			if err != nil {
+				t.Fatalf("Authorize: %v", err)
+			}
+			if p.ID != tt.wantID || p.Status != tt.wantStatus {
+				t.Errorf("payment = %d %s, want %d %s", p.ID, p.Status, tt.wantID, tt.wantStatus)
+			}
+			if len(payRepo.payments) != tt.wantPayments {
+				t.Errorf("payments = %d, want %d", len(payRepo.payments), tt.wantPayments)
+			}
+			if len(orderRepo.queries) != 1 || !orderRepo.queries[0].ForUpdate {
+				t.Errorf("order queries = %+v, want one locking the order", orderRepo.queries)
+			}
+			if uow.begun != 1 || uow.committed != 1 || uow.rolledBack != 0 {
+				t.Errorf("transactions = %+v, want one committed", uow)
+			}
+
+			// A second call for the same order gets the same payment.
+			again, err := svc.Authorize(context.Background(), bll.OrderUnit{ID: 1, TotalPriceCents: 1000, TotalPriceCurr: "RUB"})
+			if err != nil || again.ID != p.ID || len(payRepo.payments) != tt.wantPayments {
+				t.Errorf("second Authorize = %d, %v with %d payments, want the same payment", again.ID, err, len(payRepo.payments))
+			}
+		})
+	}
+}
+
+func TestAuthorizeInsertConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantKind bllerrors.Kind
+	}{
+		{"live payment index", &pgconn.PgError{Code: "23505", ConstraintName: paymentLiveOrderIndex}, bllerrors.KIND_CONFLICT},
+		{"other unique index", &pgconn.PgError{Code: "23505", ConstraintName: "payments_pkey"}, bllerrors.KIND_INTERNAL},
+		{"not a unique violation", errors.New("connection reset"), bllerrors.KIND_INTERNAL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := &fakeUnitOfWork{}
+			payRepo := &fakePaymentRepo{insertErr: tt.err}
+			svc := NewPaymentService(uow, payRepo, newTestOrderRepo(bll.ORDER_STATUS_CREATED), payment.NewFakeProvider(0, 0), nil, zap.NewNop().Sugar())
+
+			_, err := svc.Authorize(context.Background(), bll.OrderUnit{ID: 1, TotalPriceCents: 1000, TotalPriceCurr: "RUB"})
+			if err == nil {
+				t.Fatal("Authorize succeeded, want the insert error")
+			}
+			if got := bllerrors.IsKind(err, bllerrors.KIND_CONFLICT); got != (tt.wantKind == bllerrors.KIND_CONFLICT) {
+				t.Errorf("err = %v, conflict = %v", err, got)
+			}
+			if uow.rolledBack != 1 || uow.committed != 0 {
+				t.Errorf("transactions = %+v, want one rolled back", uow)
+			}
+		})
+	}
+}
+
+func TestHandleCallbackMovesOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		payment     bll.PaymentStatus
+		order       bll.OrderStatus
+		event       string
+		wantPayment bll.PaymentStatus
+		wantOrder   bll.OrderStatus
+		wantErr     bool
+	}{
+		{"captured moves order to processing", bll.PAYMENT_STATUS_AUTHORIZED, bll.ORDER_STATUS_CREATED, payment.CALLBACK_EVENT_CAPTURED, bll.PAYMENT_STATUS_CAPTURED, bll.ORDER_STATUS_PROCESSING, false},
+		{"failed cancels the order", bll.PAYMENT_STATUS_PENDING, bll.ORDER_STATUS_CREATED, payment.CALLBACK_EVENT_FAILED, bll.PAYMENT_STATUS_FAILED, bll.ORDER_STATUS_CANCELLED, false},
+		{"voided leaves the order", bll.PAYMENT_STATUS_AUTHORIZED, bll.ORDER_STATUS_CREATED, payment.CALLBACK_EVENT_VOIDED, bll.PAYMENT_STATUS_VOIDED, bll.ORDER_STATUS_CREATED, false},
+		{"captured after the order moved on", bll.PAYMENT_STATUS_AUTHORIZED, bll.ORDER_STATUS_COMPLETED, payment.CALLBACK_EVENT_CAPTURED, bll.PAYMENT_STATUS_CAPTURED, bll.ORDER_STATUS_COMPLETED, false},
+		{"duplicate callback", bll.PAYMENT_STATUS_CAPTURED, bll.ORDER_STATUS_PROCESSING, payment.CALLBACK_EVENT_CAPTURED, bll.PAYMENT_STATUS_CAPTURED, bll.ORDER_STATUS_PROCESSING, false},
+		{"unknown event", bll.PAYMENT_STATUS_AUTHORIZED, bll.ORDER_STATUS_CREATED, "disputed", bll.PAYMENT_STATUS_AUTHORIZED, bll.ORDER_STATUS_CREATED, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := &fakeUnitOfWork{}
+			payRepo := &fakePaymentRepo{payments: []dal.V1PaymentDal{{
+				ID: 1, OrderID: 1, Provider: payment.FakeProviderName, ProviderPaymentID: "fake-pay-1-1",
+				AmountCents: 1000, Currency: "RUB", Status: tt.payment.String(),
+			}}}
+			orderRepo := newTestOrderRepo(tt.order)
+			log := zap.NewNop().Sugar()
+			orderSvc := NewOrderService(uow, orderRepo, nil, nil, fakePublisher{}, log)
+			svc := NewPaymentService(uow, payRepo, orderRepo, payment.NewFakeProvider(0, 0), orderSvc, log)
+
+			_, err := svc.HandleCallback(context.Background(), payment.CallbackEvent{
+				Event:             tt.event,
+				Provider:          payment.FakeProviderName,
+				ProviderPaymentID: "fake-pay-1-1",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HandleCallback err = %v", err)
+			}
+			if st := bll.PaymentStatus(payRepo.payments[0].Status); st != tt.wantPayment {
+				t.Errorf("payment status = %s, want %s", st, tt.wantPayment)
+			}
+			if st := bll.StringToOrderStatus(orderRepo.orders[0].Status); st != tt.wantOrder {
+				t.Errorf("order status = %s, want %s", st, tt.wantOrder)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/cache"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/validators"
+	"go.uber.org/zap"
+)
+
+type ProductService struct {
+	uow         interfaces.UnitOfWork
+	productRepo interfaces.ProductRepository
+	cache       *cache.ProductCache
+	log         *zap.SugaredLogger
+}
+
+func NewProductService(
+	uow interfaces.UnitOfWork,
+	productRepo interfaces.ProductRepository,
+	cache *cache.ProductCache,
+	log *zap.SugaredLogger,
+) *ProductService {
+	return &ProductService{
+		uow:         uow,
+		productRepo: productRepo,
+		cache:       cache,
+		log:         log,
+	}
+}
+
+func (s *ProductService) UpsertProducts(ctx context.Context, products []bll.Product) ([]bll.Product, error) {
+	now := time.Now().UTC()
+	s.log.Infow("product_service.upsert_products_start", "products_count", len(products))
+
+	dalProducts := make([]dal.V1ProductDal, 0, len(products))
+	ids := make([]int64, 0, len(products))
+	for _, p := range products {
+		p.CreatedAt = now
+		p.UpdatedAt = now
+		dalProducts = append(dalProducts, mappers.BllProductToDal(p))
+		ids = append(ids, p.ID)
+	}
+
+	upserted, err := s.productRepo.BulkUpsert(ctx, dalProducts)
+	if err != nil {
+		s.log.Errorw("product_service.bulk_upsert_products_failed", "err", err)
+		return nil, err
+	}
+	s.cache.Invalidate(ids)
+
+	result := make([]bll.Product, 0, len(upserted))
+	for _, p := range upserted {
+		result = append(result, mappers.DalProductToBll(p))
+	}
+
+	s.log.Infow("product_service.upsert_products_success", "upserted_products_count", len(result))
+	return result, nil
+}
+
+func (s *ProductService) DeleteProducts(ctx context.Context, ids []int64) error {
+	s.log.Infow("product_service.delete_products_start", "ids", ids)
+
+	deleted, err := s.productRepo.Delete(ctx, ids)
+	if err != nil {
+		s.log.Errorw("product_service.delete_products_failed", "err", err)
+		return err
+	}
+	s.cache.Invalidate(ids)
+
+	s.log.Infow("product_service.delete_products_success", "deleted_products_count", deleted)
+	return nil
+}
+
+func (s *ProductService) GetProducts(ctx context.Context, query bll.QueryProductsModel) ([]bll.Product, error) {
+	s.log.Infow("product_service.get_products_start", "query", query)
+
+	products, err := s.productRepo.Query(ctx, dal.QueryProductsDalModel{
+		IDs:    query.IDs,
+		Limit:  query.PageSize,
+		Offset: query.PageSize * (query.Page - 1),
+	})
+	if err != nil {
+		s.log.Errorw("product_service.query_products_failed", "err", err)
+		return nil, err
+	}
+
+	result := make([]bll.Product, 0, len(products))
+	for _, p := range products {
+		result = append(result, mappers.DalProductToBll(p))
+	}
+
+	s.log.Infow("product_service.get_products_success", "returned_products_count", len(result))
+	return result, nil
+}
+
+// ApplyCatalog checks order items against the catalog according to mode and fills
+// product details in place. Order totals are recomputed when prices are overridden.
+func (s *ProductService) ApplyCatalog(ctx context.Context, orders []bll.OrderUnit, mode bll.ProductValidationMode) (validators.ValidationErrors, error) {
+	if mode == bll.PRODUCT_VALIDATION_MODE_OFF {
+		return nil, nil
+	}
+	s.log.Infow("product_service.apply_catalog_start", "orders_count", len(orders), "mode", mode)
+
+	var ids []int64
+	for _, o := range orders {
+		for _, it := range o.OrderItems {
+			ids = append(ids, it.ProductID)
+		}
+	}
+
+	products, err := s.lookup(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(validators.ValidationErrors)
+	for i := range orders {
+		order := &orders[i]
+		prefix := fmt.Sprintf("orders[%d]", i)
+
+		for j := range order.OrderItems {
+			item := &order.OrderItems[j]
+			iprefix := fmt.Sprintf("%s.order_items[%d]", prefix, j)
+
+			p, ok := products[item.ProductID]
+			if !ok {
+				errs[iprefix+".product_id"] = fmt.Sprintf("UnknownProduct: product %d not found in catalog", item.ProductID)
+				continue
+			}
+
+			item.ProductTitle = p.Title
+			item.ProductURL = p.URL
+
+			if mode == bll.PRODUCT_VALIDATION_MODE_OVERRIDE {
+				item.PriceCents = p.PriceCents
+				item.PriceCurr = p.PriceCurrency
+				continue
+			}
+			if item.PriceCents != p.PriceCents || item.PriceCurr != p.PriceCurrency {
+				errs[iprefix+".price_cents"] = fmt.Sprintf("PriceMismatch: expected %d %s, got %d %s",
+					p.PriceCents, p.PriceCurrency, item.PriceCents, item.PriceCurr)
+			}
+		}
+
+		if mode == bll.PRODUCT_VALIDATION_MODE_OVERRIDE && len(order.OrderItems) > 0 {
+			var total int64
+			currencies := make(map[string]struct{})
+			for _, it := range order.OrderItems {
+				total += it.PriceCents * int64(it.Quantity)
+				currencies[it.PriceCurr] = struct{}{}
+			}
+			if len(currencies) > 1 {
+				errs[prefix+".order_items.price_currency"] = "all catalog products must have the same currency"
+			}
+			order.TotalPriceCents = total
+			order.TotalPriceCurr = order.OrderItems[0].PriceCurr
+		}
+	}
+	if len(errs) > 0 {
+		s.log.Warnw("product_service.apply_catalog_failed", "errs", errs)
+		return errs, nil
+	}
+
+	s.log.Infow("product_service.apply_catalog_success")
+	return nil, nil
+}
+
+func (s *ProductService) lookup(ctx context.Context, ids []int64) (map[int64]bll.Product, error) {
+	found, missing := s.cache.Get(ids)
+	if len(missing) == 0 {
+		return found, nil
+	}
+
+	loaded, err := s.productRepo.Query(ctx, dal.QueryProductsDalModel{IDs: missing})
+	if err != nil {
+		s.log.Errorw("product_service.query_products_failed", "err", err)
+		return nil, err
+	}
+
+	products := make([]bll.Product, 0, len(loaded))
+	for _, p := range loaded {
+		product := mappers.DalProductToBll(p)
+		found[product.ID] = product
+		products = append(products, product)
+	}
+	s.cache.Set(products)
+
+	return found, nil
+}
+
+func (s *ProductService) UnitOfWork() interfaces.UnitOfWork {
+	return s.uow
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"go.uber.org/zap"
+)
+
+type SagaService struct {
+	uow      interfaces.UnitOfWork
+	sagaRepo interfaces.SagaRepository
+	log      *zap.SugaredLogger
+}
+
+func NewSagaService(uow interfaces.UnitOfWork, sagaRepo interfaces.SagaRepository, log *zap.SugaredLogger) *SagaService {
+	return &SagaService{
+		uow:      uow,
+		sagaRepo: sagaRepo,
+		log:      log,
+	}
+}
+
+// CreateSaga returns false when the order already has a saga.
+func (s *SagaService) CreateSaga(ctx context.Context, orderID int64, steps []string) (bll.Saga, bool, error) {
+	now := time.Now().UTC()
+	s.log.Infow("saga_service.create_saga_start", "order_id", orderID, "steps", steps)
+
+	d, err := mappers.BllSagaToDal(bll.Saga{
+		OrderID:   orderID,
+		Status:    bll.SAGA_STATUS_RUNNING,
+		Steps:     steps,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return bll.Saga{}, false, err
+	}
+
+	inserted, err := s.sagaRepo.BulkInsert(ctx, []dal.V1SagaDal{d})
+	if err != nil {
+		s.log.Errorw("saga_service.insert_saga_failed", "err", err)
+		return bll.Saga{}, false, err
+	}
+	if len(inserted) == 0 {
+		s.log.Infow("saga_service.saga_already_exists", "order_id", orderID)
+		return bll.Saga{}, false, nil
+	}
+
+	saga, err := mappers.DalSagaToBll(inserted[0])
+	if err != nil {
+		return bll.Saga{}, false, err
+	}
+
+	s.log.Infow("saga_service.create_saga_success", "saga_id", saga.ID)
+	return saga, true, nil
+}
+
+func (s *SagaService) SaveSaga(ctx context.Context, saga bll.Saga) (bll.Saga, error) {
+	saga.UpdatedAt = time.Now().UTC()
+
+	d, err := mappers.BllSagaToDal(saga)
+	if err != nil {
+		return bll.Saga{}, err
+	}
+
+	updated, err := s.sagaRepo.BulkUpdate(ctx, []dal.V1SagaDal{d})
+	if err != nil {
+		s.log.Errorw("saga_service.update_saga_failed", "saga_id", saga.ID, "err", err)
+		return bll.Saga{}, err
+	}
+	if len(updated) == 0 {
+		return saga, nil
+	}
+
+	return mappers.DalSagaToBll(updated[0])
+}
+
+func (s *SagaService) GetInFlightSagas(ctx context.Context) ([]bll.Saga, error) {
+	s.log.Infow("saga_service.get_in_flight_sagas_start")
+
+	sagasDal, err := s.sagaRepo.Query(ctx, dal.QuerySagasDalModel{
+		Statuses: []string{bll.SAGA_STATUS_RUNNING.String(), bll.SAGA_STATUS_COMPENSATING.String()},
+	})
+	if err != nil {
+		s.log.Errorw("saga_service.query_sagas_failed", "err", err)
+		return nil, err
+	}
+
+	result := make([]bll.Saga, 0, len(sagasDal))
+	for _, d := range sagasDal {
+		saga, err := mappers.DalSagaToBll(d)
+		if err != nil {
+			s.log.Errorw("saga_service.map_saga_failed", "saga_id", d.ID, "err", err)
+			continue
+		}
+		result = append(result, saga)
+	}
+
+	s.log.Infow("saga_service.get_in_flight_sagas_success", "sagas_count", len(result))
+	return result, nil
+}
+
+// GetSaga returns false when there is no saga with the id.
+func (s *SagaService) GetSaga(ctx context.Context, sagaID int64) (bll.Saga, bool, error) {
+	sagasDal, err := s.sagaRepo.Query(ctx, dal.QuerySagasDalModel{IDs: []int64{sagaID}, Limit: 1})
+	if err != nil {
+		s.log.Errorw("saga_service.query_sagas_failed", "saga_id", sagaID, "err", err)
+		return bll.Saga{}, false, err
+	}
+	if len(sagasDal) == 0 {
+		return bll.Saga{}, false, nil
+	}
+
+	saga, err := mappers.DalSagaToBll(sagasDal[0])
+	if err != nil {
+		return bll.Saga{}, false, err
+	}
+	return saga, true, nil
+}
+
+// GetOrderSaga returns false when the order has no saga.
+func (s *SagaService) GetOrderSaga(ctx context.Context, orderID int64) (bll.Saga, bool, error) {
+	sagasDal, err := s.sagaRepo.Query(ctx, dal.QuerySagasDalModel{OrderIDs: []int64{orderID}, Limit: 1})
+	if err != nil {
+		s.log.Errorw("saga_service.query_sagas_failed", "order_id", orderID, "err", err)
+		return bll.Saga{}, false, err
+	}
+	if len(sagasDal) == 0 {
+		return bll.Saga{}, false, nil
+	}
+
+	saga, err := mappers.DalSagaToBll(sagasDal[0])
+	if err != nil {
+		return bll.Saga{}, false, err
+	}
+	return saga, true, nil
+}
+
+func (s *SagaService) UnitOfWork() interfaces.UnitOfWork {
+	return s.uow
+}
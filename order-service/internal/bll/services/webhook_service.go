@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/bllerrors"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+)
+
+const webhookSecretBytes = 32
+
+type WebhookService struct {
+	uow              interfaces.UnitOfWork
+	subscriptionRepo interfaces.WebhookSubscriptionRepository
+	deliveryRepo     interfaces.WebhookDeliveryRepository
+	log              *zap.SugaredLogger
+}
+
+func NewWebhookService(
+	uow interfaces.UnitOfWork,
+	subscriptionRepo interfaces.WebhookSubscriptionRepository,
+	deliveryRepo interfaces.WebhookDeliveryRepository,
+	log *zap.SugaredLogger,
+) *WebhookService {
+	return &WebhookService{
+		uow:              uow,
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		log:              log,
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, sub bll.WebhookSubscription) (bll.WebhookSubscription, error) {
+	now := time.Now().UTC()
+	s.log.Infow("webhook_service.create_subscription_start", "url", sub.URL, "event_types", sub.EventTypes)
+
+	if sub.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			s.log.Errorw("webhook_service.generate_secret_failed", "err", err)
+			return bll.WebhookSubscription{}, err
+		}
+		sub.Secret = secret
+	}
+	sub.IsActive = true
+	sub.TenantID = utils.TenantOrDefault(ctx)
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+
+	inserted, err := s.subscriptionRepo.BulkInsert(ctx, []dal.V1WebhookSubscriptionDal{mappers.BllWebhookSubscriptionToDal(sub)})
+	if err != nil {
+		s.log.Errorw("webhook_service.insert_subscription_failed", "err", err)
+		return bll.WebhookSubscription{}, err
+	}
+	if len(inserted) == 0 {
+		return bll.WebhookSubscription{}, fmt.Errorf("insert subscription: no rows returned")
+	}
+
+	result := mappers.DalWebhookSubscriptionToBll(inserted[0])
+	s.log.Infow("webhook_service.create_subscription_success", "subscription_id", result.ID)
+	return result, nil
+}
+
+func (s *WebhookService) UpdateSubscription(ctx context.Context, sub bll.WebhookSubscription, rotateSecret bool) (bll.WebhookSubscription, error) {
+	now := time.Now().UTC()
+	s.log.Infow("webhook_service.update_subscription_start", "subscription_id", sub.ID)
+
+	existing, err := s.subscriptionRepo.Query(ctx, dal.QueryWebhookSubscriptionsDalModel{IDs: []int64{sub.ID}, Limit: 1})
+	if err != nil {
+		s.log.Errorw("webhook_service.query_subscription_failed", "err", err)
+		return bll.WebhookSubscription{}, err
+	}
+	if len(existing) == 0 {
+		s.log.Warnw("webhook_service.subscription_not_found", "subscription_id", sub.ID)
+		return bll.WebhookSubscription{}, bllerrors.NotFound("WEBHOOK_SUBSCRIPTION_NOT_FOUND", "webhook subscription %d not found", sub.ID)
+	}
+
+	current := mappers.DalWebhookSubscriptionToBll(existing[0])
+	current.URL = sub.URL
+	current.EventTypes = sub.EventTypes
+	current.CustomerIDs = sub.CustomerIDs
+	current.UpdatedAt = now
+
+	if sub.IsActive && !current.IsActive {
+		current.ConsecutiveFailures = 0
+		current.DisabledAt = nil
+	}
+	if !sub.IsActive && current.IsActive {
+		current.DisabledAt = &now
+	}
+	current.IsActive = sub.IsActive
+
+	if rotateSecret {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			s.log.Errorw("webhook_service.generate_secret_failed", "err", err)
+			return bll.WebhookSubscription{}, err
+		}
+		current.Secret = secret
+	}
+
+	updated, err := s.subscriptionRepo.BulkUpdate(ctx, []dal.V1WebhookSubscriptionDal{mappers.BllWebhookSubscriptionToDal(current)})
+	if err != nil {
+		s.log.Errorw("webhook_service.update_subscription_failed", "err", err)
+		return bll.WebhookSubscription{}, err
+	}
+	if len(updated) == 0 {
+		return bll.WebhookSubscription{}, bllerrors.NotFound("WEBHOOK_SUBSCRIPTION_NOT_FOUND", "webhook subscription %d not found", sub.ID)
+	}
+
+	s.log.Infow("webhook_service.update_subscription_success", "subscription_id", sub.ID, "secret_rotated", rotateSecret)
+	return mappers.DalWebhookSubscriptionToBll(updated[0]), nil
+}
+
+func (s *WebhookService) DeleteSubscriptions(ctx context.Context, ids []int64) error {
+	s.log.Infow("webhook_service.delete_subscriptions_start", "ids", ids)
+
+	deleted, err := s.subscriptionRepo.Delete(ctx, ids)
+	if err != nil {
+		s.log.Errorw("webhook_service.delete_subscriptions_failed", "err", err)
+		return err
+	}
+
+	s.log.Infow("webhook_service.delete_subscriptions_success", "deleted_count", deleted)
+	return nil
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context, query bll.QueryWebhookSubscriptionsModel) ([]bll.WebhookSubscription, error) {
+	s.log.Infow("webhook_service.get_subscriptions_start", "query", query)
+
+	subs, err := s.subscriptionRepo.Query(ctx, dal.QueryWebhookSubscriptionsDalModel{
+		IDs:        query.IDs,
+		OnlyActive: query.OnlyActive,
+		Limit:      query.PageSize,
+		Offset:     query.PageSize * (query.Page - 1),
+	})
+	if err != nil {
+		s.log.Errorw("webhook_service.query_subscriptions_failed", "err", err)
+		return nil, err
+	}
+
+	result := make([]bll.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, mappers.DalWebhookSubscriptionToBll(sub))
+	}
+
+	s.log.Infow("webhook_service.get_subscriptions_success", "returned_count", len(result))
+	return result, nil
+}
+
+func (s *WebhookService) GetActiveSubscriptions(ctx context.Context, eventTypes []bll.WebhookEventType) ([]bll.WebhookSubscription, error) {
+	types := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		types = append(types, t.String())
+	}
+
+	subs, err := s.subscriptionRepo.Query(ctx, dal.QueryWebhookSubscriptionsDalModel{
+		EventTypes: types,
+		OnlyActive: true,
+	})
+	if err != nil {
+		s.log.Errorw("webhook_service.query_active_subscriptions_failed", "err", err)
+		return nil, err
+	}
+
+	result := make([]bll.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, mappers.DalWebhookSubscriptionToBll(sub))
+	}
+	return result, nil
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, query bll.QueryWebhookDeliveriesModel) ([]bll.WebhookDelivery, error) {
+	s.log.Infow("webhook_service.get_deliveries_start", "query", query)
+
+	deliveries, err := s.deliveryRepo.Query(ctx, dal.QueryWebhookDeliveriesDalModel{
+		SubscriptionIDs: query.SubscriptionIDs,
+		OnlyFailed:      query.OnlyFailed,
+		Limit:           query.PageSize,
+		Offset:          query.PageSize * (query.Page - 1),
+	})
+	if err != nil {
+		s.log.Errorw("webhook_service.query_deliveries_failed", "err", err)
+		return nil, err
+	}
+
+	result := make([]bll.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, mappers.DalWebhookDeliveryToBll(d))
+	}
+
+	s.log.Infow("webhook_service.get_deliveries_success", "returned_count", len(result))
+	return result, nil
+}
+
+// GetSucceededDeliveries returns the successful attempts for the events, so redelivered
+// events are only sent to the subscriptions that haven't received them yet.
+func (s *WebhookService) GetSucceededDeliveries(ctx context.Context, eventIDs []string) ([]bll.WebhookDelivery, error) {
+	deliveries, err := s.deliveryRepo.Query(ctx, dal.QueryWebhookDeliveriesDalModel{
+		EventIDs:      eventIDs,
+		OnlySucceeded: true,
+	})
+	if err != nil {
+		s.log.Errorw("webhook_service.query_deliveries_failed", "err", err)
+		return nil, err
+	}
+
+	result := make([]bll.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, mappers.DalWebhookDeliveryToBll(d))
+	}
+	return result, nil
+}
+
+func (s *WebhookService) RecordDeliveries(ctx context.Context, deliveries []bll.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	dalDeliveries := make([]dal.V1WebhookDeliveryDal, 0, len(deliveries))
+	for _, d := range deliveries {
+		dalDeliveries = append(dalDeliveries, mappers.BllWebhookDeliveryToDal(d))
+	}
+
+	if _, err := s.deliveryRepo.BulkInsert(ctx, dalDeliveries); err != nil {
+		s.log.Errorw("webhook_service.insert_deliveries_failed", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (s *WebhookService) RegisterDeliveryResult(ctx context.Context, subscriptionID int64, success bool, disableAfter int) error {
+	now := time.Now().UTC()
+
+	if success {
+		if err := s.subscriptionRepo.RegisterSuccess(ctx, subscriptionID, now); err != nil {
+			s.log.Errorw("webhook_service.register_success_failed", "subscription_id", subscriptionID, "err", err)
+			return err
+		}
+		return nil
+	}
+
+	sub, err := s.subscriptionRepo.RegisterFailure(ctx, subscriptionID, disableAfter, now)
+	if err != nil {
+		s.log.Errorw("webhook_service.register_failure_failed", "subscription_id", subscriptionID, "err", err)
+		return err
+	}
+	if !sub.IsActive && disableAfter > 0 && sub.ConsecutiveFailures == disableAfter {
+		s.log.Warnw("webhook_service.subscription_disabled",
+			"subscription_id", subscriptionID,
+			"consecutive_failures", sub.ConsecutiveFailures)
+	}
+	return nil
+}
+
+func (s *WebhookService) UnitOfWork() interfaces.UnitOfWork {
+	return s.uow
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("read random: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"go.uber.org/zap"
+)
+
+// fakeSubscriptionRepo keeps one subscription and applies the same disable rule as the
+// postgres RegisterFailure query.
+type fakeSubscriptionRepo struct {
+	interfaces.WebhookSubscriptionRepository
+
+	sub          dal.V1WebhookSubscriptionDal
+	disableAfter []int
+}
+
+func (r *fakeSubscriptionRepo) RegisterSuccess(ctx context.Context, id int64, now time.Time) error {
+	r.sub.ConsecutiveFailures = 0
+	r.sub.UpdatedAt = now
+	return nil
+}
+
+func (r *fakeSubscriptionRepo) RegisterFailure(ctx context.Context, id int64, disableAfter int, now time.Time) (dal.V1WebhookSubscriptionDal, error) {
+	r.disableAfter = append(r.disableAfter, disableAfter)
+	r.sub.ConsecutiveFailures++
+	if r.sub.IsActive && disableAfter > 0 && r.sub.ConsecutiveFailures >= disableAfter {
+		r.sub.IsActive = false
+		r.sub.DisabledAt = &now
+	}
+	r.sub.UpdatedAt = now
+	return r.sub, nil
+}
+
+func TestWebhookServiceRegisterDeliveryResult(t *testing.T) {
+	tests := []struct {
+		name         string
+		results      []bool
+		disableAfter int
+		wantActive   bool
+		wantFailures int
+	}{
+		{"success keeps subscription active", []bool{true}, 3, true, 0},
+		{"failures below threshold", []bool{false, false}, 3, true, 2},
+		{"disabled at threshold", []bool{false, false, false}, 3, false, 3},
+		{"success resets the failure streak", []bool{false, false, true, false, false}, 3, true, 2},
+		{"zero threshold never disables", []bool{false, false, false, false}, 0, true, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSubscriptionRepo{sub: dal.V1WebhookSubscriptionDal{ID: 1, IsActive: true}}
+			svc := NewWebhookService(nil, repo, nil, zap.NewNop().Sugar())
+
+			for _, success := range tt.results {
+				if err := svc.RegisterDeliveryResult(context.Background(), 1, success, tt.disableAfter); err != nil {
+					t.Fatalf("RegisterDeliveryResult: %v", err)
+				}
+			}
+
+			if repo.sub.IsActive != tt.wantActive {
+				t.Errorf("active = %v, want %v", repo.sub.IsActive, tt.wantActive)
+			}
+			if (repo.sub.DisabledAt != nil) == tt.wantActive {
+				t.Errorf("disabled_at = %v with active = %v", repo.sub.DisabledAt, repo.sub.IsActive)
+			}
+			if repo.sub.ConsecutiveFailures != tt.wantFailures {
+				t.Errorf("consecutive failures = %d, want %d", repo.sub.ConsecutiveFailures, tt.wantFailures)
+			}
+			for _, n := range repo.disableAfter {
+				if n != tt.disableAfter {
+					t.Errorf("RegisterFailure got disableAfter %d, want %d", n, tt.disableAfter)
+				}
+			}
+		})
+	}
+}
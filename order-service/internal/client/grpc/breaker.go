@@ -0,0 +1,84 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/sony/gobreaker/v2"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Only failures that say the server is unreachable or overloaded trip the breaker; a bad
+// request is the caller's problem, not the server's.
+func breakerFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// breakerUnaryInterceptor sits in front of the retrying channel, so it only sees a failure
+// once the retries are used up. While the breaker is open, calls fail with Unavailable and
+// a RetryInfo telling the caller when the next trial call will be let through.
+func breakerUnaryInterceptor(cfg config.CircuitBreakerSettings, name string, log *zap.SugaredLogger) grpc.UnaryClientInterceptor {
+	openTimeout := time.Duration(cfg.OpenTimeoutSeconds) * time.Second
+	cb := gobreaker.NewCircuitBreaker[struct{}](gobreaker.Settings{
+		Name:        name,
+		MaxRequests: uint32(cfg.HalfOpenMaxRequests),
+		Timeout:     openTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= uint32(cfg.ConsecutiveFailures)
+		},
+		IsSuccessful: func(err error) bool {
+			return !breakerFailure(err)
+		},
+		IsExcluded: func(err error) bool {
+			// The caller gave up; that says nothing about the server.
+			return errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			log.Warnw("grpc_client.circuit_breaker_state_changed", "client", name, "from", from.String(), "to", to.String())
+		},
+	})
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		_, err := cb.Execute(func() (struct{}, error) {
+			return struct{}{}, invoker(ctx, method, req, reply, cc, opts...)
+		})
+		if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+			return circuitOpenError(name, openTimeout)
+		}
+		return err
+	}
+}
+
+func circuitOpenError(name string, retryAfter time.Duration) error {
+	st := status.New(codes.Unavailable, "circuit breaker for "+name+" is open")
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// Unavailable reports whether err means the server can't be reached right now, and how
+// long the caller should wait before trying again if the server said so.
+func Unavailable(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Unavailable {
+		return 0, false
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, true
+}
@@ -0,0 +1,107 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreakerFailure(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{status.Error(codes.Unavailable, "down"), true},
+		{status.Error(codes.DeadlineExceeded, "slow"), true},
+		{status.Error(codes.InvalidArgument, "bad"), false},
+		{status.Error(codes.NotFound, "missing"), false},
+		{status.Error(codes.FailedPrecondition, "conflict"), false},
+		{status.Error(codes.PermissionDenied, "denied"), false},
+		{status.Error(codes.ResourceExhausted, "limited"), false},
+		{status.Error(codes.Internal, "bug"), false},
+		{status.Error(codes.Canceled, "gone"), false},
+		{errors.New("not a status"), false},
+	}
+	for _, tt := range tests {
+		if got := breakerFailure(tt.err); got != tt.want {
+			t.Errorf("breakerFailure(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantUnavail   bool
+		wantRetryTime time.Duration
+	}{
+		{"open breaker", circuitOpenError("oms", 30*time.Second), true, 30 * time.Second},
+		{"plain unavailable", status.Error(codes.Unavailable, "down"), true, 0},
+		{"other code", status.Error(codes.Internal, "bug"), false, 0},
+		{"not a status", fmt.Errorf("wrapped: %w", errors.New("boom")), false, 0},
+		{"nil", nil, false, 0},
+	}
+	for _, tt := range tests {
+		retryAfter, ok := Unavailable(tt.err)
+		if ok != tt.wantUnavail || retryAfter != tt.wantRetryTime {
+			t.Errorf("%s: Unavailable = %v, %v; want %v, %v", tt.name, retryAfter, ok, tt.wantRetryTime, tt.wantUnavail)
+		}
+	}
+}
+
+func TestBreakerOpens(t *testing.T) {
+	interceptor := breakerUnaryInterceptor(config.CircuitBreakerSettings{
+		Enabled:             true,
+		ConsecutiveFailures: 2,
+		OpenTimeoutSeconds:  30,
+		HalfOpenMaxRequests: 1,
+	}, "oms", zap.NewNop().Sugar())
+
+	calls := 0
+	call := func(err error) error {
+		return interceptor(context.Background(), "/svc/Method", nil, nil, nil,
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls++
+				return err
+			})
+	}
+
+	// Neither caller errors nor cancellations count against the server, and a caller
+	// error still resets the run of failures.
+	for _, err := range []error{
+		status.Error(codes.InvalidArgument, "bad"),
+		status.Error(codes.Unavailable, "down"),
+		status.Error(codes.Canceled, "gone"),
+		context.Canceled,
+		status.Error(codes.NotFound, "missing"),
+		status.Error(codes.Unavailable, "down"),
+	} {
+		if got := call(err); got != err {
+			t.Fatalf("closed breaker returned %v for %v", got, err)
+		}
+	}
+
+	// The second consecutive failure opens it.
+	call(status.Error(codes.DeadlineExceeded, "slow"))
+	calls = 0
+	err := call(nil)
+	if calls != 0 {
+		t.Error("open breaker called the server")
+	}
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("open breaker = %v, want Unavailable", err)
+	}
+	if retryAfter, ok := Unavailable(err); !ok || retryAfter != 30*time.Second {
+		t.Errorf("open breaker retry after = %v, %v; want 30s", retryAfter, ok)
+	}
+}
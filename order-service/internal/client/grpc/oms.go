@@ -3,12 +3,17 @@ package grpcclient
 import (
 	"context"
 	"fmt"
-	"time"
 
 	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
 	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tlsconfig"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 type OmsGrpcClient struct {
@@ -16,11 +21,39 @@ type OmsGrpcClient struct {
 	client pb.OrderServiceClient
 }
 
-func NewOmsGrpcClient(cfg config.GrpcClientSettings) (*OmsGrpcClient, error) {
-	conn, err := grpc.Dial(
-		cfg.Address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+func NewOmsGrpcClient(cfg config.GrpcClientSettings, log *zap.SugaredLogger) (*OmsGrpcClient, error) {
+	transportCreds := insecure.NewCredentials()
+	if cfg.Tls.Enabled {
+		tlsCfg, err := tlsconfig.NewClientConfig(cfg.Tls)
+		if err != nil {
+			return nil, err
+		}
+		transportCreds = credentials.NewTLS(tlsCfg)
+	}
+
+	sc, err := serviceConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("grpc service config: %w", err)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithDefaultServiceConfig(sc),
+	}
+	if cfg.CircuitBreaker.Enabled {
+		opts = append(opts, grpc.WithUnaryInterceptor(breakerUnaryInterceptor(cfg.CircuitBreaker, "oms", log)))
+	}
+	if cfg.ServiceTokenFile != "" {
+		creds, err := newServiceTokenCredentials(cfg.ServiceTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(creds))
+	}
+
+	// NewClient resolves through DNS by default, which round_robin needs to see every replica.
+	conn, err := grpc.NewClient(cfg.Address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to grpc server: %w", err)
 	}
@@ -32,14 +65,20 @@ func NewOmsGrpcClient(cfg config.GrpcClientSettings) (*OmsGrpcClient, error) {
 	}, nil
 }
 
+// WithTenant makes the calls made with ctx act on behalf of the tenant.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	if tenantID == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, utils.TENANT_METADATA_KEY, tenantID)
+}
+
 func (c *OmsGrpcClient) Close() error {
 	return c.conn.Close()
 }
 
+// Deadlines and retries come from the service config built in NewOmsGrpcClient.
 func (c *OmsGrpcClient) LogOrder(ctx context.Context, req *pb.AuditLogOrderBatchCreateRequest) (*pb.AuditLogOrderBatchCreateResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
 	resp, err := c.client.AuditLogOrderBatchCreate(ctx, req)
 	if err != nil {
 		return nil, err
@@ -49,9 +88,6 @@ func (c *OmsGrpcClient) LogOrder(ctx context.Context, req *pb.AuditLogOrderBatch
 }
 
 func (c *OmsGrpcClient) QueryOrders(ctx context.Context, req *pb.QueryOrdersRequest) (*pb.QueryOrdersResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
 	resp, err := c.client.QueryOrders(ctx, req)
 	if err != nil {
 		return nil, err
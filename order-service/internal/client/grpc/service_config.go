@@ -0,0 +1,83 @@
+package grpcclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+)
+
+type methodName struct {
+	Service string `json:"service,omitempty"`
+	Method  string `json:"method,omitempty"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	Timeout     string       `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// serviceConfig builds the gRPC service config for cfg, see
+// https://github.com/grpc/grpc/blob/master/doc/service_config.md. An empty name in the
+// first entry makes it the default for every method.
+func serviceConfig(cfg config.GrpcClientSettings) (string, error) {
+	var retry *retryPolicy
+	if cfg.Retry.Enabled {
+		retry = &retryPolicy{
+			MaxAttempts:          cfg.Retry.MaxAttempts,
+			InitialBackoff:       protoDuration(cfg.Retry.InitialBackoffMs),
+			MaxBackoff:           protoDuration(cfg.Retry.MaxBackoffMs),
+			BackoffMultiplier:    cfg.Retry.BackoffMultiplier,
+			RetryableStatusCodes: cfg.Retry.RetryableCodes,
+		}
+	}
+
+	methods := []methodConfig{{
+		Name:        []methodName{{}},
+		Timeout:     protoDuration(cfg.TimeoutMs),
+		RetryPolicy: retry,
+	}}
+	for _, m := range cfg.MethodTimeouts {
+		service, method, ok := strings.Cut(strings.TrimPrefix(m.Method, "/"), "/")
+		if !ok {
+			return "", fmt.Errorf("method %q is not a full method name", m.Method)
+		}
+		methods = append(methods, methodConfig{
+			Name:        []methodName{{Service: service, Method: method}},
+			Timeout:     protoDuration(m.TimeoutMs),
+			RetryPolicy: retry,
+		})
+	}
+
+	sc := struct {
+		LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig,omitempty"`
+		MethodConfig        []methodConfig        `json:"methodConfig"`
+	}{MethodConfig: methods}
+	if cfg.LoadBalancingPolicy != "" {
+		sc.LoadBalancingConfig = []map[string]struct{}{{cfg.LoadBalancingPolicy: {}}}
+	}
+
+	raw, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func protoDuration(ms int) string {
+	if ms <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%gs", (time.Duration(ms) * time.Millisecond).Seconds())
+}
@@ -0,0 +1,128 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+)
+
+const (
+	HeaderWebhookEvent     = "X-Oms-Event"
+	HeaderWebhookEventID   = "X-Oms-Event-Id"
+	HeaderWebhookAttempt   = "X-Oms-Delivery-Attempt"
+	HeaderWebhookTimestamp = "X-Oms-Timestamp"
+	HeaderWebhookSignature = "X-Oms-Signature"
+)
+
+type WebhookRequest struct {
+	URL       string
+	Secret    string
+	EventType string
+	EventID   string
+	Attempt   int
+	Body      []byte
+}
+
+// ErrWebhookTargetNotPublic is returned for deliveries whose host resolves to a loopback,
+// link-local or private address.
+var ErrWebhookTargetNotPublic = errors.New("webhook target is not a public address")
+
+type WebhookClient struct {
+	client *http.Client
+}
+
+// NewWebhookClient checks every address it connects to, redirects included, so a host that
+// passed validation can't be re-pointed at this host or its network later.
+// allowPrivateTargets turns the check off for local setups.
+func NewWebhookClient(timeout time.Duration, allowPrivateTargets bool) *WebhookClient {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateTargets {
+		dialer.Control = checkPublicAddr
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Through a proxy the dial check would only see the proxy's address.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &WebhookClient{
+		client: &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+func checkPublicAddr(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrWebhookTargetNotPublic, address)
+	}
+	if !utils.IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrWebhookTargetNotPublic, addrPort.Addr())
+	}
+	return nil
+}
+
+func (c *WebhookClient) Send(ctx context.Context, req WebhookRequest) (int, error) {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(HeaderWebhookEvent, req.EventType)
+	httpReq.Header.Set(HeaderWebhookEventID, req.EventID)
+	httpReq.Header.Set(HeaderWebhookAttempt, strconv.Itoa(req.Attempt))
+	httpReq.Header.Set(HeaderWebhookTimestamp, ts)
+	httpReq.Header.Set(HeaderWebhookSignature, "sha256="+SignWebhookPayload(req.Secret, ts, req.Body))
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+// Receivers recompute it with the shared secret and compare it with the
+// X-Oms-Signature header; the timestamp guards against replays.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsRetryableWebhook reports whether a failed delivery may succeed when sent again.
+func IsRetryableWebhook(code int, err error) bool {
+	if errors.Is(err, ErrWebhookTargetNotPublic) {
+		return false
+	}
+	return IsRetryableWebhookStatus(code)
+}
+
+func IsRetryableWebhookStatus(code int) bool {
+	if code == 0 {
+		return true
+	}
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+}
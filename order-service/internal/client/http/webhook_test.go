@@ -0,0 +1,162 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWebhookClientSend(t *testing.T) {
+	const secret = "s3cret"
+	body := []byte(`{"id":"evt-1"}`)
+
+	var got *http.Request
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	code, err := NewWebhookClient(time.Second, true).Send(context.Background(), WebhookRequest{
+		URL:       srv.URL,
+		Secret:    secret,
+		EventType: "order.created",
+		EventID:   "evt-1",
+		Attempt:   2,
+		Body:      body,
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if code != http.StatusNoContent {
+		t.Fatalf("code = %d, want %d", code, http.StatusNoContent)
+	}
+
+	if got.Method != http.MethodPost {
+		t.Errorf("method = %s, want POST", got.Method)
+	}
+	if string(gotBody) != string(body) {
+		t.Errorf("body = %s, want %s", gotBody, body)
+	}
+	for header, want := range map[string]string{
+		"Content-Type":       "application/json",
+		HeaderWebhookEvent:   "order.created",
+		HeaderWebhookEventID: "evt-1",
+		HeaderWebhookAttempt: "2",
+	} {
+		if v := got.Header.Get(header); v != want {
+			t.Errorf("%s = %q, want %q", header, v, want)
+		}
+	}
+
+	ts := got.Header.Get(HeaderWebhookTimestamp)
+	if _, err := strconv.ParseInt(ts, 10, 64); err != nil {
+		t.Fatalf("%s = %q is not a unix timestamp", HeaderWebhookTimestamp, ts)
+	}
+
+	// Verify the signature the way a receiver would.
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(gotBody)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if sig := got.Header.Get(HeaderWebhookSignature); !hmac.Equal([]byte(sig), []byte(want)) {
+		t.Errorf("%s = %q, want %q", HeaderWebhookSignature, sig, want)
+	}
+}
+
+func TestWebhookClientSendNon2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	code, err := NewWebhookClient(time.Second, true).Send(context.Background(), WebhookRequest{URL: srv.URL})
+	if err == nil {
+		t.Fatal("Send: want error for 503")
+	}
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("code = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestWebhookClientSendUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	code, err := NewWebhookClient(time.Second, true).Send(context.Background(), WebhookRequest{URL: url})
+	if err == nil {
+		t.Fatal("Send: want error for a closed server")
+	}
+	if code != 0 {
+		t.Errorf("code = %d, want 0", code)
+	}
+}
+
+func TestWebhookClientSendRejectsPrivateTargets(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer srv.Close()
+
+	code, err := NewWebhookClient(time.Second, false).Send(context.Background(), WebhookRequest{URL: srv.URL})
+	if !errors.Is(err, ErrWebhookTargetNotPublic) {
+		t.Fatalf("Send: err = %v, want ErrWebhookTargetNotPublic", err)
+	}
+	if code != 0 || calls != 0 {
+		t.Errorf("code = %d, calls = %d; want no request", code, calls)
+	}
+	if IsRetryableWebhook(code, err) {
+		t.Error("a rejected target is retryable")
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	a := SignWebhookPayload("secret", "1700000000", []byte("{}"))
+	if a != SignWebhookPayload("secret", "1700000000", []byte("{}")) {
+		t.Error("signature is not deterministic")
+	}
+	for name, other := range map[string]string{
+		"secret":    SignWebhookPayload("other", "1700000000", []byte("{}")),
+		"timestamp": SignWebhookPayload("secret", "1700000001", []byte("{}")),
+		"body":      SignWebhookPayload("secret", "1700000000", []byte("[]")),
+	} {
+		if other == a {
+			t.Errorf("changing the %s doesn't change the signature", name)
+		}
+	}
+}
+
+func TestIsRetryableWebhookStatus(t *testing.T) {
+	tests := []struct {
+		code int
+		want bool
+	}{
+		{0, true},
+		{http.StatusOK, false},
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusNotFound, false},
+		{http.StatusRequestTimeout, true},
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		if got := IsRetryableWebhookStatus(tt.code); got != tt.want {
+			t.Errorf("IsRetryableWebhookStatus(%d) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	CALLBACK_EVENT_CAPTURED = "payment.captured"
+	CALLBACK_EVENT_FAILED   = "payment.failed"
+	CALLBACK_EVENT_VOIDED   = "payment.voided"
+	CALLBACK_EVENT_REFUNDED = "payment.refunded"
+)
+
+// CallbackEvent is the body providers post to the gateway callback endpoint.
+type CallbackEvent struct {
+	Event             string `json:"event"`
+	Provider          string `json:"provider"`
+	ProviderPaymentID string `json:"provider_payment_id"`
+	Reason            string `json:"reason"`
+}
+
+func SignCallbackPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyCallbackSignature(secret string, payload []byte, signature string) bool {
+	if secret == "" || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(SignCallbackPayload(secret, payload)), []byte(signature))
+}
@@ -0,0 +1,166 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const FakeProviderName = "fake"
+
+type fakePaymentState int
+
+const (
+	fakePaymentAuthorized fakePaymentState = iota
+	fakePaymentCaptured
+	fakePaymentVoided
+	fakePaymentRefunded
+)
+
+type fakePayment struct {
+	amountCents   int64
+	capturedCents int64
+	refundedCents int64
+	state         fakePaymentState
+}
+
+// FakeProvider keeps payments in memory. Amounts above declineAboveCents are declined
+// when the threshold is positive.
+type FakeProvider struct {
+	declineAboveCents int64
+	latency           time.Duration
+
+	mu             sync.Mutex
+	seq            int64
+	authorizations map[string]string
+	payments       map[string]*fakePayment
+}
+
+func NewFakeProvider(declineAboveCents int64, latency time.Duration) *FakeProvider {
+	return &FakeProvider{
+		declineAboveCents: declineAboveCents,
+		latency:           latency,
+		authorizations:    make(map[string]string),
+		payments:          make(map[string]*fakePayment),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+func (p *FakeProvider) Authorize(ctx context.Context, req AuthorizeRequest) (string, error) {
+	if err := p.wait(ctx); err != nil {
+		return "", err
+	}
+
+	if p.declineAboveCents > 0 && req.AmountCents > p.declineAboveCents {
+		return "", fmt.Errorf("%w: amount %d %s exceeds limit", ErrDeclined, req.AmountCents, req.Currency)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id, ok := p.authorizations[req.IdempotencyKey]; ok {
+		return id, nil
+	}
+	p.seq++
+	id := fmt.Sprintf("fake-pay-%d-%d", req.OrderID, p.seq)
+	p.authorizations[req.IdempotencyKey] = id
+	p.payments[id] = &fakePayment{amountCents: req.AmountCents, state: fakePaymentAuthorized}
+	return id, nil
+}
+
+func (p *FakeProvider) Capture(ctx context.Context, paymentID string, amountCents int64) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pay, err := p.get(paymentID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case pay.state == fakePaymentCaptured:
+		return nil
+	case pay.state != fakePaymentAuthorized:
+		return fmt.Errorf("%w: payment %s cannot be captured", ErrInvalidState, paymentID)
+	case amountCents > pay.amountCents:
+		return fmt.Errorf("%w: capture %d exceeds authorized %d", ErrInvalidState, amountCents, pay.amountCents)
+	}
+	pay.capturedCents = amountCents
+	pay.state = fakePaymentCaptured
+	return nil
+}
+
+func (p *FakeProvider) Void(ctx context.Context, paymentID string) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pay, err := p.get(paymentID)
+	if err != nil {
+		return err
+	}
+	switch pay.state {
+	case fakePaymentVoided:
+		return nil
+	case fakePaymentAuthorized:
+		pay.state = fakePaymentVoided
+		return nil
+	default:
+		return fmt.Errorf("%w: payment %s cannot be voided", ErrInvalidState, paymentID)
+	}
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, paymentID string, amountCents int64) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pay, err := p.get(paymentID)
+	if err != nil {
+		return err
+	}
+	if pay.state != fakePaymentCaptured && pay.state != fakePaymentRefunded {
+		return fmt.Errorf("%w: payment %s cannot be refunded", ErrInvalidState, paymentID)
+	}
+	if pay.refundedCents+amountCents > pay.capturedCents {
+		return fmt.Errorf("%w: refund %d exceeds captured %d", ErrInvalidState, amountCents, pay.capturedCents-pay.refundedCents)
+	}
+	pay.refundedCents += amountCents
+	if pay.refundedCents == pay.capturedCents {
+		pay.state = fakePaymentRefunded
+	}
+	return nil
+}
+
+func (p *FakeProvider) get(paymentID string) (*fakePayment, error) {
+	pay, ok := p.payments[paymentID]
+	if !ok {
+		return nil, fmt.Errorf("%w: payment %s not found", ErrInvalidState, paymentID)
+	}
+	return pay, nil
+}
+
+func (p *FakeProvider) wait(ctx context.Context) error {
+	if p.latency <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(p.latency):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
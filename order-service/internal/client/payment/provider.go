@@ -0,0 +1,27 @@
+package payment
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrDeclined     = errors.New("payment declined")
+	ErrInvalidState = errors.New("payment in invalid state")
+)
+
+type AuthorizeRequest struct {
+	OrderID     int64
+	AmountCents int64
+	Currency    string
+	// Repeated calls with the same key return the original authorization.
+	IdempotencyKey string
+}
+
+type PaymentProvider interface {
+	Name() string
+	Authorize(ctx context.Context, req AuthorizeRequest) (string, error)
+	Capture(ctx context.Context, paymentID string, amountCents int64) error
+	Void(ctx context.Context, paymentID string) error
+	Refund(ctx context.Context, paymentID string, amountCents int64) error
+}
@@ -0,0 +1,75 @@
+package shipping
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeProvider creates shipments in memory. Addresses containing rejectAddressSubstring
+// are rejected when it is not empty.
+type FakeProvider struct {
+	rejectAddressSubstring string
+	latency                time.Duration
+
+	mu        sync.Mutex
+	seq       int64
+	shipments map[string]string
+	cancelled map[string]struct{}
+}
+
+func NewFakeProvider(rejectAddressSubstring string, latency time.Duration) *FakeProvider {
+	return &FakeProvider{
+		rejectAddressSubstring: rejectAddressSubstring,
+		latency:                latency,
+		shipments:              make(map[string]string),
+		cancelled:              make(map[string]struct{}),
+	}
+}
+
+func (p *FakeProvider) CreateShipment(ctx context.Context, req CreateShipmentRequest) (string, error) {
+	if err := p.wait(ctx); err != nil {
+		return "", err
+	}
+
+	if p.rejectAddressSubstring != "" && strings.Contains(req.DeliveryAddress, p.rejectAddressSubstring) {
+		return "", fmt.Errorf("%w: address %q is not serviceable", ErrRejected, req.DeliveryAddress)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id, ok := p.shipments[req.IdempotencyKey]; ok {
+		return id, nil
+	}
+	p.seq++
+	id := fmt.Sprintf("fake-shipment-%d-%d", req.OrderID, p.seq)
+	p.shipments[req.IdempotencyKey] = id
+	return id, nil
+}
+
+func (p *FakeProvider) CancelShipment(ctx context.Context, shipmentID string) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cancelled[shipmentID] = struct{}{}
+	return nil
+}
+
+func (p *FakeProvider) wait(ctx context.Context) error {
+	if p.latency <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(p.latency):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,21 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrRejected = errors.New("shipment rejected")
+
+type CreateShipmentRequest struct {
+	OrderID         int64
+	DeliveryAddress string
+	ItemsCount      int
+	// Repeated calls with the same key return the original shipment.
+	IdempotencyKey string
+}
+
+type ShippingProvider interface {
+	CreateShipment(ctx context.Context, req CreateShipmentRequest) (string, error)
+	CancelShipment(ctx context.Context, shipmentID string) error
+}
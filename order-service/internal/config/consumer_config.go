@@ -2,7 +2,6 @@ package config
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
 	"github.com/spf13/viper"
@@ -12,11 +11,40 @@ type ConsumerConfig struct {
 	OrderCreatedRabbitMqConsumerSettings       settings.RabbitMqConsumerSettings `mapstructure:"OrderCreatedConsumerSettings"`
 	OrderStatusChangedRabbitMqConsumerSettings settings.RabbitMqConsumerSettings `mapstructure:"OrderStatusChangedConsumerSettings"`
 	OmsClientGrpcSettings                      settings.GrpcClientSettings       `mapstructure:"OmsGrpcClient"`
+	Tracing                                    settings.TracingSettings          `mapstructure:"TracingSettings"`
+	Metrics                                    settings.HttpServerSettings       `mapstructure:"MetricsServerSettings"`
+	Logging                                    settings.LoggingSettings          `mapstructure:"LoggingSettings"`
+	Admin                                      settings.AdminSettings            `mapstructure:"AdminSettings"`
+}
+
+var consumerSecretKeys = []string{
+	"OrderCreatedConsumerSettings.RabbitMqSettings.Password",
+	"OrderStatusChangedConsumerSettings.RabbitMqSettings.Password",
+	"AdminSettings.Token",
 }
 
 func LoadConsumerConfig() (*ConsumerConfig, error) {
+	v, err := newConsumerViper()
+	if err != nil {
+		return nil, err
+	}
+	return decodeConsumerConfig(v)
+}
+
+// WatchConsumerConfig calls onChange with the reloaded config every time the config
+// file changes. A config that fails to load or validate goes to onError instead.
+func WatchConsumerConfig(onChange func(*ConsumerConfig), onError func(error)) error {
+	v, err := newConsumerViper()
+	if err != nil {
+		return err
+	}
+	watchConfig(v, decodeConsumerConfig, onChange, onError)
+	return nil
+}
+
+func newConsumerViper() (*viper.Viper, error) {
 	v := viper.New()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.SetEnvKeyReplacer(envKeyReplacer)
 	v.AutomaticEnv()
 
 	env := v.GetString("APP_ENVIRONMENT")
@@ -35,10 +63,21 @@ func LoadConsumerConfig() (*ConsumerConfig, error) {
 		return nil, fmt.Errorf("read config: %w", err)
 	}
 
+	return v, nil
+}
+
+func decodeConsumerConfig(v *viper.Viper) (*ConsumerConfig, error) {
+	if err := resolveSecretFiles(v, consumerSecretKeys); err != nil {
+		return nil, err
+	}
+
 	var cfg ConsumerConfig
-	if err := v.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg, strictDecoding); err != nil {
 		return nil, fmt.Errorf("unmarshal config: %w", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
@@ -49,10 +88,35 @@ func setDefaultConsumerConfigValues(v *viper.Viper) {
 	v.SetDefault("OrderCreatedConsumerSettings.RabbitMqSettings.ReconnectTimeoutSeconds", 5)
 	v.SetDefault("OrderCreatedConsumerSettings.BatchSize", 100)
 	v.SetDefault("OrderCreatedConsumerSettings.BatchTimeoutSeconds", 1)
+	v.SetDefault("OrderCreatedConsumerSettings.ProcessTimeoutSeconds", 10)
+	setDefaultConsumerValues(v, "OrderCreatedConsumerSettings")
 	
 	v.SetDefault("OrderStatusChangedConsumerSettings.RabbitMqSettings.HeartbeatSeconds", 30)
 	v.SetDefault("OrderStatusChangedConsumerSettings.RabbitMqSettings.MaxReconnectAttempts", 3)
 	v.SetDefault("OrderStatusChangedConsumerSettings.RabbitMqSettings.ReconnectTimeoutSeconds", 5)
 	v.SetDefault("OrderStatusChangedConsumerSettings.BatchSize", 100)
 	v.SetDefault("OrderStatusChangedConsumerSettings.BatchTimeoutSeconds", 1)
+	v.SetDefault("OrderStatusChangedConsumerSettings.ProcessTimeoutSeconds", 10)
+	setDefaultConsumerValues(v, "OrderStatusChangedConsumerSettings")
+	v.SetDefault("OrderStatusChangedConsumerSettings.RetrySettings.Enabled", true)
+
+	v.SetDefault("TracingSettings.Enabled", false)
+	v.SetDefault("TracingSettings.Exporter", "otlp")
+	v.SetDefault("TracingSettings.SampleRatio", 1.0)
+
+	v.SetDefault("OmsGrpcClient.LoadBalancingPolicy", "round_robin")
+	v.SetDefault("OmsGrpcClient.TimeoutMs", 5000)
+	v.SetDefault("OmsGrpcClient.Retry.Enabled", true)
+	v.SetDefault("OmsGrpcClient.Retry.MaxAttempts", 4)
+	v.SetDefault("OmsGrpcClient.Retry.InitialBackoffMs", 100)
+	v.SetDefault("OmsGrpcClient.Retry.MaxBackoffMs", 2000)
+	v.SetDefault("OmsGrpcClient.Retry.BackoffMultiplier", 2.0)
+	v.SetDefault("OmsGrpcClient.Retry.RetryableCodes", []string{"UNAVAILABLE"})
+	v.SetDefault("OmsGrpcClient.CircuitBreaker.Enabled", true)
+	v.SetDefault("OmsGrpcClient.CircuitBreaker.ConsecutiveFailures", 5)
+	v.SetDefault("OmsGrpcClient.CircuitBreaker.OpenTimeoutSeconds", 10)
+	v.SetDefault("OmsGrpcClient.CircuitBreaker.HalfOpenMaxRequests", 1)
+
+	v.SetDefault("MetricsServerSettings.Port", 9090)
+	setDefaultLoggingValues(v)
 }
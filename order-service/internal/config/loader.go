@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+)
+
+var envKeyReplacer = strings.NewReplacer(".", "_")
+
+// strictDecoding makes unknown keys (typos in section or field names) an error
+// instead of silently leaving the intended field at its zero value.
+func strictDecoding(dc *mapstructure.DecoderConfig) {
+	dc.ErrorUnused = true
+}
+
+// resolveSecretFiles implements the *_FILE convention: when e.g.
+// DBSETTINGS_CONNECTIONSTRING_FILE is set, the value of DbSettings.ConnectionString is
+// read from that file, so secrets can be mounted instead of kept in yaml or env.
+func resolveSecretFiles(v *viper.Viper, keys []string) error {
+	for _, key := range keys {
+		env := strings.ToUpper(envKeyReplacer.Replace(key)) + "_FILE"
+		path := os.Getenv(env)
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", env, err)
+		}
+		v.Set(key, strings.TrimRight(string(data), "\r\n"))
+	}
+	return nil
+}
+
+func watchConfig[T any](v *viper.Viper, decode func(*viper.Viper) (*T, error), onChange func(*T), onError func(error)) {
+	v.OnConfigChange(func(fsnotify.Event) {
+		cfg, err := decode(v)
+		if err != nil {
+			onError(err)
+			return
+		}
+		onChange(cfg)
+	})
+	v.WatchConfig()
+}
+
+// Every app logs the same way, so the logging defaults are shared.
+func setDefaultLoggingValues(v *viper.Viper) {
+	v.SetDefault("LoggingSettings.Level", "info")
+	v.SetDefault("LoggingSettings.Format", "json")
+	v.SetDefault("LoggingSettings.Sampling.Enabled", true)
+	v.SetDefault("LoggingSettings.Sampling.Initial", 100)
+	v.SetDefault("LoggingSettings.Sampling.Thereafter", 100)
+	v.SetDefault("LoggingSettings.Output.Console", true)
+	v.SetDefault("LoggingSettings.Output.File.MaxSizeMb", 100)
+	v.SetDefault("LoggingSettings.Output.File.MaxBackups", 5)
+	v.SetDefault("LoggingSettings.Output.File.MaxAgeDays", 7)
+	v.SetDefault("LoggingSettings.Redaction.Enabled", true)
+	v.SetDefault("LoggingSettings.Redaction.Fields", []string{"delivery_address", "product_url"})
+}
+
+// Defaults shared by every RabbitMQ consumer. Retries stay opt-in per consumer.
+func setDefaultConsumerValues(v *viper.Viper, prefix string) {
+	v.SetDefault(prefix+".Workers", 1)
+	v.SetDefault(prefix+".DrainTimeoutSeconds", 20)
+	v.SetDefault(prefix+".RetrySettings.Enabled", false)
+	v.SetDefault(prefix+".RetrySettings.DelaysSeconds", []int{5, 30, 120})
+	v.SetDefault(prefix+".RetrySettings.MaxAttempts", 5)
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+const webhookDispatcherYaml = `
+DbSettings:
+  ConnectionString: "host=db user=app"
+WebhookConsumerSettings:
+  RabbitMqSettings:
+    Host: rabbitmq
+    Port: 5672
+    User: app
+    Password: inline-password
+  Queue: webhooks
+  DeadLetterSettings:
+    Dlx: webhooks.dlx
+    Dlq: webhooks.dlq
+`
+
+func newTestWebhookDispatcherViper(t *testing.T, yaml string) *viper.Viper {
+	t.Helper()
+	v := viper.New()
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	v.AutomaticEnv()
+	setDefaultWebhookDispatcherConfigValues(v)
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(yaml)); err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	return v
+}
+
+func writeSecret(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+	return path
+}
+
+func TestDecodeWebhookDispatcherConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         string
+		wantErr      []string
+		wantProblems int
+	}{
+		{"valid", webhookDispatcherYaml, nil, 0},
+		{"unknown section", webhookDispatcherYaml + "WebhookDispatcherSetings:\n  MaxAttempts: 3\n", []string{"invalid keys: webhookdispatchersetings"}, 0},
+		{"unknown field", strings.Replace(webhookDispatcherYaml, "Queue: webhooks", "Queue: webhooks\n  Queu: typo", 1), []string{"'WebhookConsumerSettings' has invalid keys: queu"}, 0},
+		{"missing required fields", strings.Replace(
+			strings.Replace(webhookDispatcherYaml, "Queue: webhooks", "", 1),
+			`ConnectionString: "host=db user=app"`, `ConnectionString: ""`, 1,
+		), []string{"DbSettings.ConnectionString: is required", "WebhookConsumerSettings.Queue: is required"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := decodeWebhookDispatcherConfig(newTestWebhookDispatcherViper(t, tt.yaml))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("decode = %v", err)
+				}
+				if cfg.WebhookRabbitMqConsumerSettings.Workers != 1 || cfg.Dispatcher.MaxAttempts != 4 {
+					t.Errorf("defaults not applied: %+v", cfg)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("decode succeeded, want error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("err = %v, want it to mention %q", err, want)
+				}
+			}
+			var verr *ValidationError
+			if errors.As(err, &verr) != (tt.wantProblems > 0) || (verr != nil && len(verr.Problems) != tt.wantProblems) {
+				t.Errorf("err = %#v, want a ValidationError with %d problems", err, tt.wantProblems)
+			}
+		})
+	}
+}
+
+func TestResolveSecretFiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		wantPassword string
+	}{
+		{"inline value without a file", "", "inline-password"},
+		{"file wins over the inline value", "file-password", "file-password"},
+		{"trailing newline is trimmed", "file-password\n", "file-password"},
+		{"windows line ending is trimmed", "file-password\r\n", "file-password"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.file != "" {
+				t.Setenv("WEBHOOKCONSUMERSETTINGS_RABBITMQSETTINGS_PASSWORD_FILE", writeSecret(t, tt.file))
+			}
+			cfg, err := decodeWebhookDispatcherConfig(newTestWebhookDispatcherViper(t, webhookDispatcherYaml))
+			if err != nil {
+				t.Fatalf("decode = %v", err)
+			}
+			if got := cfg.WebhookRabbitMqConsumerSettings.RabbitMqSettings.Password; got != tt.wantPassword {
+				t.Errorf("password = %q, want %q", got, tt.wantPassword)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("DBSETTINGS_CONNECTIONSTRING_FILE", filepath.Join(t.TempDir(), "missing"))
+		_, err := decodeWebhookDispatcherConfig(newTestWebhookDispatcherViper(t, webhookDispatcherYaml))
+		if err == nil || !strings.Contains(err.Error(), "DBSETTINGS_CONNECTIONSTRING_FILE") {
+			t.Errorf("err = %v, want it to name the env variable", err)
+		}
+	})
+}
+
+func TestValidationErrorListsEveryProblem(t *testing.T) {
+	v := &validator{}
+	v.required("A", " ")
+	v.positive("B", 0)
+	v.port("C", 70000)
+	v.oneOf("D", "x", "a", "b")
+	v.nonNegative("E", 0)
+
+	var verr *ValidationError
+	if err := v.err(); !errors.As(err, &verr) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	want := []string{
+		"A: is required",
+		"B: must be greater than 0, got 0",
+		"C: must be a port between 1 and 65535, got 70000",
+		`D: must be one of a, b, got "x"`,
+	}
+	if !slices.Equal(verr.Problems, want) {
+		t.Errorf("problems = %q, want %q", verr.Problems, want)
+	}
+	if got := verr.Error(); got != "invalid config:\n  - "+strings.Join(want, "\n  - ") {
+		t.Errorf("Error() = %q", got)
+	}
+
+	if err := (&validator{}).err(); err != nil {
+		t.Errorf("no problems: err = %v", err)
+	}
+}
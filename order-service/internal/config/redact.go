@@ -0,0 +1,68 @@
+package config
+
+import (
+	"reflect"
+)
+
+const redactedValue = "******"
+
+// Fields with these names hold credentials, or connection strings that embed them.
+var secretFieldNames = map[string]struct{}{
+	"ConnectionString":          {},
+	"MigrationConnectionString": {},
+	"Password":                  {},
+	"CallbackSecret":            {},
+	"Token":                     {},
+	"ApiKeys":                   {},
+}
+
+// Redacted converts a loaded config into a map keyed like the yaml it came from, with
+// secret values masked, for printing the effective configuration.
+func Redacted(cfg any) map[string]any {
+	m, _ := redactValue(reflect.ValueOf(cfg)).(map[string]any)
+	return m
+}
+
+func redactValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem())
+	case reflect.Struct:
+		out := make(map[string]any, v.NumField())
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := f.Tag.Get("mapstructure")
+			if name == "" {
+				name = f.Name
+			}
+			if _, secret := secretFieldNames[f.Name]; secret && !v.Field(i).IsZero() {
+				out[name] = redactedValue
+				continue
+			}
+			out[name] = redactValue(v.Field(i))
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = redactValue(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[iter.Key().String()] = redactValue(iter.Value())
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
@@ -2,22 +2,63 @@ package config
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
 	"github.com/spf13/viper"
 )
 
 type ServerConfig struct {
-	DbSettings                   settings.DbSettings                `mapstructure:"DbSettings"`
-	OmsRabbitMqPublisherSettings settings.RabbitMqPublisherSettings `mapstructure:"OmsPublisherSettings"`
-	Http                         settings.HttpServerSettings        `mapstructure:"HttpServerSettings"`
-	Grpc                         settings.GrpcServerSettings        `mapstructure:"GrpcServerSettings"`
+	DbSettings                   settings.DbSettings                  `mapstructure:"DbSettings"`
+	OmsRabbitMqPublisherSettings settings.RabbitMqPublisherSettings   `mapstructure:"OmsPublisherSettings"`
+	Http                         settings.HttpServerSettings          `mapstructure:"HttpServerSettings"`
+	Grpc                         settings.GrpcServerSettings          `mapstructure:"GrpcServerSettings"`
+	Inventory                    settings.InventorySettings           `mapstructure:"InventorySettings"`
+	ProductCatalog               settings.ProductCatalogSettings      `mapstructure:"ProductCatalogSettings"`
+	Saga                         settings.SagaSettings                `mapstructure:"SagaSettings"`
+	SagaRabbitMqConsumerSettings settings.RabbitMqConsumerSettings    `mapstructure:"SagaConsumerSettings"`
+	PaymentProvider              settings.PaymentProviderSettings     `mapstructure:"PaymentProviderSettings"`
+	ShippingProvider             settings.ShippingProviderSettings    `mapstructure:"ShippingProviderSettings"`
+	StaleOrderScheduler          settings.StaleOrderSchedulerSettings `mapstructure:"StaleOrderSchedulerSettings"`
+	Tenancy                      settings.TenantSettings              `mapstructure:"TenantSettings"`
+	Auth                         settings.AuthSettings                `mapstructure:"AuthSettings"`
+	RateLimit                    settings.RateLimitSettings           `mapstructure:"RateLimitSettings"`
+	Tracing                      settings.TracingSettings             `mapstructure:"TracingSettings"`
+	Logging                      settings.LoggingSettings             `mapstructure:"LoggingSettings"`
+	Admin                        settings.AdminSettings               `mapstructure:"AdminSettings"`
+	Dlq                          settings.DlqSettings                 `mapstructure:"DlqSettings"`
+}
+
+var serverSecretKeys = []string{
+	"DbSettings.ConnectionString",
+	"DbSettings.MigrationConnectionString",
+	"OmsPublisherSettings.RabbitMqSettings.Password",
+	"SagaConsumerSettings.RabbitMqSettings.Password",
+	"PaymentProviderSettings.CallbackSecret",
+	"AdminSettings.Token",
 }
 
 func LoadServerConfig() (*ServerConfig, error) {
+	v, err := newServerViper()
+	if err != nil {
+		return nil, err
+	}
+	return decodeServerConfig(v)
+}
+
+// WatchServerConfig calls onChange with the reloaded config every time the config
+// file changes. A config that fails to load or validate goes to onError instead.
+func WatchServerConfig(onChange func(*ServerConfig), onError func(error)) error {
+	v, err := newServerViper()
+	if err != nil {
+		return err
+	}
+	watchConfig(v, decodeServerConfig, onChange, onError)
+	return nil
+}
+
+func newServerViper() (*viper.Viper, error) {
 	v := viper.New()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.SetEnvKeyReplacer(envKeyReplacer)
 	v.AutomaticEnv()
 
 	env := v.GetString("APP_ENVIRONMENT")
@@ -36,18 +77,68 @@ func LoadServerConfig() (*ServerConfig, error) {
 		return nil, fmt.Errorf("read config: %w", err)
 	}
 
+	return v, nil
+}
+
+func decodeServerConfig(v *viper.Viper) (*ServerConfig, error) {
+	if err := resolveSecretFiles(v, serverSecretKeys); err != nil {
+		return nil, err
+	}
+
 	var cfg ServerConfig
-	if err := v.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg, strictDecoding); err != nil {
 		return nil, fmt.Errorf("unmarshal config: %w", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
 
 func setDefaultServerConfigValues(v *viper.Viper) {
-	v.SetDefault("OmsRabbitMqPublisherSettings.RabbitMqSettings.HeartbeatSeconds", 30)
-	v.SetDefault("OmsRabbitMqPublisherSettings.RabbitMqSettings.MaxReconnectAttempts", 3)
-	v.SetDefault("OmsRabbitMqPublisherSettings.RabbitMqSettings.ReconnectTimeoutSeconds", 5)
+	v.SetDefault("OmsPublisherSettings.RabbitMqSettings.HeartbeatSeconds", 30)
+	v.SetDefault("OmsPublisherSettings.RabbitMqSettings.MaxReconnectAttempts", 3)
+	v.SetDefault("OmsPublisherSettings.RabbitMqSettings.ReconnectTimeoutSeconds", 5)
 	v.SetDefault("HttpServerSettings.Port", 5000)
 	v.SetDefault("GrpcServerSettings.Port", 50051)
+	v.SetDefault("InventorySettings.ReservationEnabled", false)
+	v.SetDefault("InventorySettings.DefaultWarehouseId", 1)
+	v.SetDefault("ProductCatalogSettings.ValidationMode", "off")
+	v.SetDefault("ProductCatalogSettings.CacheTtlSeconds", 60)
+	v.SetDefault("SagaSettings.Enabled", false)
+	v.SetDefault("SagaSettings.Steps", []string{"reserve_stock", "authorize_payment", "create_shipment"})
+	v.SetDefault("SagaSettings.StepTimeoutSeconds", 10)
+	v.SetDefault("SagaSettings.MaxStepAttempts", 3)
+	v.SetDefault("SagaSettings.StepRetryBackoffMs", 500)
+	v.SetDefault("SagaConsumerSettings.RabbitMqSettings.HeartbeatSeconds", 30)
+	v.SetDefault("SagaConsumerSettings.RabbitMqSettings.MaxReconnectAttempts", 3)
+	v.SetDefault("SagaConsumerSettings.RabbitMqSettings.ReconnectTimeoutSeconds", 5)
+	v.SetDefault("SagaConsumerSettings.BatchSize", 20)
+	v.SetDefault("SagaConsumerSettings.BatchTimeoutSeconds", 1)
+	v.SetDefault("SagaConsumerSettings.ProcessTimeoutSeconds", 120)
+	setDefaultConsumerValues(v, "SagaConsumerSettings")
+	v.SetDefault("PaymentProviderSettings.Provider", "fake")
+	v.SetDefault("PaymentProviderSettings.AutoCapture", true)
+	v.SetDefault("ShippingProviderSettings.Provider", "fake")
+	v.SetDefault("StaleOrderSchedulerSettings.Enabled", false)
+	v.SetDefault("StaleOrderSchedulerSettings.IntervalSeconds", 60)
+	v.SetDefault("StaleOrderSchedulerSettings.BatchSize", 100)
+	v.SetDefault("StaleOrderSchedulerSettings.LockKey", 7310001)
+	v.SetDefault("StaleOrderSchedulerSettings.StatusTtlSeconds", map[string]int{"created": 86400})
+	v.SetDefault("TenantSettings.Required", false)
+	v.SetDefault("TenantSettings.DefaultTenantId", "default")
+	v.SetDefault("TenantSettings.ServiceRoles", []string{"consumer", "gateway"})
+	v.SetDefault("AuthSettings.Enabled", false)
+	v.SetDefault("AuthSettings.ClockSkewSeconds", 30)
+	v.SetDefault("RateLimitSettings.Enabled", false)
+	v.SetDefault("RateLimitSettings.IdleTtlSeconds", 600)
+	v.SetDefault("TracingSettings.Enabled", false)
+	v.SetDefault("TracingSettings.Exporter", "otlp")
+	v.SetDefault("TracingSettings.SampleRatio", 1.0)
+	v.SetDefault("DlqSettings.ReplayRatePerSecond", 50)
+	v.SetDefault("DlqSettings.MaxScanMessages", 10000)
+	v.SetDefault("DlqSettings.ReplayCallTimeoutSeconds", 20)
+
+	setDefaultLoggingValues(v)
 }
@@ -0,0 +1,6 @@
+package settings
+
+type AdminSettings struct {
+	// Token guards the admin endpoints; they are disabled when it is empty.
+	Token string `mapstructure:"Token"`
+}
@@ -0,0 +1,11 @@
+package settings
+
+type AuthSettings struct {
+	Enabled          bool   `mapstructure:"Enabled"`
+	JwksFile         string `mapstructure:"JwksFile"`
+	Issuer           string `mapstructure:"Issuer"`
+	Audience         string `mapstructure:"Audience"`
+	ClockSkewSeconds int    `mapstructure:"ClockSkewSeconds"`
+	// PolicyFile maps roles to the RPCs they may call. It is required when auth is enabled.
+	PolicyFile string `mapstructure:"PolicyFile"`
+}
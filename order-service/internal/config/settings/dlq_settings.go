@@ -0,0 +1,16 @@
+package settings
+
+// DlqSettings lists the dead-letter queues the DLQ tools may touch, together with the
+// queue their messages are replayed to. ReplayCallTimeoutSeconds bounds a single replay
+// RPC; a longer replay is continued by calling again.
+type DlqSettings struct {
+	ReplayRatePerSecond      float64            `mapstructure:"ReplayRatePerSecond"`
+	MaxScanMessages          int                `mapstructure:"MaxScanMessages"`
+	ReplayCallTimeoutSeconds int                `mapstructure:"ReplayCallTimeoutSeconds"`
+	Queues                   []DlqQueueSettings `mapstructure:"Queues"`
+}
+
+type DlqQueueSettings struct {
+	Dlq   string `mapstructure:"Dlq"`
+	Queue string `mapstructure:"Queue"`
+}
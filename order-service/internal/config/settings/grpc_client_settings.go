@@ -1,5 +1,46 @@
 package settings
 
 type GrpcClientSettings struct {
+	// Address is a host:port or a resolver target such as dns:///order-service:50051.
 	Address string `mapstructure:"Address"`
+	// ServiceTokenFile holds a JWT issued for this service; calls are anonymous when empty.
+	ServiceTokenFile string            `mapstructure:"ServiceTokenFile"`
+	Tls              TlsClientSettings `mapstructure:"Tls"`
+	// LoadBalancingPolicy is round_robin or pick_first. round_robin spreads calls over every
+	// address the name resolves to, e.g. all replicas behind a headless service.
+	LoadBalancingPolicy string `mapstructure:"LoadBalancingPolicy"`
+	// TimeoutMs bounds a call including its retries; MethodTimeouts override it per method.
+	TimeoutMs      int                    `mapstructure:"TimeoutMs"`
+	MethodTimeouts []GrpcMethodTimeout    `mapstructure:"MethodTimeouts"`
+	Retry          GrpcRetrySettings      `mapstructure:"Retry"`
+	CircuitBreaker CircuitBreakerSettings `mapstructure:"CircuitBreaker"`
+}
+
+type GrpcMethodTimeout struct {
+	// Method is the full method name, e.g. /order_service.v1.OrderService/QueryOrders.
+	Method    string `mapstructure:"Method"`
+	TimeoutMs int    `mapstructure:"TimeoutMs"`
+}
+
+// GrpcRetrySettings becomes the retry policy of the client's service config. Backoff is
+// randomized by gRPC, so replicas don't retry in lockstep.
+type GrpcRetrySettings struct {
+	Enabled bool `mapstructure:"Enabled"`
+	// MaxAttempts includes the first call; gRPC caps it at 5.
+	MaxAttempts       int     `mapstructure:"MaxAttempts"`
+	InitialBackoffMs  int     `mapstructure:"InitialBackoffMs"`
+	MaxBackoffMs      int     `mapstructure:"MaxBackoffMs"`
+	BackoffMultiplier float64 `mapstructure:"BackoffMultiplier"`
+	// RetryableCodes are gRPC code names such as UNAVAILABLE.
+	RetryableCodes []string `mapstructure:"RetryableCodes"`
+}
+
+// CircuitBreakerSettings opens the breaker after ConsecutiveFailures calls in a row failed
+// with Unavailable or DeadlineExceeded. While open, calls fail fast for OpenTimeoutSeconds,
+// then HalfOpenMaxRequests trial calls decide whether it closes again.
+type CircuitBreakerSettings struct {
+	Enabled             bool `mapstructure:"Enabled"`
+	ConsecutiveFailures int  `mapstructure:"ConsecutiveFailures"`
+	OpenTimeoutSeconds  int  `mapstructure:"OpenTimeoutSeconds"`
+	HalfOpenMaxRequests int  `mapstructure:"HalfOpenMaxRequests"`
 }
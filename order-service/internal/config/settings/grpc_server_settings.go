@@ -1,5 +1,8 @@
 package settings
 
 type GrpcServerSettings struct {
-	Port int `mapstructure:"Port"`
+	Port int               `mapstructure:"Port"`
+	Tls  TlsServerSettings `mapstructure:"Tls"`
+	// GatewayTls is what the HTTP gateway uses to dial this server over loopback.
+	GatewayTls TlsClientSettings `mapstructure:"GatewayTls"`
 }
@@ -1,5 +1,6 @@
 package settings
 
 type HttpServerSettings struct {
-	Port int `mapstructure:"Port"`
+	Port int               `mapstructure:"Port"`
+	Tls  TlsServerSettings `mapstructure:"Tls"`
 }
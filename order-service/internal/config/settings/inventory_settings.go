@@ -0,0 +1,6 @@
+package settings
+
+type InventorySettings struct {
+	ReservationEnabled bool  `mapstructure:"ReservationEnabled"`
+	DefaultWarehouseId int64 `mapstructure:"DefaultWarehouseId"`
+}
@@ -0,0 +1,42 @@
+package settings
+
+type LoggingSettings struct {
+	// Level is one of debug, info, warn, error.
+	Level string `mapstructure:"Level"`
+	// Format is json or console.
+	Format string `mapstructure:"Format"`
+	// Components overrides Level for named loggers, e.g. consumer: debug.
+	Components map[string]string    `mapstructure:"Components"`
+	Sampling   LogSamplingSettings  `mapstructure:"Sampling"`
+	Output     LogOutputSettings    `mapstructure:"Output"`
+	Redaction  LogRedactionSettings `mapstructure:"Redaction"`
+}
+
+// LogSamplingSettings keeps the first Initial entries with the same message and level
+// each second, then every Thereafter-th one.
+type LogSamplingSettings struct {
+	Enabled    bool `mapstructure:"Enabled"`
+	Initial    int  `mapstructure:"Initial"`
+	Thereafter int  `mapstructure:"Thereafter"`
+}
+
+type LogOutputSettings struct {
+	Console bool            `mapstructure:"Console"`
+	File    LogFileSettings `mapstructure:"File"`
+}
+
+// LogFileSettings enables a rotated log file when Path is set.
+type LogFileSettings struct {
+	Path       string `mapstructure:"Path"`
+	MaxSizeMb  int    `mapstructure:"MaxSizeMb"`
+	MaxBackups int    `mapstructure:"MaxBackups"`
+	MaxAgeDays int    `mapstructure:"MaxAgeDays"`
+	Compress   bool   `mapstructure:"Compress"`
+}
+
+// LogRedactionSettings masks log fields with these names, at any depth. Names match
+// regardless of case and underscores, so delivery_address also covers DeliveryAddress.
+type LogRedactionSettings struct {
+	Enabled bool     `mapstructure:"Enabled"`
+	Fields  []string `mapstructure:"Fields"`
+}
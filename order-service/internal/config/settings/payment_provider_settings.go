@@ -0,0 +1,9 @@
+package settings
+
+type PaymentProviderSettings struct {
+	Provider              string `mapstructure:"Provider"`
+	FakeDeclineAboveCents int64  `mapstructure:"FakeDeclineAboveCents"`
+	FakeLatencyMs         int    `mapstructure:"FakeLatencyMs"`
+	AutoCapture           bool   `mapstructure:"AutoCapture"`
+	CallbackSecret        string `mapstructure:"CallbackSecret"`
+}
@@ -0,0 +1,6 @@
+package settings
+
+type ProductCatalogSettings struct {
+	ValidationMode  string `mapstructure:"ValidationMode"`
+	CacheTtlSeconds int    `mapstructure:"CacheTtlSeconds"`
+}
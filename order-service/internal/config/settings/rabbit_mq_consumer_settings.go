@@ -1,10 +1,14 @@
 package settings
 
 type RabbitMqConsumerSettings struct {
-	RabbitMqSettings    RabbitMqSettings           `mapstructure:"RabbitMqSettings"`
-	Consumer            string                     `mapstructure:"Consumer"`
-	Queue               string                     `mapstructure:"Queue"`
-	BatchSize           int                        `mapstructure:"BatchSize"`
-	BatchTimeoutSeconds int                        `mapstructure:"BatchTimeoutSeconds"`
-	DeadLetterSettings  RabbitMqDeadLetterSettings `mapstructure:"DeadLetterSettings"`
+	RabbitMqSettings      RabbitMqSettings           `mapstructure:"RabbitMqSettings"`
+	Consumer              string                     `mapstructure:"Consumer"`
+	Queue                 string                     `mapstructure:"Queue"`
+	BatchSize             int                        `mapstructure:"BatchSize"`
+	BatchTimeoutSeconds   int                        `mapstructure:"BatchTimeoutSeconds"`
+	ProcessTimeoutSeconds int                        `mapstructure:"ProcessTimeoutSeconds"`
+	Workers               int                        `mapstructure:"Workers"`
+	DrainTimeoutSeconds   int                        `mapstructure:"DrainTimeoutSeconds"`
+	DeadLetterSettings    RabbitMqDeadLetterSettings `mapstructure:"DeadLetterSettings"`
+	RetrySettings         RabbitMqRetrySettings      `mapstructure:"RetrySettings"`
 }
@@ -0,0 +1,11 @@
+package settings
+
+// RabbitMqRetrySettings describes the delayed retry topology of a consumer. Every delay
+// gets its own TTL queue that dead-letters back into the consumer queue; attempt n waits
+// DelaysSeconds[n-1], and later attempts reuse the last delay.
+type RabbitMqRetrySettings struct {
+	Enabled       bool   `mapstructure:"Enabled"`
+	Exchange      string `mapstructure:"Exchange"`
+	DelaysSeconds []int  `mapstructure:"DelaysSeconds"`
+	MaxAttempts   int    `mapstructure:"MaxAttempts"`
+}
@@ -0,0 +1,33 @@
+package settings
+
+// A rule with RequestsPerSecond <= 0 does not limit anything.
+type RateLimitRule struct {
+	RequestsPerSecond float64 `mapstructure:"RequestsPerSecond"`
+	Burst             int     `mapstructure:"Burst"`
+}
+
+type MethodRateLimit struct {
+	Method string        `mapstructure:"Method"`
+	Limit  RateLimitRule `mapstructure:"Limit"`
+}
+
+// ClientQuota replaces the method and order limits for a single client key,
+// e.g. "client:order-generator" or "customer:42".
+type ClientQuota struct {
+	Client   string        `mapstructure:"Client"`
+	Requests RateLimitRule `mapstructure:"Requests"`
+	Orders   RateLimitRule `mapstructure:"Orders"`
+}
+
+type RateLimitSettings struct {
+	Enabled bool              `mapstructure:"Enabled"`
+	Default RateLimitRule     `mapstructure:"Default"`
+	Methods []MethodRateLimit `mapstructure:"Methods"`
+	// Orders limits the number of orders a client may create per second across batches.
+	Orders         RateLimitRule `mapstructure:"Orders"`
+	Clients        []ClientQuota `mapstructure:"Clients"`
+	IdleTtlSeconds int           `mapstructure:"IdleTtlSeconds"`
+	// ApiKeys are the x-api-key values unauthenticated callers may be keyed by. Any other
+	// key is ignored, since callers could otherwise rotate it to get a fresh bucket.
+	ApiKeys []string `mapstructure:"ApiKeys"`
+}
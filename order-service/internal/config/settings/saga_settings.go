@@ -0,0 +1,9 @@
+package settings
+
+type SagaSettings struct {
+	Enabled            bool     `mapstructure:"Enabled"`
+	Steps              []string `mapstructure:"Steps"`
+	StepTimeoutSeconds int      `mapstructure:"StepTimeoutSeconds"`
+	MaxStepAttempts    int      `mapstructure:"MaxStepAttempts"`
+	StepRetryBackoffMs int      `mapstructure:"StepRetryBackoffMs"`
+}
@@ -0,0 +1,7 @@
+package settings
+
+type ShippingProviderSettings struct {
+	Provider                   string `mapstructure:"Provider"`
+	FakeRejectAddressSubstring string `mapstructure:"FakeRejectAddressSubstring"`
+	FakeLatencyMs              int    `mapstructure:"FakeLatencyMs"`
+}
@@ -0,0 +1,10 @@
+package settings
+
+type StaleOrderSchedulerSettings struct {
+	Enabled         bool  `mapstructure:"Enabled"`
+	IntervalSeconds int   `mapstructure:"IntervalSeconds"`
+	BatchSize       int   `mapstructure:"BatchSize"`
+	LockKey         int64 `mapstructure:"LockKey"`
+	// StatusTtlSeconds maps an order status to how long an order may stay in it before being cancelled.
+	StatusTtlSeconds map[string]int `mapstructure:"StatusTtlSeconds"`
+}
@@ -0,0 +1,9 @@
+package settings
+
+type TenantSettings struct {
+	// Required rejects requests without tenant metadata instead of assigning DefaultTenantId.
+	Required        bool   `mapstructure:"Required"`
+	DefaultTenantId string `mapstructure:"DefaultTenantId"`
+	// ServiceRoles may act for the tenant in metadata when their token carries no tenant_id.
+	ServiceRoles []string `mapstructure:"ServiceRoles"`
+}
@@ -0,0 +1,21 @@
+package settings
+
+type TlsServerSettings struct {
+	Enabled  bool   `mapstructure:"Enabled"`
+	CertFile string `mapstructure:"CertFile"`
+	KeyFile  string `mapstructure:"KeyFile"`
+	// ClientCaFile enables mTLS: client certificates signed by this CA are verified.
+	ClientCaFile string `mapstructure:"ClientCaFile"`
+	// RequireClientCert rejects callers without a certificate instead of only verifying
+	// the ones that present one.
+	RequireClientCert bool `mapstructure:"RequireClientCert"`
+}
+
+type TlsClientSettings struct {
+	Enabled bool   `mapstructure:"Enabled"`
+	CaFile  string `mapstructure:"CaFile"`
+	// CertFile and KeyFile are the client certificate presented for mTLS.
+	CertFile   string `mapstructure:"CertFile"`
+	KeyFile    string `mapstructure:"KeyFile"`
+	ServerName string `mapstructure:"ServerName"`
+}
@@ -0,0 +1,12 @@
+package settings
+
+type TracingSettings struct {
+	Enabled     bool   `mapstructure:"Enabled"`
+	ServiceName string `mapstructure:"ServiceName"`
+	// Exporter is "otlp" or "file".
+	Exporter     string  `mapstructure:"Exporter"`
+	OtlpEndpoint string  `mapstructure:"OtlpEndpoint"`
+	OtlpInsecure bool    `mapstructure:"OtlpInsecure"`
+	FilePath     string  `mapstructure:"FilePath"`
+	SampleRatio  float64 `mapstructure:"SampleRatio"`
+}
@@ -0,0 +1,13 @@
+package settings
+
+type WebhookDispatcherSettings struct {
+	RequestTimeoutSeconds   int `mapstructure:"RequestTimeoutSeconds"`
+	MaxAttempts             int `mapstructure:"MaxAttempts"`
+	InitialBackoffMs        int `mapstructure:"InitialBackoffMs"`
+	MaxBackoffMs            int `mapstructure:"MaxBackoffMs"`
+	DisableAfterFailures    int `mapstructure:"DisableAfterFailures"`
+	MaxConcurrentDeliveries int `mapstructure:"MaxConcurrentDeliveries"`
+	// AllowPrivateTargets lets deliveries reach loopback, link-local and private addresses.
+	// Only meant for local setups where receivers run next to the dispatcher.
+	AllowPrivateTargets bool `mapstructure:"AllowPrivateTargets"`
+}
@@ -0,0 +1,309 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"google.golang.org/grpc/codes"
+)
+
+// ValidationError lists every problem found, so a broken config is fixed in one pass
+// instead of one restart per field.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, field, format string, args ...any) {
+	if !ok {
+		v.problems = append(v.problems, field+": "+fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *validator) required(field, value string) {
+	v.check(strings.TrimSpace(value) != "", field, "is required")
+}
+
+func (v *validator) positive(field string, value int) {
+	v.check(value > 0, field, "must be greater than 0, got %d", value)
+}
+
+func (v *validator) nonNegative(field string, value int) {
+	v.check(value >= 0, field, "must not be negative, got %d", value)
+}
+
+func (v *validator) port(field string, value int) {
+	v.check(value > 0 && value <= 65535, field, "must be a port between 1 and 65535, got %d", value)
+}
+
+func (v *validator) oneOf(field, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, field, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+func (v *validator) rabbitMq(prefix string, s settings.RabbitMqSettings) {
+	v.required(prefix+".Host", s.Host)
+	v.port(prefix+".Port", s.Port)
+	v.required(prefix+".User", s.User)
+	v.required(prefix+".Password", s.Password)
+	v.nonNegative(prefix+".HeartbeatSeconds", s.HeartbeatSeconds)
+	v.nonNegative(prefix+".MaxReconnectAttempts", s.MaxReconnectAttempts)
+	v.positive(prefix+".ReconnectTimeoutSeconds", s.ReconnectTimeoutSeconds)
+}
+
+func (v *validator) rabbitMqConsumer(prefix string, s settings.RabbitMqConsumerSettings) {
+	v.rabbitMq(prefix+".RabbitMqSettings", s.RabbitMqSettings)
+	v.required(prefix+".Queue", s.Queue)
+	v.positive(prefix+".BatchSize", s.BatchSize)
+	v.positive(prefix+".BatchTimeoutSeconds", s.BatchTimeoutSeconds)
+	v.positive(prefix+".ProcessTimeoutSeconds", s.ProcessTimeoutSeconds)
+	v.positive(prefix+".Workers", s.Workers)
+	v.positive(prefix+".DrainTimeoutSeconds", s.DrainTimeoutSeconds)
+	v.required(prefix+".DeadLetterSettings.Dlx", s.DeadLetterSettings.Dlx)
+	v.required(prefix+".DeadLetterSettings.Dlq", s.DeadLetterSettings.Dlq)
+	v.rabbitMqRetry(prefix+".RetrySettings", s.RetrySettings)
+}
+
+func (v *validator) rabbitMqRetry(prefix string, s settings.RabbitMqRetrySettings) {
+	if !s.Enabled {
+		return
+	}
+	v.positive(prefix+".MaxAttempts", s.MaxAttempts)
+	v.check(len(s.DelaysSeconds) > 0, prefix+".DelaysSeconds", "must not be empty")
+	for i, d := range s.DelaysSeconds {
+		v.positive(fmt.Sprintf("%s.DelaysSeconds[%d]", prefix, i), d)
+	}
+}
+
+func (v *validator) tlsServer(prefix string, s settings.TlsServerSettings) {
+	if !s.Enabled {
+		return
+	}
+	v.required(prefix+".CertFile", s.CertFile)
+	v.required(prefix+".KeyFile", s.KeyFile)
+	v.check(!s.RequireClientCert || s.ClientCaFile != "", prefix+".ClientCaFile", "is required when RequireClientCert is set")
+}
+
+func (v *validator) tlsClient(prefix string, s settings.TlsClientSettings) {
+	if !s.Enabled {
+		return
+	}
+	v.check((s.CertFile == "") == (s.KeyFile == ""), prefix, "CertFile and KeyFile must be set together")
+}
+
+func (v *validator) grpcClient(prefix string, s settings.GrpcClientSettings) {
+	v.required(prefix+".Address", s.Address)
+	v.tlsClient(prefix+".Tls", s.Tls)
+	v.oneOf(prefix+".LoadBalancingPolicy", s.LoadBalancingPolicy, "round_robin", "pick_first")
+	v.positive(prefix+".TimeoutMs", s.TimeoutMs)
+	for i, m := range s.MethodTimeouts {
+		field := fmt.Sprintf("%s.MethodTimeouts[%d]", prefix, i)
+		v.check(strings.Count(m.Method, "/") == 2 && strings.HasPrefix(m.Method, "/"), field+".Method",
+			"must be a full method name like /package.Service/Method, got %q", m.Method)
+		v.positive(field+".TimeoutMs", m.TimeoutMs)
+	}
+
+	if r := s.Retry; r.Enabled {
+		v.check(r.MaxAttempts >= 2 && r.MaxAttempts <= 5, prefix+".Retry.MaxAttempts", "must be between 2 and 5, got %d", r.MaxAttempts)
+		v.positive(prefix+".Retry.InitialBackoffMs", r.InitialBackoffMs)
+		v.positive(prefix+".Retry.MaxBackoffMs", r.MaxBackoffMs)
+		v.check(r.BackoffMultiplier > 0, prefix+".Retry.BackoffMultiplier", "must be greater than 0, got %g", r.BackoffMultiplier)
+		v.check(len(r.RetryableCodes) > 0, prefix+".Retry.RetryableCodes", "must not be empty")
+		for i, code := range r.RetryableCodes {
+			var c codes.Code
+			v.check(c.UnmarshalJSON([]byte(`"`+code+`"`)) == nil, fmt.Sprintf("%s.Retry.RetryableCodes[%d]", prefix, i),
+				"must be a gRPC code name like UNAVAILABLE, got %q", code)
+		}
+	}
+
+	if b := s.CircuitBreaker; b.Enabled {
+		v.positive(prefix+".CircuitBreaker.ConsecutiveFailures", b.ConsecutiveFailures)
+		v.positive(prefix+".CircuitBreaker.OpenTimeoutSeconds", b.OpenTimeoutSeconds)
+		v.positive(prefix+".CircuitBreaker.HalfOpenMaxRequests", b.HalfOpenMaxRequests)
+	}
+}
+
+func (v *validator) tracing(prefix string, s settings.TracingSettings) {
+	if !s.Enabled {
+		return
+	}
+	v.oneOf(prefix+".Exporter", s.Exporter, "otlp", "file")
+	if s.Exporter == "otlp" {
+		v.required(prefix+".OtlpEndpoint", s.OtlpEndpoint)
+	}
+	if s.Exporter == "file" {
+		v.required(prefix+".FilePath", s.FilePath)
+	}
+	v.check(s.SampleRatio >= 0 && s.SampleRatio <= 1, prefix+".SampleRatio", "must be between 0 and 1, got %v", s.SampleRatio)
+}
+
+func (v *validator) logging(prefix string, s settings.LoggingSettings) {
+	v.oneOf(prefix+".Level", s.Level, "debug", "info", "warn", "error")
+	v.oneOf(prefix+".Format", s.Format, "json", "console")
+	for name, level := range s.Components {
+		v.oneOf(prefix+".Components."+name, level, "debug", "info", "warn", "error")
+	}
+	if s.Sampling.Enabled {
+		v.positive(prefix+".Sampling.Initial", s.Sampling.Initial)
+		v.nonNegative(prefix+".Sampling.Thereafter", s.Sampling.Thereafter)
+	}
+	v.check(s.Output.Console || s.Output.File.Path != "", prefix+".Output", "must enable Console or set File.Path")
+	if s.Output.File.Path != "" {
+		v.nonNegative(prefix+".Output.File.MaxSizeMb", s.Output.File.MaxSizeMb)
+		v.nonNegative(prefix+".Output.File.MaxBackups", s.Output.File.MaxBackups)
+		v.nonNegative(prefix+".Output.File.MaxAgeDays", s.Output.File.MaxAgeDays)
+	}
+}
+
+func (v *validator) rateLimitRule(field string, r settings.RateLimitRule) {
+	v.check(r.RequestsPerSecond >= 0, field+".RequestsPerSecond", "must not be negative")
+	v.nonNegative(field+".Burst", r.Burst)
+}
+
+func (c *ServerConfig) Validate() error {
+	v := &validator{}
+
+	v.required("DbSettings.ConnectionString", c.DbSettings.ConnectionString)
+
+	v.rabbitMq("OmsPublisherSettings.RabbitMqSettings", c.OmsRabbitMqPublisherSettings.RabbitMqSettings)
+	v.required("OmsPublisherSettings.Exchange", c.OmsRabbitMqPublisherSettings.Exchange)
+	for i, m := range c.OmsRabbitMqPublisherSettings.ExchangeMappings {
+		prefix := fmt.Sprintf("OmsPublisherSettings.ExchangeMappings[%d]", i)
+		v.required(prefix+".Queue", m.Queue)
+		v.required(prefix+".RoutingKeyPattern", m.RoutingKeyPattern)
+	}
+
+	v.port("HttpServerSettings.Port", c.Http.Port)
+	v.port("GrpcServerSettings.Port", c.Grpc.Port)
+	v.check(c.Http.Port != c.Grpc.Port, "HttpServerSettings.Port", "must differ from GrpcServerSettings.Port")
+	v.tlsServer("HttpServerSettings.Tls", c.Http.Tls)
+	v.tlsServer("GrpcServerSettings.Tls", c.Grpc.Tls)
+	if c.Grpc.Tls.Enabled {
+		// The gateway dials the gRPC server with GatewayTls whenever the server uses TLS.
+		gw := c.Grpc.GatewayTls
+		v.check((gw.CertFile == "") == (gw.KeyFile == ""), "GrpcServerSettings.GatewayTls", "CertFile and KeyFile must be set together")
+		v.check(!c.Grpc.Tls.RequireClientCert || gw.CertFile != "",
+			"GrpcServerSettings.GatewayTls.CertFile", "is required when the gRPC server requires client certificates")
+	}
+
+	if c.Inventory.ReservationEnabled {
+		v.check(c.Inventory.DefaultWarehouseId > 0, "InventorySettings.DefaultWarehouseId", "must be greater than 0")
+	}
+	v.oneOf("ProductCatalogSettings.ValidationMode", c.ProductCatalog.ValidationMode, "off", "verify", "override")
+	v.nonNegative("ProductCatalogSettings.CacheTtlSeconds", c.ProductCatalog.CacheTtlSeconds)
+
+	if c.Saga.Enabled {
+		v.check(len(c.Saga.Steps) > 0, "SagaSettings.Steps", "must not be empty")
+		v.positive("SagaSettings.StepTimeoutSeconds", c.Saga.StepTimeoutSeconds)
+		v.positive("SagaSettings.MaxStepAttempts", c.Saga.MaxStepAttempts)
+		v.nonNegative("SagaSettings.StepRetryBackoffMs", c.Saga.StepRetryBackoffMs)
+		v.rabbitMqConsumer("SagaConsumerSettings", c.SagaRabbitMqConsumerSettings)
+	}
+
+	v.oneOf("PaymentProviderSettings.Provider", c.PaymentProvider.Provider, "fake")
+	v.required("PaymentProviderSettings.CallbackSecret", c.PaymentProvider.CallbackSecret)
+	v.oneOf("ShippingProviderSettings.Provider", c.ShippingProvider.Provider, "fake")
+
+	if c.StaleOrderScheduler.Enabled {
+		v.positive("StaleOrderSchedulerSettings.IntervalSeconds", c.StaleOrderScheduler.IntervalSeconds)
+		v.positive("StaleOrderSchedulerSettings.BatchSize", c.StaleOrderScheduler.BatchSize)
+		for status, ttl := range c.StaleOrderScheduler.StatusTtlSeconds {
+			v.positive("StaleOrderSchedulerSettings.StatusTtlSeconds."+status, ttl)
+		}
+	}
+
+	v.required("TenantSettings.DefaultTenantId", c.Tenancy.DefaultTenantId)
+
+	v.check(c.Dlq.ReplayRatePerSecond > 0, "DlqSettings.ReplayRatePerSecond", "must be greater than 0")
+	v.positive("DlqSettings.MaxScanMessages", c.Dlq.MaxScanMessages)
+	v.positive("DlqSettings.ReplayCallTimeoutSeconds", c.Dlq.ReplayCallTimeoutSeconds)
+	for i, q := range c.Dlq.Queues {
+		prefix := fmt.Sprintf("DlqSettings.Queues[%d]", i)
+		v.required(prefix+".Dlq", q.Dlq)
+		v.required(prefix+".Queue", q.Queue)
+	}
+
+	if c.Auth.Enabled {
+		v.required("AuthSettings.JwksFile", c.Auth.JwksFile)
+		v.required("AuthSettings.PolicyFile", c.Auth.PolicyFile)
+		v.nonNegative("AuthSettings.ClockSkewSeconds", c.Auth.ClockSkewSeconds)
+	}
+
+	if c.RateLimit.Enabled {
+		v.rateLimitRule("RateLimitSettings.Default", c.RateLimit.Default)
+		v.rateLimitRule("RateLimitSettings.Orders", c.RateLimit.Orders)
+		for i, m := range c.RateLimit.Methods {
+			prefix := fmt.Sprintf("RateLimitSettings.Methods[%d]", i)
+			v.required(prefix+".Method", m.Method)
+			v.rateLimitRule(prefix+".Limit", m.Limit)
+		}
+		for i, key := range c.RateLimit.ApiKeys {
+			v.required(fmt.Sprintf("RateLimitSettings.ApiKeys[%d]", i), key)
+		}
+		for i, q := range c.RateLimit.Clients {
+			prefix := fmt.Sprintf("RateLimitSettings.Clients[%d]", i)
+			v.required(prefix+".Client", q.Client)
+			v.rateLimitRule(prefix+".Requests", q.Requests)
+			v.rateLimitRule(prefix+".Orders", q.Orders)
+		}
+	}
+
+	v.tracing("TracingSettings", c.Tracing)
+	v.logging("LoggingSettings", c.Logging)
+
+	return v.err()
+}
+
+func (c *ConsumerConfig) Validate() error {
+	v := &validator{}
+
+	v.rabbitMqConsumer("OrderCreatedConsumerSettings", c.OrderCreatedRabbitMqConsumerSettings)
+	v.rabbitMqConsumer("OrderStatusChangedConsumerSettings", c.OrderStatusChangedRabbitMqConsumerSettings)
+	// Status events that overtake their order's created event wait in the delay queues;
+	// requeued straight away they would come back at once and spin.
+	v.check(c.OrderStatusChangedRabbitMqConsumerSettings.RetrySettings.Enabled,
+		"OrderStatusChangedConsumerSettings.RetrySettings.Enabled", "must be true")
+	v.grpcClient("OmsGrpcClient", c.OmsClientGrpcSettings)
+	v.tracing("TracingSettings", c.Tracing)
+	v.port("MetricsServerSettings.Port", c.Metrics.Port)
+	v.logging("LoggingSettings", c.Logging)
+
+	return v.err()
+}
+
+func (c *WebhookDispatcherConfig) Validate() error {
+	v := &validator{}
+
+	v.required("DbSettings.ConnectionString", c.DbSettings.ConnectionString)
+	v.rabbitMqConsumer("WebhookConsumerSettings", c.WebhookRabbitMqConsumerSettings)
+	v.positive("WebhookDispatcherSettings.RequestTimeoutSeconds", c.Dispatcher.RequestTimeoutSeconds)
+	v.positive("WebhookDispatcherSettings.MaxAttempts", c.Dispatcher.MaxAttempts)
+	v.nonNegative("WebhookDispatcherSettings.InitialBackoffMs", c.Dispatcher.InitialBackoffMs)
+	v.check(c.Dispatcher.MaxBackoffMs >= c.Dispatcher.InitialBackoffMs, "WebhookDispatcherSettings.MaxBackoffMs", "must not be less than InitialBackoffMs")
+	v.nonNegative("WebhookDispatcherSettings.DisableAfterFailures", c.Dispatcher.DisableAfterFailures)
+	v.positive("WebhookDispatcherSettings.MaxConcurrentDeliveries", c.Dispatcher.MaxConcurrentDeliveries)
+	v.tracing("TracingSettings", c.Tracing)
+	v.port("MetricsServerSettings.Port", c.Metrics.Port)
+	v.logging("LoggingSettings", c.Logging)
+
+	return v.err()
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/spf13/viper"
+)
+
+type WebhookDispatcherConfig struct {
+	DbSettings                      settings.DbSettings                `mapstructure:"DbSettings"`
+	WebhookRabbitMqConsumerSettings settings.RabbitMqConsumerSettings  `mapstructure:"WebhookConsumerSettings"`
+	Dispatcher                      settings.WebhookDispatcherSettings `mapstructure:"WebhookDispatcherSettings"`
+	Tracing                         settings.TracingSettings           `mapstructure:"TracingSettings"`
+	Metrics                         settings.HttpServerSettings        `mapstructure:"MetricsServerSettings"`
+	Logging                         settings.LoggingSettings           `mapstructure:"LoggingSettings"`
+	Admin                           settings.AdminSettings             `mapstructure:"AdminSettings"`
+}
+
+var webhookDispatcherSecretKeys = []string{
+	"DbSettings.ConnectionString",
+	"WebhookConsumerSettings.RabbitMqSettings.Password",
+	"AdminSettings.Token",
+}
+
+func LoadWebhookDispatcherConfig() (*WebhookDispatcherConfig, error) {
+	v, err := newWebhookDispatcherViper()
+	if err != nil {
+		return nil, err
+	}
+	return decodeWebhookDispatcherConfig(v)
+}
+
+// WatchWebhookDispatcherConfig calls onChange with the reloaded config every time the config
+// file changes. A config that fails to load or validate goes to onError instead.
+func WatchWebhookDispatcherConfig(onChange func(*WebhookDispatcherConfig), onError func(error)) error {
+	v, err := newWebhookDispatcherViper()
+	if err != nil {
+		return err
+	}
+	watchConfig(v, decodeWebhookDispatcherConfig, onChange, onError)
+	return nil
+}
+
+func newWebhookDispatcherViper() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetEnvKeyReplacer(envKeyReplacer)
+	v.AutomaticEnv()
+
+	env := v.GetString("APP_ENVIRONMENT")
+	if env == "" {
+		env = "Development"
+	}
+
+	setDefaultWebhookDispatcherConfigValues(v)
+
+	v.SetConfigType("yaml")
+	v.SetConfigName("appsettings." + env)
+	v.AddConfigPath("/etc/order-service-webhook-dispatcher")
+	v.AddConfigPath(".")
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	return v, nil
+}
+
+func decodeWebhookDispatcherConfig(v *viper.Viper) (*WebhookDispatcherConfig, error) {
+	if err := resolveSecretFiles(v, webhookDispatcherSecretKeys); err != nil {
+		return nil, err
+	}
+
+	var cfg WebhookDispatcherConfig
+	if err := v.Unmarshal(&cfg, strictDecoding); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func setDefaultWebhookDispatcherConfigValues(v *viper.Viper) {
+	v.SetDefault("WebhookConsumerSettings.RabbitMqSettings.HeartbeatSeconds", 30)
+	v.SetDefault("WebhookConsumerSettings.RabbitMqSettings.MaxReconnectAttempts", 3)
+	v.SetDefault("WebhookConsumerSettings.RabbitMqSettings.ReconnectTimeoutSeconds", 5)
+	v.SetDefault("WebhookConsumerSettings.BatchSize", 50)
+	v.SetDefault("WebhookConsumerSettings.BatchTimeoutSeconds", 1)
+	v.SetDefault("WebhookConsumerSettings.ProcessTimeoutSeconds", 60)
+	setDefaultConsumerValues(v, "WebhookConsumerSettings")
+
+	v.SetDefault("WebhookDispatcherSettings.RequestTimeoutSeconds", 5)
+	v.SetDefault("WebhookDispatcherSettings.MaxAttempts", 4)
+	v.SetDefault("WebhookDispatcherSettings.InitialBackoffMs", 500)
+	v.SetDefault("WebhookDispatcherSettings.MaxBackoffMs", 8000)
+	v.SetDefault("WebhookDispatcherSettings.DisableAfterFailures", 10)
+	v.SetDefault("WebhookDispatcherSettings.MaxConcurrentDeliveries", 16)
+	v.SetDefault("WebhookDispatcherSettings.AllowPrivateTargets", false)
+
+	v.SetDefault("TracingSettings.Enabled", false)
+	v.SetDefault("TracingSettings.Exporter", "otlp")
+	v.SetDefault("TracingSettings.SampleRatio", 1.0)
+
+	v.SetDefault("MetricsServerSettings.Port", 9090)
+	setDefaultLoggingValues(v)
+}
@@ -3,23 +3,22 @@ package consumer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
 	grpcclient "github.com/ZaiiiRan/backend_labs/order-service/internal/client/grpc"
 	dalconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
-	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
 	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
 )
 
 type OrderCreatedMessageProcessor struct {
+	orderPartition
+
 	client *grpcclient.OmsGrpcClient
 	log    *zap.SugaredLogger
-
-	counter int // experiment
 }
 
 func NewOrderCreatedMessageProcessor(client *grpcclient.OmsGrpcClient, log *zap.SugaredLogger) dalconsumer.MessageProcessor {
@@ -30,50 +29,61 @@ func NewOrderCreatedMessageProcessor(client *grpcclient.OmsGrpcClient, log *zap.
 }
 
 func (p *OrderCreatedMessageProcessor) ProcessMessage(ctx context.Context, batch []dalconsumer.MessageInfo) (bool, error) {
-	/* experiment */
-	p.counter++
-	if p.counter%5 == 0 {
-		p.log.Errorw("order_created_message_processor.experiment_error", "err", "experiment induced error")
-		return false, fmt.Errorf("experiment induced error")
-	}
-	/* experiment */
+	results, err := p.ProcessMessages(ctx, batch)
+	return dalconsumer.Requeue(results), err
+}
 
-	var orders []messages.OrderCreatedMessage
-	for _, msg := range batch {
+// ProcessMessages sends one audit request per tenant, so a failed call only fails the
+// messages of that tenant.
+func (p *OrderCreatedMessageProcessor) ProcessMessages(ctx context.Context, batch []dalconsumer.MessageInfo) ([]dalconsumer.MessageResult, error) {
+	results := dalconsumer.Results(len(batch), dalconsumer.MESSAGE_RESULT_ACK)
+	var errs []error
+	var tenants []string
+	reqs := make(map[string]*pb.AuditLogOrderBatchCreateRequest)
+	msgIdx := make(map[string][]int)
+	for i, msg := range batch {
 		var o messages.OrderCreatedMessage
 		if err := json.Unmarshal(msg.Body, &o); err != nil {
-			p.log.Errorw("order_created_message_processor.unmarshal_failed", "err", err, "body", string(msg.Body))
-			return false, fmt.Errorf("unmarshal: %w", err)
+			p.log.Errorw("order_created_message_processor.unmarshal_failed", "err", err, "body_size", len(msg.Body))
+			results[i] = dalconsumer.MESSAGE_RESULT_REJECT
+			errs = append(errs, fmt.Errorf("unmarshal: %w", err))
+			continue
 		}
-		orders = append(orders, o)
-	}
 
-	req := &pb.AuditLogOrderBatchCreateRequest{}
-	for _, order := range orders {
-		for _, item := range order.OrderItems {
+		req, ok := reqs[o.TenantId]
+		if !ok {
+			req = &pb.AuditLogOrderBatchCreateRequest{}
+			reqs[o.TenantId] = req
+			tenants = append(tenants, o.TenantId)
+		}
+		msgIdx[o.TenantId] = append(msgIdx[o.TenantId], i)
+		for _, item := range o.OrderItems {
 			log := &pb.LogOrder{
-				OrderId:     order.Id,
+				OrderId:     o.Id,
 				OrderItemId: item.Id,
-				CustomerId:  order.CustomerID,
+				CustomerId:  o.CustomerID,
 				OrderStatus: models.ORDER_STATUS_CREATED.String(),
 			}
-			p.log.Infow("order_created_message_processor.log_order", "log_order", log)
+			p.log.Debugw("order_created_message_processor.log_order", "log_order", log, "tenant_id", o.TenantId)
 			req.Orders = append(req.Orders, log)
 		}
 	}
 
-	if _, err := p.client.LogOrder(ctx, req); err != nil {
-		p.log.Errorw("order_created_message_processor.grpc_call_failed", "err", err)
-
-		needToRequeue := false
-		st, err := utils.GetGrpcErrStatus(err)
-		if err != nil || st.Code() != codes.InvalidArgument {
-			needToRequeue = true
+	for _, tenantID := range tenants {
+		if len(reqs[tenantID].Orders) == 0 {
+			continue
+		}
+		if _, err := p.client.LogOrder(grpcclient.WithTenant(ctx, tenantID), reqs[tenantID]); err != nil {
+			p.log.Errorw("order_created_message_processor.grpc_call_failed", "err", err, "tenant_id", tenantID)
+			requeue, err := grpcCallFailed(err)
+			if isUnavailable(err) {
+				return results, err
+			}
+			setResults(results, msgIdx[tenantID], failedResult(requeue))
+			errs = append(errs, err)
 		}
-
-		return needToRequeue, fmt.Errorf("grpc: %w", err)
 	}
 
-	p.log.Infow("order_created_message_processor.batch_processed", "count", len(orders))
-	return false, nil
+	p.log.Infow("order_created_message_processor.batch_processed", "count", len(batch), "failed", countFailed(results))
+	return results, errors.Join(errs...)
 }
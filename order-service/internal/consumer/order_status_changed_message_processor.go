@@ -3,18 +3,20 @@ package consumer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 
 	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
 	grpcclient "github.com/ZaiiiRan/backend_labs/order-service/internal/client/grpc"
 	dalconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
-	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
 	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
 )
 
 type OrderStatusChangedMessageProcessor struct {
+	orderPartition
+
 	client *grpcclient.OmsGrpcClient
 	log    *zap.SugaredLogger
 }
@@ -27,18 +29,66 @@ func NewOrderStatusChangedMessageProcessor(client *grpcclient.OmsGrpcClient, log
 }
 
 func (p *OrderStatusChangedMessageProcessor) ProcessMessage(ctx context.Context, batch []dalconsumer.MessageInfo) (bool, error) {
-	orders := make([]messages.OrderStatusChangedMessage, 0, len(batch))
-	ids := make([]int64, 0, len(batch))
-	for _, msg := range batch {
+	results, err := p.ProcessMessages(ctx, batch)
+	return dalconsumer.Requeue(results), err
+}
+
+// ProcessMessages records each order's current status rather than the one in the message,
+// so a late event can't overwrite a newer status. Messages of orders whose created event
+// hasn't been processed yet are retried on their own, after a delay.
+func (p *OrderStatusChangedMessageProcessor) ProcessMessages(ctx context.Context, batch []dalconsumer.MessageInfo) ([]dalconsumer.MessageResult, error) {
+	results := dalconsumer.Results(len(batch), dalconsumer.MESSAGE_RESULT_ACK)
+	var errs []error
+	var tenants []string
+	ids := make(map[string][]int64)
+	msgIdx := make(map[string][]int)
+	orderIDs := make([]int64, len(batch))
+	actors := make(map[int64]string, len(batch))
+	for i, msg := range batch {
 		var o messages.OrderStatusChangedMessage
 		if err := json.Unmarshal(msg.Body, &o); err != nil {
-			p.log.Errorw("order_status_changed_message_processor.unmarshal_failed", "err", err, "body", string(msg.Body))
-			return false, fmt.Errorf("unmarshal: %w", err)
+			p.log.Errorw("order_status_changed_message_processor.unmarshal_failed", "err", err, "body_size", len(msg.Body))
+			results[i] = dalconsumer.MESSAGE_RESULT_REJECT
+			errs = append(errs, fmt.Errorf("unmarshal: %w", err))
+			continue
+		}
+		if _, ok := ids[o.TenantId]; !ok {
+			tenants = append(tenants, o.TenantId)
+		}
+		ids[o.TenantId] = append(ids[o.TenantId], o.OrderId)
+		msgIdx[o.TenantId] = append(msgIdx[o.TenantId], i)
+		orderIDs[i] = o.OrderId
+		actors[o.OrderId] = o.Actor
+	}
+
+	for _, tenantID := range tenants {
+		notCreated, requeue, err := p.processTenant(grpcclient.WithTenant(ctx, tenantID), ids[tenantID], actors)
+		if err != nil {
+			if isUnavailable(err) {
+				return results, err
+			}
+			setResults(results, msgIdx[tenantID], failedResult(requeue))
+			errs = append(errs, err)
+			continue
+		}
+		if len(notCreated) == 0 {
+			continue
 		}
-		orders = append(orders, o)
-		ids = append(ids, o.OrderId)
+		for _, i := range msgIdx[tenantID] {
+			if slices.Contains(notCreated, orderIDs[i]) {
+				results[i] = dalconsumer.MESSAGE_RESULT_REQUEUE
+			}
+		}
+		errs = append(errs, fmt.Errorf("tenant %s: orders %v have no created entry yet", tenantID, notCreated))
 	}
 
+	p.log.Infow("order_status_changed_message_processor.batch_processed", "count", len(batch), "failed", countFailed(results))
+	return results, errors.Join(errs...)
+}
+
+// processTenant returns the orders that were left out because their created entry isn't
+// recorded yet; everything else in ids is logged.
+func (p *OrderStatusChangedMessageProcessor) processTenant(ctx context.Context, ids []int64, actors map[int64]string) ([]int64, bool, error) {
 	ordersResp, err := p.client.QueryOrders(ctx, &pb.QueryOrdersRequest{
 		Ids:               ids,
 		IncludeOrderItems: true,
@@ -47,14 +97,8 @@ func (p *OrderStatusChangedMessageProcessor) ProcessMessage(ctx context.Context,
 	})
 	if err != nil {
 		p.log.Errorw("order_status_changed_message_processor.grpc_call_failed", "err", err)
-
-		needToRequeue := false
-		st, err := utils.GetGrpcErrStatus(err)
-		if err != nil || st.Code() != codes.InvalidArgument {
-			needToRequeue = true
-		}
-
-		return needToRequeue, fmt.Errorf("grpc: %w", err)
+		requeue, err := grpcCallFailed(err)
+		return nil, requeue, err
 	}
 
 	req := &pb.AuditLogOrderBatchCreateRequest{}
@@ -65,24 +109,34 @@ func (p *OrderStatusChangedMessageProcessor) ProcessMessage(ctx context.Context,
 				OrderItemId: item.Id,
 				CustomerId:  order.CustomerId,
 				OrderStatus: order.Status,
+				Actor:       actors[order.Id],
 			}
-			p.log.Infow("order_status_changed_message_processor.log_order", "log_order", log)
+			p.log.Debugw("order_status_changed_message_processor.log_order", "log_order", log)
 			req.Orders = append(req.Orders, log)
 		}
 	}
+	if len(req.Orders) == 0 {
+		return nil, false, nil
+	}
 
-	if _, err := p.client.LogOrder(ctx, req); err != nil {
-		p.log.Errorw("order_status_changed_message_processor.grpc_call_failed", "err", err)
-
-		needToRequeue := false
-		st, err := utils.GetGrpcErrStatus(err)
-		if err != nil || st.Code() != codes.InvalidArgument {
-			needToRequeue = true
+	_, err = p.client.LogOrder(ctx, req)
+	notCreated, ok := notCreatedOrderIDs(err)
+	if ok {
+		// The OMS rejects the whole request; send it again without the early orders.
+		p.log.Infow("order_status_changed_message_processor.orders_not_created_yet", "order_ids", notCreated)
+		req.Orders = slices.DeleteFunc(req.Orders, func(l *pb.LogOrder) bool {
+			return slices.Contains(notCreated, l.OrderId)
+		})
+		if len(req.Orders) == 0 {
+			return notCreated, false, nil
 		}
-
-		return needToRequeue, fmt.Errorf("grpc: %w", err)
+		_, err = p.client.LogOrder(ctx, req)
+	}
+	if err != nil {
+		p.log.Errorw("order_status_changed_message_processor.grpc_call_failed", "err", err)
+		requeue, err := grpcCallFailed(err)
+		return nil, requeue, err
 	}
 
-	p.log.Infow("order_status_changed_message_processor.batch_processed", "count", len(orders))
-	return false, nil
+	return notCreated, false, nil
 }
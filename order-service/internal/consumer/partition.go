@@ -0,0 +1,23 @@
+package consumer
+
+import (
+	"strconv"
+
+	dalconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
+)
+
+// orderPartition keeps the events of one order on one worker of a consumer, so they are
+// processed in delivery order. That holds only within one queue and one replica, and a
+// requeued or retried message falls behind later ones. Events on different queues, such
+// as order.created and order.status.changed, are ordered by the audit log service, which
+// rejects status entries of orders not recorded as created yet.
+type orderPartition struct{}
+
+func (orderPartition) PartitionKey(msg dalconsumer.MessageInfo) string {
+	id, ok := messages.OrderID(msg.Body)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
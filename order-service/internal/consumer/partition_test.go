@@ -0,0 +1,24 @@
+package consumer
+
+import (
+	"testing"
+
+	dalconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+)
+
+func TestOrderPartitionKey(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"id":5,"customer_id":42}`, "5"},
+		{`{"order_id":5,"order_status":"completed"}`, "5"},
+		{`{"customer_id":42}`, ""},
+		{`not json`, ""},
+	}
+	for _, tt := range tests {
+		if got := (orderPartition{}).PartitionKey(dalconsumer.MessageInfo{Body: []byte(tt.body)}); got != tt.want {
+			t.Errorf("PartitionKey(%s) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
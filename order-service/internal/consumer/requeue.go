@@ -0,0 +1,93 @@
+package consumer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	grpcclient "github.com/ZaiiiRan/backend_labs/order-service/internal/client/grpc"
+	dalconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// These codes mean the request itself is wrong, so redelivering the message would fail
+// the same way. Aborted is left out: it means the message arrived too early.
+var permanentCodes = map[codes.Code]struct{}{
+	codes.InvalidArgument:    {},
+	codes.NotFound:           {},
+	codes.AlreadyExists:      {},
+	codes.FailedPrecondition: {},
+}
+
+func needsRequeue(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	_, permanent := permanentCodes[st.Code()]
+	return !permanent
+}
+
+// notCreatedOrderIDs returns the orders an Aborted audit request named as having no
+// created entry yet. ok is false for any other error.
+func notCreatedOrderIDs(err error) (ids []int64, ok bool) {
+	st, isStatus := status.FromError(err)
+	if !isStatus || st.Code() != codes.Aborted {
+		return nil, false
+	}
+	for _, d := range st.Details() {
+		info, isInfo := d.(*errdetails.ErrorInfo)
+		if !isInfo || info.Reason != "ORDER_NOT_CREATED_YET" {
+			continue
+		}
+		for _, raw := range strings.Split(info.Metadata["order_ids"], ",") {
+			id, parseErr := strconv.ParseInt(raw, 10, 64)
+			if parseErr != nil {
+				return nil, false
+			}
+			ids = append(ids, id)
+		}
+		return ids, len(ids) > 0
+	}
+	return nil, false
+}
+
+// grpcCallFailed decides what happens to a batch whose OMS call failed. An unreachable
+// OMS pauses the consumer rather than sending the batch to the DLQ.
+func grpcCallFailed(err error) (bool, error) {
+	if retryAfter, ok := grpcclient.Unavailable(err); ok {
+		return true, &dalconsumer.UnavailableError{RetryAfter: retryAfter, Err: err}
+	}
+	return needsRequeue(err), fmt.Errorf("grpc: %w", err)
+}
+
+func failedResult(requeue bool) dalconsumer.MessageResult {
+	if requeue {
+		return dalconsumer.MESSAGE_RESULT_REQUEUE
+	}
+	return dalconsumer.MESSAGE_RESULT_REJECT
+}
+
+func setResults(results []dalconsumer.MessageResult, idx []int, result dalconsumer.MessageResult) {
+	for _, i := range idx {
+		results[i] = result
+	}
+}
+
+func isUnavailable(err error) bool {
+	var unavailable *dalconsumer.UnavailableError
+	return errors.As(err, &unavailable)
+}
+
+func countFailed(results []dalconsumer.MessageResult) int {
+	n := 0
+	for _, r := range results {
+		if r != dalconsumer.MESSAGE_RESULT_ACK {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,44 @@
+package consumer
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func statusWithInfo(t *testing.T, code codes.Code, reason string, metadata map[string]string) error {
+	t.Helper()
+	st, err := status.New(code, "test").WithDetails(&errdetails.ErrorInfo{Reason: reason, Metadata: metadata})
+	if err != nil {
+		t.Fatalf("WithDetails: %v", err)
+	}
+	return st.Err()
+}
+
+func TestNotCreatedOrderIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   []int64
+		wantOk bool
+	}{
+		{"not created yet", statusWithInfo(t, codes.Aborted, "ORDER_NOT_CREATED_YET", map[string]string{"order_ids": "5,7"}), []int64{5, 7}, true},
+		{"other reason", statusWithInfo(t, codes.Aborted, "CONCURRENT_UPDATE", map[string]string{"order_ids": "5"}), nil, false},
+		{"other code", statusWithInfo(t, codes.FailedPrecondition, "ORDER_NOT_CREATED_YET", map[string]string{"order_ids": "5"}), nil, false},
+		{"malformed ids", statusWithInfo(t, codes.Aborted, "ORDER_NOT_CREATED_YET", map[string]string{"order_ids": "5,x"}), nil, false},
+		{"no ids", statusWithInfo(t, codes.Aborted, "ORDER_NOT_CREATED_YET", nil), nil, false},
+		{"no details", status.Error(codes.Aborted, "aborted"), nil, false},
+		{"not a status", errors.New("boom"), nil, false},
+		{"nil", nil, nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := notCreatedOrderIDs(tt.err)
+		if !slices.Equal(got, tt.want) || ok != tt.wantOk {
+			t.Errorf("%s: notCreatedOrderIDs = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	dalconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/saga"
+	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
+	"go.uber.org/zap"
+)
+
+type SagaMessageProcessor struct {
+	orderPartition
+
+	orchestrator *saga.Orchestrator
+	log          *zap.SugaredLogger
+}
+
+func NewSagaMessageProcessor(orchestrator *saga.Orchestrator, log *zap.SugaredLogger) dalconsumer.MessageProcessor {
+	return &SagaMessageProcessor{
+		orchestrator: orchestrator,
+		log:          log,
+	}
+}
+
+func (p *SagaMessageProcessor) ProcessMessage(ctx context.Context, batch []dalconsumer.MessageInfo) (bool, error) {
+	results, err := p.ProcessMessages(ctx, batch)
+	return dalconsumer.Requeue(results), err
+}
+
+// ProcessMessages starts every saga on its own, so one failed start doesn't fail the
+// sagas of the other orders in the batch.
+func (p *SagaMessageProcessor) ProcessMessages(ctx context.Context, batch []dalconsumer.MessageInfo) ([]dalconsumer.MessageResult, error) {
+	results := dalconsumer.Results(len(batch), dalconsumer.MESSAGE_RESULT_ACK)
+	var errs []error
+	for i, msg := range batch {
+		var o messages.OrderCreatedMessage
+		if err := json.Unmarshal(msg.Body, &o); err != nil {
+			p.log.Errorw("saga_message_processor.unmarshal_failed", "err", err, "body_size", len(msg.Body))
+			results[i] = dalconsumer.MESSAGE_RESULT_REJECT
+			errs = append(errs, fmt.Errorf("unmarshal: %w", err))
+			continue
+		}
+
+		if err := p.orchestrator.Start(ctx, o.Id); err != nil {
+			p.log.Errorw("saga_message_processor.start_saga_failed", "order_id", o.Id, "err", err)
+			results[i] = dalconsumer.MESSAGE_RESULT_REQUEUE
+			errs = append(errs, fmt.Errorf("start saga: %w", err))
+		}
+	}
+
+	p.log.Infow("saga_message_processor.success", "sagas_count", len(batch)-countFailed(results))
+	return results, errors.Join(errs...)
+}
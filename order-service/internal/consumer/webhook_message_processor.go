@@ -0,0 +1,380 @@
+package consumer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	httpclient "github.com/ZaiiiRan/backend_labs/order-service/internal/client/http"
+	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	dalconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
+	"go.uber.org/zap"
+)
+
+type webhookEvent struct {
+	ID         string
+	Type       bll.WebhookEventType
+	TenantID   string
+	CustomerID int64
+	Body       []byte
+}
+
+type webhookEnvelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+type webhookDeliveryKey struct {
+	subscriptionID int64
+	eventID        string
+}
+
+type webhookDeliveryResult struct {
+	subscriptionID int64
+	eventID        string
+	attempts       []bll.WebhookDelivery
+	success        bool
+	// retryable is set for failures that may pass when the event is sent again later.
+	retryable bool
+}
+
+type WebhookMessageProcessor struct {
+	orderPartition
+
+	cfg      *config.WebhookDispatcherSettings
+	pgClient *postgres.PostgresClient
+	client   *httpclient.WebhookClient
+	log      *zap.SugaredLogger
+}
+
+func NewWebhookMessageProcessor(
+	cfg *config.WebhookDispatcherSettings,
+	pgClient *postgres.PostgresClient,
+	client *httpclient.WebhookClient,
+	log *zap.SugaredLogger,
+) dalconsumer.MessageProcessor {
+	return &WebhookMessageProcessor{
+		cfg:      cfg,
+		pgClient: pgClient,
+		client:   client,
+		log:      log,
+	}
+}
+
+func (p *WebhookMessageProcessor) ProcessMessage(ctx context.Context, batch []dalconsumer.MessageInfo) (bool, error) {
+	results, err := p.ProcessMessages(ctx, batch)
+	return dalconsumer.Requeue(results), err
+}
+
+// ProcessMessages delivers each event only to the subscriptions of the event's tenant.
+// Only messages with a delivery that may still pass are retried, and a retried event
+// skips the subscriptions that already received it.
+func (p *WebhookMessageProcessor) ProcessMessages(ctx context.Context, batch []dalconsumer.MessageInfo) ([]dalconsumer.MessageResult, error) {
+	results := dalconsumer.Results(len(batch), dalconsumer.MESSAGE_RESULT_ACK)
+	var errs []error
+	var tenants []string
+	events := make(map[string][]webhookEvent)
+	msgIdx := make(map[string][]int)
+	for i, msg := range batch {
+		ev, ok, err := p.parseEvent(msg)
+		if err != nil {
+			p.log.Errorw("webhook_message_processor.unmarshal_failed", "err", err, "routing_key", msg.RoutingKey)
+			results[i] = dalconsumer.MESSAGE_RESULT_REJECT
+			errs = append(errs, fmt.Errorf("unmarshal: %w", err))
+			continue
+		}
+		if !ok {
+			p.log.Warnw("webhook_message_processor.unknown_event_type", "routing_key", msg.RoutingKey)
+			continue
+		}
+		// A message delivered twice in one batch has the same event id and is sent once.
+		if _, seen := msgIdx[ev.ID]; !seen {
+			if _, ok := events[ev.TenantID]; !ok {
+				tenants = append(tenants, ev.TenantID)
+			}
+			events[ev.TenantID] = append(events[ev.TenantID], ev)
+		}
+		msgIdx[ev.ID] = append(msgIdx[ev.ID], i)
+	}
+	if len(tenants) == 0 {
+		return results, errors.Join(errs...)
+	}
+
+	webhookSvc := p.createBllWebhookService()
+	defer webhookSvc.UnitOfWork().Close()
+
+	for _, tenantID := range tenants {
+		failed, err := p.processTenant(utils.WithTenant(ctx, tenantID), webhookSvc, events[tenantID])
+		if err != nil {
+			for _, ev := range events[tenantID] {
+				setResults(results, msgIdx[ev.ID], dalconsumer.MESSAGE_RESULT_REQUEUE)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		for _, eventID := range failed {
+			setResults(results, msgIdx[eventID], dalconsumer.MESSAGE_RESULT_REQUEUE)
+		}
+		if len(failed) > 0 {
+			errs = append(errs, fmt.Errorf("tenant %s: %d events not delivered to every subscription", tenantID, len(failed)))
+		}
+	}
+
+	p.log.Infow("webhook_message_processor.batch_processed", "count", len(batch), "failed", countFailed(results))
+	return results, errors.Join(errs...)
+}
+
+// processTenant returns the ids of events with a delivery worth retrying.
+func (p *WebhookMessageProcessor) processTenant(ctx context.Context, webhookSvc *bllServices.WebhookService, events []webhookEvent) ([]string, error) {
+	var types []bll.WebhookEventType
+	eventIDs := make([]string, 0, len(events))
+	for _, ev := range events {
+		if !slices.Contains(types, ev.Type) {
+			types = append(types, ev.Type)
+		}
+		eventIDs = append(eventIDs, ev.ID)
+	}
+	subs, err := webhookSvc.GetActiveSubscriptions(ctx, types)
+	if err != nil {
+		p.log.Errorw("webhook_message_processor.get_subscriptions_failed", "err", err, "tenant_id", utils.TenantFromContext(ctx))
+		return nil, fmt.Errorf("get subscriptions: %w", err)
+	}
+
+	succeeded, err := webhookSvc.GetSucceededDeliveries(ctx, eventIDs)
+	if err != nil {
+		p.log.Errorw("webhook_message_processor.get_succeeded_deliveries_failed", "err", err, "tenant_id", utils.TenantFromContext(ctx))
+		return nil, fmt.Errorf("get succeeded deliveries: %w", err)
+	}
+	delivered := make(map[webhookDeliveryKey]struct{}, len(succeeded))
+	for _, d := range succeeded {
+		delivered[webhookDeliveryKey{d.SubscriptionID, d.EventID}] = struct{}{}
+	}
+
+	results := p.dispatch(ctx, events, subs, delivered)
+
+	var attempts []bll.WebhookDelivery
+	var failed []string
+	for _, r := range results {
+		attempts = append(attempts, r.attempts...)
+		if !r.success && r.retryable && !slices.Contains(failed, r.eventID) {
+			failed = append(failed, r.eventID)
+		}
+	}
+	if err := webhookSvc.RecordDeliveries(ctx, attempts); err != nil {
+		p.log.Errorw("webhook_message_processor.record_deliveries_failed", "err", err, "count", len(attempts))
+	}
+	for _, r := range results {
+		if err := webhookSvc.RegisterDeliveryResult(ctx, r.subscriptionID, r.success, p.cfg.DisableAfterFailures); err != nil {
+			p.log.Errorw("webhook_message_processor.register_result_failed", "err", err, "subscription_id", r.subscriptionID)
+		}
+	}
+
+	p.log.Infow("webhook_message_processor.tenant_processed",
+		"tenant_id", utils.TenantFromContext(ctx),
+		"events", len(events),
+		"deliveries", len(results),
+		"skipped", len(delivered),
+		"failed_events", len(failed))
+	return failed, nil
+}
+
+func (p *WebhookMessageProcessor) parseEvent(msg dalconsumer.MessageInfo) (webhookEvent, bool, error) {
+	event, tenantID := messages.ParseRoutingKey(msg.RoutingKey)
+	eventType := bll.StringToWebhookEventType(event)
+	if eventType == "" {
+		return webhookEvent{}, false, nil
+	}
+
+	var payload struct {
+		CustomerID int64     `json:"customer_id"`
+		TenantID   string    `json:"tenant_id"`
+		CreatedAt  time.Time `json:"created_at"`
+		UpdatedAt  time.Time `json:"updated_at"`
+	}
+	if err := json.Unmarshal(msg.Body, &payload); err != nil {
+		return webhookEvent{}, false, err
+	}
+
+	// The event happened when the order was created or changed; messages published
+	// without that time fall back to when the message arrived.
+	occurredAt := payload.CreatedAt
+	if eventType == bll.WEBHOOK_EVENT_ORDER_STATUS_CHANGED {
+		occurredAt = payload.UpdatedAt
+	}
+	if occurredAt.IsZero() {
+		occurredAt = msg.ReceivedAt
+	}
+
+	sum := sha256.Sum256(append([]byte(msg.RoutingKey+":"), msg.Body...))
+	id := hex.EncodeToString(sum[:16])
+
+	body, err := json.Marshal(webhookEnvelope{
+		ID:         id,
+		Type:       eventType.String(),
+		OccurredAt: occurredAt.UTC(),
+		Data:       msg.Body,
+	})
+	if err != nil {
+		return webhookEvent{}, false, err
+	}
+
+	if tenantID == "" {
+		tenantID = payload.TenantID
+	}
+	if tenantID == "" {
+		tenantID = utils.DEFAULT_TENANT_ID
+	}
+
+	return webhookEvent{
+		ID:         id,
+		Type:       eventType,
+		TenantID:   tenantID,
+		CustomerID: payload.CustomerID,
+		Body:       body,
+	}, true, nil
+}
+
+// dispatch skips the pairs in delivered, which already got the event on an earlier try.
+func (p *WebhookMessageProcessor) dispatch(
+	ctx context.Context,
+	events []webhookEvent,
+	subs []bll.WebhookSubscription,
+	delivered map[webhookDeliveryKey]struct{},
+) []webhookDeliveryResult {
+	concurrency := p.cfg.MaxConcurrentDeliveries
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []webhookDeliveryResult
+		sem     = make(chan struct{}, concurrency)
+	)
+
+	for _, ev := range events {
+		for _, sub := range subs {
+			if !sub.Matches(ev.Type, ev.CustomerID) {
+				continue
+			}
+			if _, ok := delivered[webhookDeliveryKey{sub.ID, ev.ID}]; ok {
+				continue
+			}
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(sub bll.WebhookSubscription, ev webhookEvent) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				attempts, success, retryable := p.deliver(ctx, sub, ev)
+
+				mu.Lock()
+				results = append(results, webhookDeliveryResult{
+					subscriptionID: sub.ID,
+					eventID:        ev.ID,
+					attempts:       attempts,
+					success:        success,
+					retryable:      retryable,
+				})
+				mu.Unlock()
+			}(sub, ev)
+		}
+	}
+
+	wg.Wait()
+	return results
+}
+
+// deliver reports whether the event got through and, if not, whether it may later.
+func (p *WebhookMessageProcessor) deliver(ctx context.Context, sub bll.WebhookSubscription, ev webhookEvent) ([]bll.WebhookDelivery, bool, bool) {
+	maxAttempts := p.cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	backoff := time.Duration(p.cfg.InitialBackoffMs) * time.Millisecond
+	maxBackoff := time.Duration(p.cfg.MaxBackoffMs) * time.Millisecond
+
+	var attempts []bll.WebhookDelivery
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		start := time.Now()
+		code, err := p.client.Send(ctx, httpclient.WebhookRequest{
+			URL:       sub.URL,
+			Secret:    sub.Secret,
+			EventType: ev.Type.String(),
+			EventID:   ev.ID,
+			Attempt:   attempt,
+			Body:      ev.Body,
+		})
+
+		d := bll.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventType:      ev.Type,
+			EventID:        ev.ID,
+			Attempt:        attempt,
+			StatusCode:     code,
+			Success:        err == nil,
+			Duration:       time.Since(start),
+			CreatedAt:      start,
+			TenantID:       sub.TenantID,
+		}
+		if err != nil {
+			d.Error = err.Error()
+		}
+		attempts = append(attempts, d)
+
+		if err == nil {
+			return attempts, true, false
+		}
+
+		p.log.Warnw("webhook_message_processor.delivery_failed",
+			"subscription_id", sub.ID,
+			"event_id", ev.ID,
+			"attempt", attempt,
+			"status_code", code,
+			"err", err)
+
+		if !httpclient.IsRetryableWebhook(code, err) {
+			return attempts, false, false
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return attempts, false, true
+		}
+
+		backoff *= 2
+		if maxBackoff > 0 && backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	return attempts, false, true
+}
+
+func (p *WebhookMessageProcessor) createBllWebhookService() *bllServices.WebhookService {
+	uow := unitofwork.New(p.pgClient)
+	subscriptionRepo := repositories.NewWebhookSubscriptionRepository(uow)
+	deliveryRepo := repositories.NewWebhookDeliveryRepository(uow)
+	return bllServices.NewWebhookService(uow, subscriptionRepo, deliveryRepo, p.log)
+}
@@ -0,0 +1,240 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	httpclient "github.com/ZaiiiRan/backend_labs/order-service/internal/client/http"
+	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	dalconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+	"go.uber.org/zap"
+)
+
+func newTestWebhookProcessor(cfg config.WebhookDispatcherSettings) *WebhookMessageProcessor {
+	return &WebhookMessageProcessor{
+		cfg:    &cfg,
+		client: httpclient.NewWebhookClient(time.Second, true),
+		log:    zap.NewNop().Sugar(),
+	}
+}
+
+// newWebhookReceiver answers with the given status codes in turn, repeating the last one.
+func newWebhookReceiver(t *testing.T, codes ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		w.WriteHeader(codes[min(n, len(codes))-1])
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestWebhookDeliverRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		codes         []int
+		maxAttempts   int
+		wantAttempts  int
+		wantSuccess   bool
+		wantRetryable bool
+	}{
+		{"first attempt succeeds", []int{200}, 3, 1, true, false},
+		{"succeeds after retryable failures", []int{500, 429, 200}, 3, 3, true, false},
+		{"gives up after max attempts", []int{503}, 3, 3, false, true},
+		{"doesn't retry client errors", []int{400, 200}, 3, 1, false, false},
+		{"zero max attempts sends once", []int{500}, 0, 1, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := newWebhookReceiver(t, tt.codes...)
+			p := newTestWebhookProcessor(config.WebhookDispatcherSettings{
+				MaxAttempts:      tt.maxAttempts,
+				InitialBackoffMs: 1,
+				MaxBackoffMs:     2,
+			})
+			sub := bll.WebhookSubscription{ID: 7, URL: srv.URL, Secret: "secret"}
+			ev := webhookEvent{ID: "evt", Type: bll.WEBHOOK_EVENT_ORDER_CREATED, Body: []byte("{}")}
+
+			attempts, success, retryable := p.deliver(context.Background(), sub, ev)
+
+			if success != tt.wantSuccess || retryable != tt.wantRetryable {
+				t.Errorf("success, retryable = %v, %v; want %v, %v", success, retryable, tt.wantSuccess, tt.wantRetryable)
+			}
+			if len(attempts) != tt.wantAttempts || int(calls.Load()) != tt.wantAttempts {
+				t.Fatalf("attempts = %d, calls = %d, want %d", len(attempts), calls.Load(), tt.wantAttempts)
+			}
+			for i, a := range attempts {
+				if a.Attempt != i+1 || a.SubscriptionID != sub.ID || a.EventID != ev.ID {
+					t.Errorf("attempt %d = %+v", i, a)
+				}
+				last := i == len(attempts)-1
+				if a.Success != (last && tt.wantSuccess) {
+					t.Errorf("attempt %d success = %v", i+1, a.Success)
+				}
+				if !a.Success && a.Error == "" {
+					t.Errorf("attempt %d has no error", i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookDeliverStopsOnCancel(t *testing.T) {
+	srv, calls := newWebhookReceiver(t, 500)
+	p := newTestWebhookProcessor(config.WebhookDispatcherSettings{MaxAttempts: 5, InitialBackoffMs: 60_000})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	attempts, success, retryable := p.deliver(ctx, bll.WebhookSubscription{URL: srv.URL}, webhookEvent{Body: []byte("{}")})
+	if success || !retryable || len(attempts) != 1 || calls.Load() != 1 {
+		t.Errorf("success = %v, retryable = %v, attempts = %d, calls = %d; want one retryable failed attempt", success, retryable, len(attempts), calls.Load())
+	}
+}
+
+func TestWebhookDispatchMatchesSubscriptions(t *testing.T) {
+	srv, calls := newWebhookReceiver(t, 200)
+	p := newTestWebhookProcessor(config.WebhookDispatcherSettings{MaxAttempts: 1, MaxConcurrentDeliveries: 2})
+
+	subs := []bll.WebhookSubscription{
+		{ID: 1, URL: srv.URL, IsActive: true, EventTypes: []bll.WebhookEventType{bll.WEBHOOK_EVENT_ORDER_CREATED}},
+		{ID: 2, URL: srv.URL, IsActive: true, EventTypes: []bll.WebhookEventType{bll.WEBHOOK_EVENT_ORDER_CREATED}, CustomerIDs: []int64{42}},
+		{ID: 3, URL: srv.URL, IsActive: false, EventTypes: []bll.WebhookEventType{bll.WEBHOOK_EVENT_ORDER_CREATED}},
+		{ID: 4, URL: srv.URL, IsActive: true, EventTypes: []bll.WebhookEventType{bll.WEBHOOK_EVENT_ORDER_STATUS_CHANGED}},
+	}
+	events := []webhookEvent{
+		{ID: "a", Type: bll.WEBHOOK_EVENT_ORDER_CREATED, CustomerID: 42, Body: []byte("{}")},
+		{ID: "b", Type: bll.WEBHOOK_EVENT_ORDER_CREATED, CustomerID: 1, Body: []byte("{}")},
+	}
+
+	results := p.dispatch(context.Background(), events, subs, nil)
+
+	delivered := make(map[int64]int)
+	for _, r := range results {
+		if !r.success {
+			t.Errorf("delivery to subscription %d failed", r.subscriptionID)
+		}
+		delivered[r.subscriptionID]++
+	}
+	if delivered[1] != 2 || delivered[2] != 1 || delivered[3] != 0 || delivered[4] != 0 {
+		t.Errorf("deliveries per subscription = %v, want map[1:2 2:1]", delivered)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+}
+
+func TestWebhookDispatchSkipsDelivered(t *testing.T) {
+	srv, calls := newWebhookReceiver(t, 200)
+	p := newTestWebhookProcessor(config.WebhookDispatcherSettings{MaxAttempts: 1, MaxConcurrentDeliveries: 2})
+
+	subs := []bll.WebhookSubscription{
+		{ID: 1, URL: srv.URL, IsActive: true, EventTypes: []bll.WebhookEventType{bll.WEBHOOK_EVENT_ORDER_CREATED}},
+		{ID: 2, URL: srv.URL, IsActive: true, EventTypes: []bll.WebhookEventType{bll.WEBHOOK_EVENT_ORDER_CREATED}},
+	}
+	events := []webhookEvent{{ID: "a", Type: bll.WEBHOOK_EVENT_ORDER_CREATED, Body: []byte("{}")}}
+
+	results := p.dispatch(context.Background(), events, subs, map[webhookDeliveryKey]struct{}{{1, "a"}: {}})
+
+	if len(results) != 1 || results[0].subscriptionID != 2 || results[0].eventID != "a" || calls.Load() != 1 {
+		t.Errorf("results = %+v, calls = %d; want only subscription 2", results, calls.Load())
+	}
+}
+
+func TestWebhookParseEventOccurredAt(t *testing.T) {
+	p := newTestWebhookProcessor(config.WebhookDispatcherSettings{})
+	receivedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		routingKey string
+		body       string
+		want       time.Time
+	}{
+		{"order.created", `{"id":1,"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:05:00Z"}`, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"order.status.changed", `{"order_id":1,"updated_at":"2024-01-01T00:05:00Z"}`, time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC)},
+		{"order.status.changed", `{"order_id":1}`, receivedAt},
+	}
+	for _, tt := range tests {
+		ev, ok, err := p.parseEvent(dalconsumer.MessageInfo{RoutingKey: tt.routingKey, Body: []byte(tt.body), ReceivedAt: receivedAt})
+		if err != nil || !ok {
+			t.Fatalf("parseEvent(%s) = %v, %v", tt.body, ok, err)
+		}
+		var env webhookEnvelope
+		if err := json.Unmarshal(ev.Body, &env); err != nil {
+			t.Fatalf("unmarshal envelope: %v", err)
+		}
+		if !env.OccurredAt.Equal(tt.want) {
+			t.Errorf("parseEvent(%s) occurred_at = %v, want %v", tt.body, env.OccurredAt, tt.want)
+		}
+	}
+}
+
+func TestWebhookParseEvent(t *testing.T) {
+	p := newTestWebhookProcessor(config.WebhookDispatcherSettings{})
+	msg := dalconsumer.MessageInfo{
+		RoutingKey: "order.created",
+		Body:       []byte(`{"id":5,"customer_id":42,"created_at":"2024-01-01T10:00:00+03:00"}`),
+		ReceivedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	occurredAt := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
+
+	ev, ok, err := p.parseEvent(msg)
+	if err != nil || !ok {
+		t.Fatalf("parseEvent = %v, %v", ok, err)
+	}
+	if ev.Type != bll.WEBHOOK_EVENT_ORDER_CREATED || ev.CustomerID != 42 || ev.ID == "" {
+		t.Errorf("event = %+v", ev)
+	}
+
+	var env webhookEnvelope
+	if err := json.Unmarshal(ev.Body, &env); err != nil {
+		t.Fatalf("unmarshal envelope: %v", err)
+	}
+	if env.ID != ev.ID || env.Type != "order.created" || !env.OccurredAt.Equal(occurredAt) || string(env.Data) != string(msg.Body) {
+		t.Errorf("envelope = %+v", env)
+	}
+
+	again, _, _ := p.parseEvent(msg)
+	if again.ID != ev.ID {
+		t.Error("event id is not stable across redeliveries")
+	}
+
+	if ev.TenantID != "default" {
+		t.Errorf("tenant = %q, want default", ev.TenantID)
+	}
+	if _, ok, err := p.parseEvent(dalconsumer.MessageInfo{RoutingKey: "unknown.event", Body: []byte("{}")}); ok || err != nil {
+		t.Errorf("unknown event: ok = %v, err = %v", ok, err)
+	}
+	if _, _, err := p.parseEvent(dalconsumer.MessageInfo{RoutingKey: "order.created", Body: []byte("{")}); err == nil {
+		t.Error("malformed body: want error")
+	}
+}
+
+func TestWebhookParseEventTenant(t *testing.T) {
+	p := newTestWebhookProcessor(config.WebhookDispatcherSettings{})
+	tests := []struct {
+		routingKey string
+		body       string
+		want       string
+	}{
+		{"order.created", `{"customer_id":1}`, "default"},
+		{"order.created.acme", `{"customer_id":1}`, "acme"},
+		{"order.created", `{"customer_id":1,"tenant_id":"acme"}`, "acme"},
+		{"order.status.changed.acme", `{"order_id":1,"tenant_id":"other"}`, "acme"},
+	}
+	for _, tt := range tests {
+		ev, ok, err := p.parseEvent(dalconsumer.MessageInfo{RoutingKey: tt.routingKey, Body: []byte(tt.body)})
+		if err != nil || !ok {
+			t.Fatalf("parseEvent(%s) = %v, %v", tt.routingKey, ok, err)
+		}
+		if ev.TenantID != tt.want {
+			t.Errorf("parseEvent(%s, %s) tenant = %q, want %q", tt.routingKey, tt.body, ev.TenantID, tt.want)
+		}
+	}
+}
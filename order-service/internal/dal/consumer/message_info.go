@@ -1,9 +1,19 @@
 package consumer
 
-import "time"
+import (
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
 
 type MessageInfo struct {
 	DeliveryTag uint64
+	RoutingKey  string
+	ContentType string
 	Body        []byte
+	Headers     amqp.Table
 	ReceivedAt  time.Time
+	// Acknowledger is the channel the message was delivered on. Delivery tags are only
+	// valid on that channel, so the message must be settled through it.
+	Acknowledger amqp.Acknowledger
 }
@@ -5,3 +5,10 @@ import "context"
 type MessageProcessor interface {
 	ProcessMessage(ctx context.Context, batch []MessageInfo) (bool, error)
 }
+
+// PartitionKeyer is implemented by processors whose messages must be handled in order per
+// key. Messages with the same key always go to the same worker; messages without a key
+// are spread over all workers.
+type PartitionKeyer interface {
+	PartitionKey(msg MessageInfo) string
+}
@@ -0,0 +1,40 @@
+package consumer
+
+import "context"
+
+type MessageResult int
+
+const (
+	MESSAGE_RESULT_ACK MessageResult = iota
+	MESSAGE_RESULT_REQUEUE
+	// MESSAGE_RESULT_REJECT marks a message as part of a failed group. Rejected messages
+	// are retried in halves until each one fails alone, and only then dead-lettered.
+	MESSAGE_RESULT_REJECT
+)
+
+// MessageResultsProcessor is implemented by processors that can tell which messages of
+// a batch failed. results[i] belongs to batch[i]; the consumer prefers it over
+// ProcessMessage. An *UnavailableError requeues the whole batch whatever the results.
+type MessageResultsProcessor interface {
+	ProcessMessages(ctx context.Context, batch []MessageInfo) ([]MessageResult, error)
+}
+
+// Results gives every message of a batch the same result, for processors that can only
+// fail a batch as a whole.
+func Results(n int, result MessageResult) []MessageResult {
+	results := make([]MessageResult, n)
+	for i := range results {
+		results[i] = result
+	}
+	return results
+}
+
+// Requeue folds per-message results into the ProcessMessage requeue flag.
+func Requeue(results []MessageResult) bool {
+	for _, r := range results {
+		if r == MESSAGE_RESULT_REQUEUE {
+			return true
+		}
+	}
+	return false
+}
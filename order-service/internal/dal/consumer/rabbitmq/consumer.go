@@ -2,14 +2,22 @@ package consumer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/rabbitmq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/metrics"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tracing"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -19,13 +27,42 @@ type Consumer struct {
 	ch               *amqp.Channel
 	log              *zap.SugaredLogger
 	stopCh           chan struct{}
-	buffer           []consumer.MessageInfo
 	mu               sync.Mutex
-	timer            *time.Timer
 	messageProcessor consumer.MessageProcessor
 	reconnectMu      sync.Mutex
+	running          atomic.Bool
+
+	workers     []*worker
+	workersOnce sync.Once
+	workersWg   sync.WaitGroup
+	nextWorker  atomic.Uint64
+	buffered    atomic.Int64
+
+	// consumerTag identifies the subscription so Stop can cancel it. loopDone is closed
+	// when the consume loop has returned; ctx is cancelled when a drain runs out of time.
+	consumerTag string
+	started     atomic.Bool
+	stopOnce    sync.Once
+	closeOnce   sync.Once
+	loopDone    chan struct{}
+	ctx         context.Context
+	cancel      context.CancelFunc
+
+	// batchSize and batchTimeout can be changed at runtime and are guarded by mu.
+	batchSize    int
+	batchTimeout time.Duration
+
+	// pausedUntil is guarded by mu; pauseCh wakes the consume loop when it changes.
+	pausedUntil time.Time
+	pauseCh     chan struct{}
 }
 
+// defaultPause is used when a dependency is unavailable but didn't say for how long.
+const defaultPause = 5 * time.Second
+
+// ErrStopped is returned by Start when Stop has already been called.
+var ErrStopped = errors.New("consumer stopped")
+
 func NewConsumer(
 	cfg *config.RabbitMqConsumerSettings,
 	client *rabbitmq.RabbitMqClient,
@@ -37,6 +74,12 @@ func NewConsumer(
 		return nil, fmt.Errorf("open channel: %w", err)
 	}
 
+	consumerTag := cfg.Consumer
+	if consumerTag == "" {
+		consumerTag = fmt.Sprintf("%s.%d.%d", cfg.Queue, os.Getpid(), time.Now().UnixNano())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+
 	c := &Consumer{
 		cfg:              cfg,
 		client:           client,
@@ -44,12 +87,33 @@ func NewConsumer(
 		log:              log,
 		stopCh:           make(chan struct{}),
 		messageProcessor: messageProcessor,
+		batchSize:        cfg.BatchSize,
+		batchTimeout:     time.Duration(cfg.BatchTimeoutSeconds) * time.Second,
+		pauseCh:          make(chan struct{}, 1),
+		workers:          newWorkers(cfg.Workers, cfg.BatchSize),
+		consumerTag:      consumerTag,
+		loopDone:         make(chan struct{}),
+		ctx:              ctx,
+		cancel:           cancel,
 	}
 
 	return c, nil
 }
 
+// Start runs the consume loop until Stop is called. started is set before stopCh is
+// checked, so a concurrent Stop either sees it and waits for loopDone, or Start sees
+// stopCh closed and returns without subscribing.
 func (c *Consumer) Start() error {
+	c.started.Store(true)
+	defer close(c.loopDone)
+
+	select {
+	case <-c.stopCh:
+		return fmt.Errorf("consumer for %s: %w", c.cfg.Queue, ErrStopped)
+	default:
+	}
+
+	c.startWorkers()
 	return c.runConsumeLoop()
 }
 
@@ -72,6 +136,7 @@ func (c *Consumer) runConsumeLoop() error {
 		}
 
 		reconnectAttempts++
+		metrics.ConsumerReconnectAttemptsTotal.WithLabelValues(c.cfg.Queue).Inc()
 		elapsed := time.Since(start)
 
 		if elapsed > reconnectTimeout {
@@ -110,7 +175,8 @@ func (c *Consumer) consume() error {
 		return err
 	}
 
-	if err := c.ch.Qos(c.cfg.BatchSize*2, 0, false); err != nil {
+	batchSize, _ := c.batchSettings()
+	if err := c.ch.Qos(c.prefetch(batchSize), 0, false); err != nil {
 		return fmt.Errorf("set qos: %w", err)
 	}
 
@@ -118,9 +184,15 @@ func (c *Consumer) consume() error {
 		return err
 	}
 
+	if c.cfg.RetrySettings.Enabled {
+		if err := c.ch.Confirm(false); err != nil {
+			return fmt.Errorf("confirm mode: %w", err)
+		}
+	}
+
 	msgs, err := c.ch.Consume(
 		c.cfg.Queue,
-		c.cfg.Consumer,
+		c.consumerTag,
 		false,
 		false,
 		false,
@@ -131,20 +203,34 @@ func (c *Consumer) consume() error {
 		return fmt.Errorf("consume: %w", err)
 	}
 
-	batchTimeout := time.Duration(c.cfg.BatchTimeoutSeconds) * time.Second
-	c.resetTimer(batchTimeout)
+	// Buffered: amqp091 sends on them while holding the channel lock, and the loop may be
+	// blocked in dispatch on a worker that needs that lock to ack.
+	notifyClose := c.ch.NotifyClose(make(chan *amqp.Error, 1))
+	notifyCancel := c.ch.NotifyCancel(make(chan string, 1))
 
-	notifyClose := c.ch.NotifyClose(make(chan *amqp.Error))
-	notifyCancel := c.ch.NotifyCancel(make(chan string))
+	c.running.Store(true)
+	defer c.running.Store(false)
 
 	c.log.Infow("consumer.started", "queue", c.cfg.Queue)
 
 	for {
+		// While paused, deliveries stay unacked in the channel buffer and are picked up
+		// again once the pause ends.
+		in := msgs
+		var resume <-chan time.Time
+		if d := c.pauseRemaining(); d > 0 {
+			in = nil
+			resume = time.After(d)
+		}
+
 		select {
 		case <-c.stopCh:
 			c.log.Infow("consumer.stopped", "queue", c.cfg.Queue)
 			return nil
-		case msg, ok := <-msgs:
+		case <-c.pauseCh:
+		case <-resume:
+			c.log.Infow("consumer.resumed", "queue", c.cfg.Queue)
+		case msg, ok := <-in:
 			if !ok {
 				c.log.Warnw("consumer.msg_channel_closed", "queue", c.cfg.Queue)
 				return fmt.Errorf("channel closed")
@@ -214,90 +300,334 @@ func (c *Consumer) declareQueues() error {
 		return fmt.Errorf("declare main queue: %w", err)
 	}
 
+	if c.cfg.RetrySettings.Enabled {
+		return c.declareRetryQueues()
+	}
 	return nil
 }
 
 func (c *Consumer) handleMessage(msg amqp.Delivery) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.buffer = append(c.buffer, consumer.MessageInfo{
-		DeliveryTag: msg.DeliveryTag,
-		Body:        msg.Body,
-		ReceivedAt:  time.Now(),
+	c.dispatch(consumer.MessageInfo{
+		DeliveryTag:  msg.DeliveryTag,
+		RoutingKey:   originalRoutingKey(msg),
+		ContentType:  msg.ContentType,
+		Body:         msg.Body,
+		Headers:      msg.Headers,
+		ReceivedAt:   time.Now(),
+		Acknowledger: msg.Acknowledger,
 	})
+}
 
-	if len(c.buffer) >= c.cfg.BatchSize {
-		go c.processBatch("size_limit")
-	}
+func (c *Consumer) processBatch(worker int, batch []consumer.MessageInfo, trigger string) {
+	metrics.ConsumerBatchSize.WithLabelValues(c.cfg.Queue).Observe(float64(len(batch)))
+
+	ctx, span := c.startBatchSpan(c.ctx, batch, trigger)
+	span.SetAttributes(attribute.Int("worker", worker))
+	defer span.End()
+
+	c.log.Infow("consumer.process_started", "queue", c.cfg.Queue, "worker", worker, "count", len(batch), "trigger", trigger)
+
+	c.settle(ctx, batch)
 }
 
-func (c *Consumer) processBatch(trigger string) {
-	c.mu.Lock()
-	if len(c.buffer) == 0 {
-		c.mu.Unlock()
+// settle processes msgs and settles each message on its own. Rejected messages are split
+// in halves and processed again, so a poison message only takes itself to the DLQ.
+func (c *Consumer) settle(ctx context.Context, msgs []consumer.MessageInfo) {
+	processCtx, cancel := context.WithTimeout(ctx, time.Duration(c.cfg.ProcessTimeoutSeconds)*time.Second)
+	start := time.Now()
+	results, err := c.process(processCtx, msgs)
+	cancel()
+	metrics.ConsumerProcessingDuration.WithLabelValues(c.cfg.Queue).Observe(time.Since(start).Seconds())
+
+	// Stop cancelled the batch, so its failures say nothing about the messages. They go
+	// back to the queue as they are instead of using up a retry each.
+	if c.ctx.Err() != nil {
+		c.log.Warnw("consumer.process_cancelled", "queue", c.cfg.Queue, "err", err, "count", len(msgs))
+		for i, msg := range msgs {
+			if results[i] == consumer.MESSAGE_RESULT_ACK {
+				c.settleMessage(msg, results[i], nil)
+				continue
+			}
+			c.requeue(msg)
+		}
 		return
 	}
 
-	batch := make([]consumer.MessageInfo, len(c.buffer))
-	copy(batch, c.buffer)
-	c.buffer = c.buffer[:0]
-	c.mu.Unlock()
+	if err != nil {
+		span := trace.SpanFromContext(ctx)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		var unavailable *consumer.UnavailableError
+		if errors.As(err, &unavailable) {
+			pause := unavailable.RetryAfter
+			if pause <= 0 {
+				pause = defaultPause
+			}
+			c.log.Warnw("consumer.dependency_unavailable", "queue", c.cfg.Queue, "err", err, "pause", pause)
+			c.pause(pause)
+			// Not a failure of the messages themselves, so it doesn't use up a retry.
+			for _, msg := range msgs {
+				c.requeue(msg)
+			}
+			return
+		}
+
+		c.log.Errorw("consumer.process_failed", "queue", c.cfg.Queue, "err", err, "count", len(msgs))
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	var rejected []consumer.MessageInfo
+	for i, msg := range msgs {
+		if results[i] == consumer.MESSAGE_RESULT_REJECT {
+			rejected = append(rejected, msg)
+			continue
+		}
+		c.settleMessage(msg, results[i], err)
+	}
 
-	c.log.Infow("consumer.process_started", "queue", c.cfg.Queue, "count", len(batch), "trigger", trigger)
+	switch {
+	case len(rejected) == 1:
+		c.log.Warnw("consumer.message_dead_lettered",
+			"queue", c.cfg.Queue,
+			"routing_key", rejected[0].RoutingKey,
+			"delivery_tag", rejected[0].DeliveryTag)
+		c.settleMessage(rejected[0], consumer.MESSAGE_RESULT_REJECT, err)
+	case len(rejected) > 1:
+		mid := len(rejected) / 2
+		c.log.Infow("consumer.bisect", "queue", c.cfg.Queue, "count", len(rejected))
+		c.settle(ctx, rejected[:mid])
+		c.settle(ctx, rejected[mid:])
+	}
+}
 
-	requeue, err := c.messageProcessor.ProcessMessage(ctx, batch)
+func (c *Consumer) process(ctx context.Context, msgs []consumer.MessageInfo) ([]consumer.MessageResult, error) {
+	if p, ok := c.messageProcessor.(consumer.MessageResultsProcessor); ok {
+		results, err := p.ProcessMessages(ctx, msgs)
+		if len(results) == len(msgs) {
+			return results, err
+		}
+		c.log.Errorw("consumer.results_mismatch", "queue", c.cfg.Queue, "count", len(msgs), "results", len(results))
+		if err == nil {
+			err = fmt.Errorf("processor returned %d results for %d messages", len(results), len(msgs))
+		}
+		return consumer.Results(len(msgs), consumer.MESSAGE_RESULT_REJECT), err
+	}
+
+	requeue, err := c.messageProcessor.ProcessMessage(ctx, msgs)
+	switch {
+	case err == nil:
+		return consumer.Results(len(msgs), consumer.MESSAGE_RESULT_ACK), nil
+	case requeue:
+		return consumer.Results(len(msgs), consumer.MESSAGE_RESULT_REQUEUE), err
+	default:
+		return consumer.Results(len(msgs), consumer.MESSAGE_RESULT_REJECT), err
+	}
+}
+
+// Messages are settled one by one: after a bisect the tags of a batch are no longer
+// contiguous, and a multiple ack would also settle messages of a batch still in flight.
+func (c *Consumer) settleMessage(msg consumer.MessageInfo, result consumer.MessageResult, cause error) {
+	var (
+		err   error
+		label string
+	)
+	switch {
+	case result == consumer.MESSAGE_RESULT_ACK:
+		err, label = c.ack(msg), "ack"
+	case result == consumer.MESSAGE_RESULT_REQUEUE && c.cfg.RetrySettings.Enabled:
+		reason := "unknown"
+		if cause != nil {
+			reason = cause.Error()
+		}
+		label, err = c.retry(msg, reason)
+	case result == consumer.MESSAGE_RESULT_REQUEUE:
+		err, label = c.nack(msg, true), "requeue"
+	default:
+		err, label = c.nack(msg, false), "dead_letter"
+	}
 	if err != nil {
-		c.log.Errorw("consumer.process_failed", "queue", c.cfg.Queue, "err", err, "need_requeue", requeue)
-		last := batch[len(batch)-1]
-		c.nack(last.DeliveryTag, true, false)
+		c.log.Errorw("consumer.settle_failed", "queue", c.cfg.Queue, "result", label, "delivery_tag", msg.DeliveryTag, "err", err)
 		return
 	}
+	metrics.ConsumerMessagesTotal.WithLabelValues(c.cfg.Queue, label).Inc()
+}
 
-	last := batch[len(batch)-1]
-	if err := c.ack(last.DeliveryTag, true); err != nil {
-		c.log.Errorw("consumer.ack_failed", "queue", c.cfg.Queue, "err", err)
+func (c *Consumer) requeue(msg consumer.MessageInfo) {
+	if err := c.nack(msg, true); err != nil {
+		c.log.Errorw("consumer.settle_failed", "queue", c.cfg.Queue, "result", "requeue", "delivery_tag", msg.DeliveryTag, "err", err)
+		return
 	}
+	metrics.ConsumerMessagesTotal.WithLabelValues(c.cfg.Queue, "requeue").Inc()
 }
 
-func (c *Consumer) ack(tag uint64, multiple bool) error {
-	if err := c.ensureChannel(); err != nil {
-		return err
+// A batch mixes messages from different traces, so the span links to every producer.
+// A single-message batch continues the producer's trace directly.
+func (c *Consumer) startBatchSpan(ctx context.Context, batch []consumer.MessageInfo, trigger string) (context.Context, trace.Span) {
+	links := make([]trace.Link, 0, len(batch))
+	for _, msg := range batch {
+		sc := trace.SpanContextFromContext(tracing.ExtractAmqpHeaders(context.Background(), msg.Headers))
+		if sc.IsValid() {
+			links = append(links, trace.Link{SpanContext: sc})
+		}
+	}
+	if len(batch) == 1 {
+		ctx = tracing.ExtractAmqpHeaders(ctx, batch[0].Headers)
 	}
-	return c.ch.Ack(tag, multiple)
+
+	return tracing.Tracer().Start(ctx, "consumer.process_batch",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithLinks(links...),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", c.cfg.Queue),
+			attribute.Int("messaging.batch.message_count", len(batch)),
+			attribute.String("trigger", trigger),
+		),
+	)
 }
 
-func (c *Consumer) nack(tag uint64, multiple, requeue bool) error {
-	if err := c.ensureChannel(); err != nil {
-		return err
-	}
-	return c.ch.Nack(tag, multiple, requeue)
+// Messages delivered before a reconnect can't be settled any more: their channel is
+// closed and the broker redelivers them on the new one.
+func (c *Consumer) ack(msg consumer.MessageInfo) error {
+	return msg.Acknowledger.Ack(msg.DeliveryTag, false)
+}
+
+func (c *Consumer) nack(msg consumer.MessageInfo, requeue bool) error {
+	return msg.Acknowledger.Nack(msg.DeliveryTag, false, requeue)
 }
 
-func (c *Consumer) Stop() {
-	close(c.stopCh)
-	if c.timer != nil {
-		c.timer.Stop()
+// Ping reports whether the consume loop is currently receiving from the queue.
+// It is false while the consumer reconnects.
+func (c *Consumer) Ping() error {
+	if !c.running.Load() {
+		return fmt.Errorf("consumer for %s is not running", c.cfg.Queue)
 	}
+	return c.client.Ping()
+}
+
+// DrainTimeout is how long Stop should be given to settle buffered messages.
+func (c *Consumer) DrainTimeout() time.Duration {
+	return time.Duration(c.cfg.DrainTimeoutSeconds) * time.Second
+}
 
-	time.Sleep(500 * time.Millisecond)
+// Stop cancels the subscription, lets every worker process and settle what it has
+// buffered, then closes the channel. Batches still running when ctx expires are
+// cancelled and requeue what they didn't finish without counting a retry; whatever is
+// left unsettled when the channel closes is redelivered by the broker.
+func (c *Consumer) Stop(ctx context.Context) error {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+	c.log.Infow("consumer.draining", "queue", c.cfg.Queue, "buffered", c.buffered.Load())
 
+	err := c.drain(ctx)
+	if err != nil {
+		c.cancel()
+		c.log.Warnw("consumer.drain_incomplete", "queue", c.cfg.Queue, "buffered", c.buffered.Load(), "err", err)
+	} else {
+		c.log.Infow("consumer.drained", "queue", c.cfg.Queue)
+	}
+
+	c.reconnectMu.Lock()
 	if c.ch != nil && !c.ch.IsClosed() {
 		c.ch.Close()
 	}
+	c.reconnectMu.Unlock()
+	c.cancel()
+	return err
 }
 
-func (c *Consumer) resetTimer(d time.Duration) {
-	if c.timer != nil {
-		c.timer.Stop()
+func (c *Consumer) drain(ctx context.Context) error {
+	if !c.started.Load() {
+		return nil
+	}
+
+	c.reconnectMu.Lock()
+	if c.ch != nil && !c.ch.IsClosed() {
+		if err := c.ch.Cancel(c.consumerTag, false); err != nil {
+			c.log.Warnw("consumer.cancel_failed", "queue", c.cfg.Queue, "err", err)
+		}
+	}
+	c.reconnectMu.Unlock()
+
+	// Only the consume loop dispatches to the workers, so their queues can be closed once
+	// it has returned.
+	select {
+	case <-c.loopDone:
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	c.timer = time.AfterFunc(d, func() {
-		c.processBatch("timeout")
-		c.resetTimer(d)
+	// Stop may be called again, e.g. to retry after a drain ran out of time.
+	c.closeOnce.Do(func() {
+		for _, w := range c.workers {
+			close(w.in)
+		}
 	})
+
+	drained := make(chan struct{})
+	go func() {
+		c.workersWg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Consumer) pause(d time.Duration) {
+	c.mu.Lock()
+	if until := time.Now().Add(d); until.After(c.pausedUntil) {
+		c.pausedUntil = until
+	}
+	c.mu.Unlock()
+
+	select {
+	case c.pauseCh <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Consumer) pauseRemaining() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Until(c.pausedUntil)
+}
+
+func (c *Consumer) batchSettings() (int, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.batchSize, c.batchTimeout
+}
+
+// UpdateBatchSettings applies new batch limits without restarting the consumer. Prefetch
+// is raised on a best-effort basis and otherwise picked up on the next reconnect.
+func (c *Consumer) UpdateBatchSettings(batchSize, batchTimeoutSeconds int) {
+	c.mu.Lock()
+	c.batchSize = batchSize
+	c.batchTimeout = time.Duration(batchTimeoutSeconds) * time.Second
+	c.mu.Unlock()
+
+	c.reconnectMu.Lock()
+	ch := c.ch
+	c.reconnectMu.Unlock()
+	if ch != nil && !ch.IsClosed() {
+		if err := ch.Qos(c.prefetch(batchSize), 0, false); err != nil {
+			c.log.Warnw("consumer.update_qos_failed", "queue", c.cfg.Queue, "err", err)
+		}
+	}
+
+	for _, w := range c.workers {
+		select {
+		case w.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Every worker may hold a full batch while the next one is being delivered.
+func (c *Consumer) prefetch(batchSize int) int {
+	return batchSize * len(c.workers) * 2
 }
 
 func (c *Consumer) ensureChannel() error {
@@ -0,0 +1,270 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestConsumer(cfg config.RabbitMqConsumerSettings, processor consumer.MessageProcessor) *Consumer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Consumer{
+		cfg:              &cfg,
+		log:              zap.NewNop().Sugar(),
+		stopCh:           make(chan struct{}),
+		messageProcessor: processor,
+		pauseCh:          make(chan struct{}, 1),
+		workers:          newWorkers(cfg.Workers, cfg.BatchSize),
+		loopDone:         make(chan struct{}),
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+}
+
+func TestStartAfterStop(t *testing.T) {
+	c := newTestConsumer(config.RabbitMqConsumerSettings{Queue: "orders"}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.Stop(ctx); err != nil {
+		t.Fatalf("Stop = %v", err)
+	}
+	if err := c.Start(); !errors.Is(err, ErrStopped) {
+		t.Fatalf("Start after Stop = %v, want ErrStopped", err)
+	}
+	select {
+	case <-c.loopDone:
+	default:
+		t.Error("loopDone is not closed")
+	}
+}
+
+func TestStopTwice(t *testing.T) {
+	c := newTestConsumer(config.RabbitMqConsumerSettings{Queue: "orders", Workers: 2, BatchSize: 1, BatchTimeoutSeconds: 1}, batchProcessor{})
+	// What Start does before the consume loop, which needs a broker.
+	c.started.Store(true)
+	c.startWorkers()
+	close(c.loopDone)
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		if err := c.Stop(ctx); err != nil {
+			t.Errorf("Stop #%d = %v", i+1, err)
+		}
+		cancel()
+	}
+}
+
+type settlement struct {
+	ack     bool
+	requeue bool
+}
+
+// fakeAcknowledger records how each delivery tag was settled.
+type fakeAcknowledger struct {
+	mu      sync.Mutex
+	settled map[uint64][]settlement
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.settled[tag] = append(a.settled[tag], settlement{ack: true})
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.settled[tag] = append(a.settled[tag], settlement{requeue: requeue})
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// poisonProcessor rejects every batch that contains one of the poison tags.
+type poisonProcessor struct {
+	poison  []uint64
+	batches [][]uint64
+}
+
+func (p *poisonProcessor) ProcessMessage(ctx context.Context, batch []consumer.MessageInfo) (bool, error) {
+	panic("ProcessMessages is preferred")
+}
+
+func (p *poisonProcessor) ProcessMessages(ctx context.Context, batch []consumer.MessageInfo) ([]consumer.MessageResult, error) {
+	var tags []uint64
+	for _, msg := range batch {
+		tags = append(tags, msg.DeliveryTag)
+	}
+	p.batches = append(p.batches, tags)
+
+	for _, tag := range tags {
+		if slices.Contains(p.poison, tag) {
+			return consumer.Results(len(batch), consumer.MESSAGE_RESULT_REJECT), errors.New("poison")
+		}
+	}
+	return consumer.Results(len(batch), consumer.MESSAGE_RESULT_ACK), nil
+}
+
+func testMessages(ack *fakeAcknowledger, n int) []consumer.MessageInfo {
+	msgs := make([]consumer.MessageInfo, n)
+	for i := range msgs {
+		msgs[i] = consumer.MessageInfo{DeliveryTag: uint64(i + 1), Acknowledger: ack}
+	}
+	return msgs
+}
+
+func TestSettleBisectsPoisonMessages(t *testing.T) {
+	tests := []struct {
+		name        string
+		count       int
+		poison      []uint64
+		wantBatches int
+	}{
+		{"no poison", 4, nil, 1},
+		{"single message", 1, []uint64{1}, 1},
+		{"one poison message", 4, []uint64{3}, 5},
+		{"two poison messages", 4, []uint64{1, 4}, 7},
+		{"every message poison", 3, []uint64{1, 2, 3}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &poisonProcessor{poison: tt.poison}
+			c := newTestConsumer(config.RabbitMqConsumerSettings{Queue: "orders", ProcessTimeoutSeconds: 1}, processor)
+			ack := &fakeAcknowledger{settled: make(map[uint64][]settlement)}
+
+			c.settle(context.Background(), testMessages(ack, tt.count))
+
+			if len(processor.batches) != tt.wantBatches {
+				t.Errorf("batches = %v, want %d", processor.batches, tt.wantBatches)
+			}
+			for tag := uint64(1); tag <= uint64(tt.count); tag++ {
+				want := settlement{ack: !slices.Contains(tt.poison, tag)}
+				if got := ack.settled[tag]; len(got) != 1 || got[0] != want {
+					t.Errorf("tag %d settled %+v, want once as %+v", tag, got, want)
+				}
+			}
+		})
+	}
+}
+
+type batchProcessor struct {
+	requeue bool
+	err     error
+}
+
+func (p batchProcessor) ProcessMessage(ctx context.Context, batch []consumer.MessageInfo) (bool, error) {
+	return p.requeue, p.err
+}
+
+type mismatchProcessor struct{}
+
+func (mismatchProcessor) ProcessMessage(ctx context.Context, batch []consumer.MessageInfo) (bool, error) {
+	panic("ProcessMessages is preferred")
+}
+
+func (mismatchProcessor) ProcessMessages(ctx context.Context, batch []consumer.MessageInfo) ([]consumer.MessageResult, error) {
+	return []consumer.MessageResult{consumer.MESSAGE_RESULT_ACK}, nil
+}
+
+func TestProcessResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		processor consumer.MessageProcessor
+		want      consumer.MessageResult
+		wantErr   bool
+	}{
+		{"success", batchProcessor{}, consumer.MESSAGE_RESULT_ACK, false},
+		{"requeue", batchProcessor{requeue: true, err: errors.New("down")}, consumer.MESSAGE_RESULT_REQUEUE, true},
+		{"failure", batchProcessor{err: errors.New("bad")}, consumer.MESSAGE_RESULT_REJECT, true},
+		{"result count mismatch", mismatchProcessor{}, consumer.MESSAGE_RESULT_REJECT, true},
+	}
+	for _, tt := range tests {
+		c := newTestConsumer(config.RabbitMqConsumerSettings{Queue: "orders"}, tt.processor)
+		results, err := c.process(context.Background(), make([]consumer.MessageInfo, 3))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v", tt.name, err)
+		}
+		if !slices.Equal(results, consumer.Results(3, tt.want)) {
+			t.Errorf("%s: results = %v, want all %v", tt.name, results, tt.want)
+		}
+	}
+}
+
+func TestSettleRequeuesWhenUnavailable(t *testing.T) {
+	processor := batchProcessor{requeue: true, err: &consumer.UnavailableError{RetryAfter: time.Minute, Err: errors.New("db down")}}
+	c := newTestConsumer(config.RabbitMqConsumerSettings{Queue: "orders", ProcessTimeoutSeconds: 1}, processor)
+	ack := &fakeAcknowledger{settled: make(map[uint64][]settlement)}
+
+	c.settle(context.Background(), testMessages(ack, 2))
+
+	for tag := uint64(1); tag <= 2; tag++ {
+		if got := ack.settled[tag]; len(got) != 1 || got[0] != (settlement{requeue: true}) {
+			t.Errorf("tag %d settled %+v, want requeued once", tag, got)
+		}
+	}
+	if d := c.pauseRemaining(); d <= 0 || d > time.Minute {
+		t.Errorf("pause = %v, want up to a minute", d)
+	}
+}
+
+// blockingProcessor finishes the first message, then waits for the batch to be cancelled
+// and asks to retry the rest, the way a cancelled gRPC call does.
+type blockingProcessor struct {
+	started chan struct{}
+}
+
+func (p blockingProcessor) ProcessMessage(ctx context.Context, batch []consumer.MessageInfo) (bool, error) {
+	panic("ProcessMessages is preferred")
+}
+
+func (p blockingProcessor) ProcessMessages(ctx context.Context, batch []consumer.MessageInfo) ([]consumer.MessageResult, error) {
+	close(p.started)
+	<-ctx.Done()
+	results := consumer.Results(len(batch), consumer.MESSAGE_RESULT_REQUEUE)
+	results[0] = consumer.MESSAGE_RESULT_ACK
+	return results, ctx.Err()
+}
+
+func TestSettleCancelledByStopDoesNotRetry(t *testing.T) {
+	processor := blockingProcessor{started: make(chan struct{})}
+	c := newTestConsumer(config.RabbitMqConsumerSettings{
+		Queue:                 "orders",
+		ProcessTimeoutSeconds: 10,
+		RetrySettings:         config.RabbitMqRetrySettings{Enabled: true, MaxAttempts: 3, DelaysSeconds: []int{1}},
+	}, processor)
+	core, logs := observer.New(zap.DebugLevel)
+	c.log = zap.New(core).Sugar()
+	ack := &fakeAcknowledger{settled: make(map[uint64][]settlement)}
+
+	go func() {
+		<-processor.started
+		// What Stop does once the drain timeout passes.
+		c.cancel()
+	}()
+	c.settle(c.ctx, testMessages(ack, 3))
+
+	if got := ack.settled[1]; len(got) != 1 || got[0] != (settlement{ack: true}) {
+		t.Errorf("tag 1 settled %+v, want acked once", got)
+	}
+	for tag := uint64(2); tag <= 3; tag++ {
+		if got := ack.settled[tag]; len(got) != 1 || got[0] != (settlement{requeue: true}) {
+			t.Errorf("tag %d settled %+v, want requeued once", tag, got)
+		}
+	}
+	// The test consumer has no channel, so a retry publish would fail and be logged.
+	if n := logs.FilterMessage("consumer.settle_failed").Len(); n != 0 {
+		t.Errorf("%d messages went through retry", n)
+	}
+}
@@ -0,0 +1,187 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	RETRY_COUNT_HEADER          = "x-retry-count"
+	RETRY_REASON_HEADER         = "x-retry-reason"
+	ORIGINAL_ROUTING_KEY_HEADER = "x-original-routing-key"
+	// DEAD_LETTER_REASON_HEADER marks messages this consumer moved to the DLQ itself, which
+	// therefore carry no x-death entry for the consumer queue.
+	DEAD_LETTER_REASON_HEADER = "x-dead-letter-reason"
+
+	DEAD_LETTER_REASON_RETRIES_EXHAUSTED = "retries_exhausted"
+
+	maxRetryReasonLength = 512
+	publishTimeout       = 5 * time.Second
+)
+
+func (c *Consumer) retryExchange() string {
+	if c.cfg.RetrySettings.Exchange != "" {
+		return c.cfg.RetrySettings.Exchange
+	}
+	return c.cfg.Queue + ".retry"
+}
+
+func (c *Consumer) delayQueue(delaySeconds int) string {
+	return fmt.Sprintf("%s.retry.%ds", c.cfg.Queue, delaySeconds)
+}
+
+// declareRetryQueues sets up one TTL queue per delay. Expired messages are dead-lettered
+// through the default exchange straight back into the consumer queue.
+func (c *Consumer) declareRetryQueues() error {
+	exchange := c.retryExchange()
+	if err := c.ch.ExchangeDeclare(exchange, "direct", true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare retry exchange: %w", err)
+	}
+
+	for _, d := range c.cfg.RetrySettings.DelaysSeconds {
+		queue := c.delayQueue(d)
+		args := amqp.Table{
+			"x-message-ttl":             int64(d) * 1000,
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": c.cfg.Queue,
+		}
+		if _, err := c.ch.QueueDeclare(queue, true, false, false, false, args); err != nil {
+			return fmt.Errorf("declare retry queue %s: %w", queue, err)
+		}
+		if err := c.ch.QueueBind(queue, queue, exchange, false, nil); err != nil {
+			return fmt.Errorf("bind retry queue %s: %w", queue, err)
+		}
+	}
+	return nil
+}
+
+// retry moves a message to the delay queue of its next attempt, or to the DLQ once
+// MaxAttempts is used up, and acks the original. It returns the metric result.
+func (c *Consumer) retry(msg consumer.MessageInfo, reason string) (string, error) {
+	attempt := retryCount(msg.Headers, c.cfg.Queue) + 1
+
+	headers := make(amqp.Table, len(msg.Headers)+3)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[RETRY_COUNT_HEADER] = int32(attempt)
+	headers[RETRY_REASON_HEADER] = truncate(reason, maxRetryReasonLength)
+	headers[ORIGINAL_ROUTING_KEY_HEADER] = msg.RoutingKey
+
+	exchange, routingKey, result := c.cfg.DeadLetterSettings.Dlx, c.cfg.DeadLetterSettings.RoutingKey, "dead_letter"
+	if attempt <= c.cfg.RetrySettings.MaxAttempts {
+		delays := c.cfg.RetrySettings.DelaysSeconds
+		exchange, routingKey, result = c.retryExchange(), c.delayQueue(delays[min(attempt, len(delays))-1]), "retry"
+	} else {
+		headers[DEAD_LETTER_REASON_HEADER] = DEAD_LETTER_REASON_RETRIES_EXHAUSTED
+	}
+
+	if err := c.publish(exchange, routingKey, msg, headers); err != nil {
+		// Hand the message back to the broker rather than leave it unacked. It comes back
+		// with its original headers, so this attempt is not counted.
+		if nackErr := c.nack(msg, true); nackErr != nil {
+			return result, errors.Join(err, nackErr)
+		}
+		return result, err
+	}
+	if result == "dead_letter" {
+		c.log.Warnw("consumer.retries_exhausted",
+			"queue", c.cfg.Queue,
+			"routing_key", msg.RoutingKey,
+			"attempts", attempt-1,
+			"reason", reason)
+	}
+	return result, c.ack(msg)
+}
+
+// publish waits for the broker confirm, so the original is only acked once the copy is
+// safely stored. It uses the channel the consume loop set up in confirm mode and never
+// reopens it: the original could not be acked on a new channel anyway.
+func (c *Consumer) publish(exchange, routingKey string, msg consumer.MessageInfo, headers amqp.Table) error {
+	c.reconnectMu.Lock()
+	ch := c.ch
+	c.reconnectMu.Unlock()
+	if ch == nil || ch.IsClosed() {
+		return fmt.Errorf("publish to %s: channel closed", exchange)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false, amqp.Publishing{
+		ContentType: msg.ContentType,
+		Headers:     headers,
+		Timestamp:   time.Now(),
+		Body:        msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("publish to %s: %w", exchange, err)
+	}
+	if confirm == nil {
+		return nil
+	}
+	ok, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("wait confirm: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("publish to %s: nacked by broker", exchange)
+	}
+	return nil
+}
+
+// retryCount prefers our own header and falls back to the x-death entries the broker
+// adds every time a message expires from one of the delay queues.
+func retryCount(headers amqp.Table, queue string) int {
+	switch n := headers[RETRY_COUNT_HEADER].(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	}
+
+	deaths, _ := headers["x-death"].([]interface{})
+	count := 0
+	for _, d := range deaths {
+		death, ok := d.(amqp.Table)
+		if !ok {
+			continue
+		}
+		if q, _ := death["queue"].(string); !strings.HasPrefix(q, queue+".retry.") {
+			continue
+		}
+		if n, ok := death["count"].(int64); ok {
+			count += int(n)
+		}
+	}
+	return count
+}
+
+// originalRoutingKey undoes the routing key rewrite of the trip through a delay queue,
+// which delivers the message back under the consumer queue's name.
+func originalRoutingKey(msg amqp.Delivery) string {
+	if key, ok := msg.Headers[ORIGINAL_ROUTING_KEY_HEADER].(string); ok && key != "" {
+		return key
+	}
+	return msg.RoutingKey
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	// Cut on a rune boundary so the header stays valid UTF-8.
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
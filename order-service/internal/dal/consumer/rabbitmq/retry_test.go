@@ -0,0 +1,151 @@
+package consumer
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func death(queue string, count int64) amqp.Table {
+	return amqp.Table{"queue": queue, "count": count, "reason": "expired"}
+}
+
+func TestRetryCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers amqp.Table
+		want    int
+	}{
+		{"no headers", nil, 0},
+		{"int32 header", amqp.Table{RETRY_COUNT_HEADER: int32(2)}, 2},
+		{"int64 header", amqp.Table{RETRY_COUNT_HEADER: int64(3)}, 3},
+		{"int header", amqp.Table{RETRY_COUNT_HEADER: 4}, 4},
+		{"header wins over x-death", amqp.Table{
+			RETRY_COUNT_HEADER: int32(1),
+			"x-death":          []interface{}{death("orders.retry.5s", 3)},
+		}, 1},
+		{"x-death of delay queues", amqp.Table{
+			"x-death": []interface{}{death("orders.retry.5s", 2), death("orders.retry.30s", 1)},
+		}, 3},
+		{"x-death of other queues", amqp.Table{
+			"x-death": []interface{}{death("orders", 1), death("payments.retry.5s", 4)},
+		}, 0},
+		{"malformed x-death", amqp.Table{
+			"x-death": []interface{}{"orders.retry.5s", amqp.Table{"queue": "orders.retry.5s", "count": "2"}},
+		}, 0},
+		{"unsupported header type", amqp.Table{RETRY_COUNT_HEADER: "2"}, 0},
+	}
+	for _, tt := range tests {
+		if got := retryCount(tt.headers, "orders"); got != tt.want {
+			t.Errorf("%s: retryCount = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRetryQueueNames(t *testing.T) {
+	tests := []struct {
+		exchange     string
+		wantExchange string
+	}{
+		{"", "orders.retry"},
+		{"retries", "retries"},
+	}
+	for _, tt := range tests {
+		cfg := config.RabbitMqConsumerSettings{Queue: "orders"}
+		cfg.RetrySettings.Exchange = tt.exchange
+		c := newTestConsumer(cfg, nil)
+
+		if got := c.retryExchange(); got != tt.wantExchange {
+			t.Errorf("retryExchange with %q = %q, want %q", tt.exchange, got, tt.wantExchange)
+		}
+		if got := c.delayQueue(30); got != "orders.retry.30s" {
+			t.Errorf("delayQueue(30) = %q", got)
+		}
+	}
+
+	// retryCount only counts deaths in queues named by delayQueue.
+	c := newTestConsumer(config.RabbitMqConsumerSettings{Queue: "orders"}, nil)
+	headers := amqp.Table{"x-death": []interface{}{death(c.delayQueue(5), 1)}}
+	if got := retryCount(headers, "orders"); got != 1 {
+		t.Errorf("retryCount for a delay queue death = %d, want 1", got)
+	}
+}
+
+func TestOriginalRoutingKey(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  amqp.Delivery
+		want string
+	}{
+		{"first delivery", amqp.Delivery{RoutingKey: "order.created"}, "order.created"},
+		{"after a delay queue", amqp.Delivery{
+			RoutingKey: "orders",
+			Headers:    amqp.Table{ORIGINAL_ROUTING_KEY_HEADER: "order.created"},
+		}, "order.created"},
+		{"empty header", amqp.Delivery{
+			RoutingKey: "orders",
+			Headers:    amqp.Table{ORIGINAL_ROUTING_KEY_HEADER: ""},
+		}, "orders"},
+	}
+	for _, tt := range tests {
+		if got := originalRoutingKey(tt.msg); got != tt.want {
+			t.Errorf("%s: originalRoutingKey = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	long := strings.Repeat("x", maxRetryReasonLength+1)
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"reason", 6},
+		{long[:maxRetryReasonLength], maxRetryReasonLength},
+		{long, maxRetryReasonLength},
+		{long[:maxRetryReasonLength-1] + "é", maxRetryReasonLength - 1},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.s, maxRetryReasonLength); len(got) != tt.want || !strings.HasPrefix(tt.s, got) || !utf8.ValidString(got) {
+			t.Errorf("truncate(%d chars) = %d chars, want %d", len(tt.s), len(got), tt.want)
+		}
+	}
+}
+
+func TestRetryTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryCount int32
+		want       string
+	}{
+		{"first retry", 0, "retry"},
+		{"last retry", 2, "retry"},
+		{"retries used up", 3, "dead_letter"},
+	}
+	for _, tt := range tests {
+		cfg := config.RabbitMqConsumerSettings{Queue: "orders"}
+		cfg.RetrySettings.Enabled = true
+		cfg.RetrySettings.MaxAttempts = 3
+		cfg.RetrySettings.DelaysSeconds = []int{5, 30}
+		c := newTestConsumer(cfg, nil)
+		ack := &fakeAcknowledger{settled: make(map[uint64][]settlement)}
+		msg := testMessages(ack, 1)[0]
+		msg.Headers = amqp.Table{RETRY_COUNT_HEADER: tt.retryCount}
+
+		// Without a channel the publish fails, so the original goes back to the queue.
+		result, err := c.retry(msg, "boom")
+		if result != tt.want {
+			t.Errorf("%s: result = %q, want %q", tt.name, result, tt.want)
+		}
+		if err == nil {
+			t.Errorf("%s: publish without a channel succeeded", tt.name)
+		}
+		if got := ack.settled[1]; len(got) != 1 || got[0] != (settlement{requeue: true}) {
+			t.Errorf("%s: settled %+v, want requeued once", tt.name, got)
+		}
+	}
+}
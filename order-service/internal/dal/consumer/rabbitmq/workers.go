@@ -0,0 +1,117 @@
+package consumer
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/metrics"
+)
+
+type worker struct {
+	id   int
+	in   chan consumer.MessageInfo
+	wake chan struct{}
+}
+
+func newWorkers(n, batchSize int) []*worker {
+	workers := make([]*worker, max(n, 1))
+	for i := range workers {
+		workers[i] = &worker{
+			id:   i,
+			in:   make(chan consumer.MessageInfo, batchSize),
+			wake: make(chan struct{}, 1),
+		}
+	}
+	return workers
+}
+
+func (c *Consumer) startWorkers() {
+	c.workersOnce.Do(func() {
+		for _, w := range c.workers {
+			c.workersWg.Add(1)
+			go c.runWorker(w)
+		}
+	})
+}
+
+// dispatch blocks while the worker's queue is full, which holds back the whole consume
+// loop; together with the prefetch limit that is the consumer's backpressure.
+func (c *Consumer) dispatch(msg consumer.MessageInfo) {
+	w := c.workerFor(msg)
+	select {
+	case w.in <- msg:
+		metrics.ConsumerBufferDepth.WithLabelValues(c.cfg.Queue).Set(float64(c.buffered.Add(1)))
+	case <-c.stopCh:
+	}
+}
+
+func (c *Consumer) workerFor(msg consumer.MessageInfo) *worker {
+	if len(c.workers) == 1 {
+		return c.workers[0]
+	}
+	if keyer, ok := c.messageProcessor.(consumer.PartitionKeyer); ok {
+		if key := keyer.PartitionKey(msg); key != "" {
+			h := fnv.New64a()
+			h.Write([]byte(key))
+			return c.workers[jumpHash(h.Sum64(), len(c.workers))]
+		}
+	}
+	return c.workers[c.nextWorker.Add(1)%uint64(len(c.workers))]
+}
+
+// runWorker batches and processes the messages of one partition. It handles one batch at
+// a time, so messages with the same key are processed in delivery order. When Stop closes
+// its queue, the worker processes what is left and exits.
+func (c *Consumer) runWorker(w *worker) {
+	defer c.workersWg.Done()
+
+	var buffer []consumer.MessageInfo
+	_, timeout := c.batchSettings()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	flush := func(trigger string) {
+		if len(buffer) > 0 {
+			c.processBatch(w.id, buffer, trigger)
+			metrics.ConsumerBufferDepth.WithLabelValues(c.cfg.Queue).Set(float64(c.buffered.Add(-int64(len(buffer)))))
+			buffer = nil
+		}
+		_, timeout := c.batchSettings()
+		timer.Reset(timeout)
+	}
+
+	for {
+		select {
+		case msg, ok := <-w.in:
+			if !ok {
+				flush("shutdown")
+				return
+			}
+			buffer = append(buffer, msg)
+			if size, _ := c.batchSettings(); len(buffer) >= size {
+				flush("size_limit")
+			}
+		case <-timer.C:
+			flush("timeout")
+		case <-w.wake:
+			if size, timeout := c.batchSettings(); len(buffer) >= size {
+				flush("size_limit")
+			} else {
+				timer.Reset(timeout)
+			}
+		}
+	}
+}
+
+// jumpHash is Lamping and Veach's jump consistent hash: when the number of workers
+// changes, only the keys that have to move to a new worker do.
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
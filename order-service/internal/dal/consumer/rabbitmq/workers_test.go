@@ -0,0 +1,98 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+
+	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer"
+)
+
+func TestJumpHash(t *testing.T) {
+	// Pinned so that a change to the hash, which would reorder in-flight keys across
+	// workers, doesn't go unnoticed.
+	tests := []struct {
+		key     uint64
+		buckets int
+		want    int
+	}{
+		{0, 1, 0},
+		{0, 10, 0},
+		{1, 10, 6},
+		{42, 10, 2},
+		{0xdeadbeef, 10, 5},
+		{0xdeadbeef, 1000, 285},
+	}
+	for _, tt := range tests {
+		if got := jumpHash(tt.key, tt.buckets); got != tt.want {
+			t.Errorf("jumpHash(%d, %d) = %d, want %d", tt.key, tt.buckets, got, tt.want)
+		}
+	}
+}
+
+func TestJumpHashRebalance(t *testing.T) {
+	const keys = 10000
+	for buckets := 1; buckets < 16; buckets++ {
+		moved := 0
+		for key := uint64(0); key < keys; key++ {
+			before, after := jumpHash(key, buckets), jumpHash(key, buckets+1)
+			if before < 0 || before >= buckets {
+				t.Fatalf("jumpHash(%d, %d) = %d out of range", key, buckets, before)
+			}
+			// A key either stays or moves to the new bucket.
+			if after != before {
+				if after != buckets {
+					t.Fatalf("key %d moved from %d to %d when adding bucket %d", key, before, after, buckets)
+				}
+				moved++
+			}
+		}
+		if want := keys / (buckets + 1); moved < want/2 || moved > want*2 {
+			t.Errorf("%d -> %d buckets moved %d keys, want about %d", buckets, buckets+1, moved, want)
+		}
+	}
+}
+
+type keyedProcessor struct{}
+
+func (keyedProcessor) ProcessMessage(ctx context.Context, batch []consumer.MessageInfo) (bool, error) {
+	return false, nil
+}
+
+func (keyedProcessor) PartitionKey(msg consumer.MessageInfo) string {
+	return msg.RoutingKey
+}
+
+func TestWorkerFor(t *testing.T) {
+	keyed := newTestConsumer(config.RabbitMqConsumerSettings{Queue: "orders", Workers: 4}, keyedProcessor{})
+	for _, key := range []string{"1", "2", "42", "order-7"} {
+		first := keyed.workerFor(consumer.MessageInfo{RoutingKey: key})
+		for i := 0; i < 10; i++ {
+			if w := keyed.workerFor(consumer.MessageInfo{RoutingKey: key}); w != first {
+				t.Fatalf("key %q went to workers %d and %d", key, first.id, w.id)
+			}
+		}
+	}
+
+	tests := []struct {
+		name      string
+		workers   int
+		processor consumer.MessageProcessor
+		key       string
+		want      int
+	}{
+		{"single worker", 1, keyedProcessor{}, "42", 1},
+		{"no partition keyer", 4, batchProcessor{}, "42", 4},
+		{"empty key", 4, keyedProcessor{}, "", 4},
+	}
+	for _, tt := range tests {
+		c := newTestConsumer(config.RabbitMqConsumerSettings{Queue: "orders", Workers: tt.workers}, tt.processor)
+		used := make(map[int]bool)
+		for i := 0; i < 8; i++ {
+			used[c.workerFor(consumer.MessageInfo{RoutingKey: tt.key}).id] = true
+		}
+		if len(used) != tt.want {
+			t.Errorf("%s: messages went to %d workers, want %d", tt.name, len(used), tt.want)
+		}
+	}
+}
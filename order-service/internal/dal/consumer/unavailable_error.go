@@ -0,0 +1,18 @@
+package consumer
+
+import "time"
+
+// UnavailableError tells the consumer that a dependency is down. Instead of dead-lettering
+// the batch, the consumer requeues it and stops taking messages for RetryAfter.
+type UnavailableError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *UnavailableError) Error() string {
+	return "dependency unavailable: " + e.Err.Error()
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
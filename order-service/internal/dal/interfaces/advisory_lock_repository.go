@@ -0,0 +1,14 @@
+package interfaces
+
+import "context"
+
+type AdvisoryLockRepository interface {
+	// TryXactLock takes a transaction-level lock, so it must run inside a transaction
+	// and is released on commit or rollback.
+	TryXactLock(ctx context.Context, key int64) (bool, error)
+	// TryLock takes a session-level lock on the (classID, objID) pair, which does not clash
+	// with single-key locks. It is held until Unlock or until the connection closes, so the
+	// unit of work must stay open in between.
+	TryLock(ctx context.Context, classID, objID int32) (bool, error)
+	Unlock(ctx context.Context, classID, objID int32) error
+}
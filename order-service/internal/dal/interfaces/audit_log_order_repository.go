@@ -8,4 +8,5 @@ import (
 
 type AuditLogOrderRepository interface {
 	BulkInsert(ctx context.Context, items []models.V1AuditLogOrderDal) ([]models.V1AuditLogOrderDal, error)
+	QueryCreatedOrderIDs(ctx context.Context, orderIDs []int64) ([]int64, error)
 }
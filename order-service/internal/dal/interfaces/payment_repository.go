@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+)
+
+type PaymentRepository interface {
+	BulkInsert(ctx context.Context, payments []models.V1PaymentDal) ([]models.V1PaymentDal, error)
+	BulkUpdate(ctx context.Context, payments []models.V1PaymentDal) ([]models.V1PaymentDal, error)
+	Query(ctx context.Context, query models.QueryPaymentsDalModel) ([]models.V1PaymentDal, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+)
+
+type ProductRepository interface {
+	BulkUpsert(ctx context.Context, products []models.V1ProductDal) ([]models.V1ProductDal, error)
+	Delete(ctx context.Context, ids []int64) (int64, error)
+	Query(ctx context.Context, query models.QueryProductsDalModel) ([]models.V1ProductDal, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
+)
+
+type Publisher interface {
+	PublishBatch(ctx context.Context, messages []messages.Message) error
+}
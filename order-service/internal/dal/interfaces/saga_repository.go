@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+)
+
+type SagaRepository interface {
+	// BulkInsert skips sagas for orders that already have one and returns only the inserted rows.
+	BulkInsert(ctx context.Context, sagas []models.V1SagaDal) ([]models.V1SagaDal, error)
+	BulkUpdate(ctx context.Context, sagas []models.V1SagaDal) ([]models.V1SagaDal, error)
+	Query(ctx context.Context, query models.QuerySagasDalModel) ([]models.V1SagaDal, error)
+}
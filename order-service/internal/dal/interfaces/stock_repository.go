@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+)
+
+type StockRepository interface {
+	BulkUpsert(ctx context.Context, stocks []models.V1StockDal) ([]models.V1StockDal, error)
+	Query(ctx context.Context, query models.QueryStocksDalModel) ([]models.V1StockDal, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+)
+
+type StockReservationRepository interface {
+	BulkInsert(ctx context.Context, reservations []models.V1StockReservationDal) ([]models.V1StockReservationDal, error)
+	Query(ctx context.Context, query models.QueryStockReservationsDalModel) ([]models.V1StockReservationDal, error)
+	UpdateStatus(ctx context.Context, ids []int64, status string, now time.Time) error
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// UnitOfWork is the transaction control services hold. Repositories created on the same
+// unit of work run their queries inside its transaction.
+type UnitOfWork interface {
+	BeginTransaction(ctx context.Context) (pgx.Tx, error)
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+	Close()
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+)
+
+type WebhookDeliveryRepository interface {
+	BulkInsert(ctx context.Context, deliveries []models.V1WebhookDeliveryDal) ([]models.V1WebhookDeliveryDal, error)
+	Query(ctx context.Context, query models.QueryWebhookDeliveriesDalModel) ([]models.V1WebhookDeliveryDal, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+)
+
+type WebhookSubscriptionRepository interface {
+	BulkInsert(ctx context.Context, subscriptions []models.V1WebhookSubscriptionDal) ([]models.V1WebhookSubscriptionDal, error)
+	BulkUpdate(ctx context.Context, subscriptions []models.V1WebhookSubscriptionDal) ([]models.V1WebhookSubscriptionDal, error)
+	Delete(ctx context.Context, ids []int64) (int64, error)
+	Query(ctx context.Context, query models.QueryWebhookSubscriptionsDalModel) ([]models.V1WebhookSubscriptionDal, error)
+	RegisterSuccess(ctx context.Context, id int64, now time.Time) error
+	RegisterFailure(ctx context.Context, id int64, disableAfter int, now time.Time) (models.V1WebhookSubscriptionDal, error)
+}
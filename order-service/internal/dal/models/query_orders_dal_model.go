@@ -1,8 +1,13 @@
 package models
 
+import "time"
+
 type QueryOrdersDalModel struct {
-	IDs         []int64
-	CustomerIDs []int64
-	Limit       int
-	Offset      int
+	IDs           []int64
+	CustomerIDs   []int64
+	Statuses      []string
+	UpdatedBefore time.Time
+	Limit         int
+	Offset        int
+	ForUpdate     bool
 }
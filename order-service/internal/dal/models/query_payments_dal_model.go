@@ -0,0 +1,12 @@
+package models
+
+type QueryPaymentsDalModel struct {
+	IDs                []int64
+	OrderIDs           []int64
+	Provider           string
+	ProviderPaymentIDs []string
+	Statuses           []string
+	ForUpdate          bool
+	Limit              int
+	Offset             int
+}
@@ -0,0 +1,7 @@
+package models
+
+type QueryProductsDalModel struct {
+	IDs    []int64
+	Limit  int
+	Offset int
+}
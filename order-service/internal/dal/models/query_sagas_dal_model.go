@@ -0,0 +1,9 @@
+package models
+
+type QuerySagasDalModel struct {
+	IDs      []int64
+	OrderIDs []int64
+	Statuses []string
+	Limit    int
+	Offset   int
+}
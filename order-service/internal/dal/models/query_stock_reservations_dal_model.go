@@ -0,0 +1,7 @@
+package models
+
+type QueryStockReservationsDalModel struct {
+	OrderIDs  []int64
+	Statuses  []string
+	ForUpdate bool
+}
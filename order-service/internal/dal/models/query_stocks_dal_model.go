@@ -0,0 +1,9 @@
+package models
+
+type QueryStocksDalModel struct {
+	ProductIDs   []int64
+	WarehouseIDs []int64
+	ForUpdate    bool
+	Limit        int
+	Offset       int
+}
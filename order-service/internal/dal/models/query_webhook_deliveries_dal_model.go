@@ -0,0 +1,10 @@
+package models
+
+type QueryWebhookDeliveriesDalModel struct {
+	SubscriptionIDs []int64
+	EventIDs        []string
+	OnlyFailed      bool
+	OnlySucceeded   bool
+	Limit           int
+	Offset          int
+}
@@ -0,0 +1,9 @@
+package models
+
+type QueryWebhookSubscriptionsDalModel struct {
+	IDs        []int64
+	EventTypes []string
+	OnlyActive bool
+	Limit      int
+	Offset     int
+}
@@ -10,6 +10,8 @@ type V1AuditLogOrderDal struct {
 	OrderStatus string    `db:"order_status"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
+	Actor       string    `db:"actor"`
+	TenantID    string    `db:"tenant_id"`
 }
 
 func (l V1AuditLogOrderDal) IsNull() bool { return false }
@@ -29,6 +31,10 @@ func (l V1AuditLogOrderDal) Index(i int) any {
 		return l.CreatedAt
 	case 6:
 		return l.UpdatedAt
+	case 7:
+		return l.Actor
+	case 8:
+		return l.TenantID
 	default:
 		return nil
 	}
@@ -11,6 +11,7 @@ type V1OrderDal struct {
 	Status          string    `db:"status"`
 	CreatedAt       time.Time `db:"created_at"`
 	UpdatedAt       time.Time `db:"updated_at"`
+	TenantID        string    `db:"tenant_id"`
 }
 
 func (o V1OrderDal) IsNull() bool { return false }
@@ -32,6 +33,8 @@ func (o V1OrderDal) Index(i int) any {
 		return o.UpdatedAt
 	case 7:
 		return o.Status
+	case 8:
+		return o.TenantID
 	default:
 		return nil
 	}
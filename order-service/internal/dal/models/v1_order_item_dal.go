@@ -13,6 +13,7 @@ type V1OrderItemDal struct {
 	PriceCurr    string    `db:"price_currency"`
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
+	TenantID     string    `db:"tenant_id"`
 }
 
 func (i V1OrderItemDal) IsNull() bool { return false }
@@ -38,6 +39,8 @@ func (i V1OrderItemDal) Index(idx int) any {
 		return i.CreatedAt
 	case 9:
 		return i.UpdatedAt
+	case 10:
+		return i.TenantID
 	default:
 		return nil
 	}
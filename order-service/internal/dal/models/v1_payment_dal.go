@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type V1PaymentDal struct {
+	ID                int64     `db:"id"`
+	OrderID           int64     `db:"order_id"`
+	Provider          string    `db:"provider"`
+	ProviderPaymentID string    `db:"provider_payment_id"`
+	AmountCents       int64     `db:"amount_cents"`
+	Currency          string    `db:"currency"`
+	Status            string    `db:"status"`
+	FailureReason     string    `db:"failure_reason"`
+	CreatedAt         time.Time `db:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at"`
+	TenantID          string    `db:"tenant_id"`
+}
+
+func (p V1PaymentDal) IsNull() bool { return false }
+func (p V1PaymentDal) Index(i int) any {
+	switch i {
+	case 0:
+		return p.ID
+	case 1:
+		return p.OrderID
+	case 2:
+		return p.Provider
+	case 3:
+		return p.ProviderPaymentID
+	case 4:
+		return p.AmountCents
+	case 5:
+		return p.Currency
+	case 6:
+		return p.Status
+	case 7:
+		return p.FailureReason
+	case 8:
+		return p.CreatedAt
+	case 9:
+		return p.UpdatedAt
+	case 10:
+		return p.TenantID
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type V1ProductDal struct {
+	ID            int64     `db:"id"`
+	Title         string    `db:"title"`
+	URL           string    `db:"url"`
+	PriceCents    int64     `db:"price_cents"`
+	PriceCurrency string    `db:"price_currency"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+func (p V1ProductDal) IsNull() bool { return false }
+func (p V1ProductDal) Index(i int) any {
+	switch i {
+	case 0:
+		return p.ID
+	case 1:
+		return p.Title
+	case 2:
+		return p.URL
+	case 3:
+		return p.PriceCents
+	case 4:
+		return p.PriceCurrency
+	case 5:
+		return p.CreatedAt
+	case 6:
+		return p.UpdatedAt
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type V1SagaDal struct {
+	ID          int64     `db:"id"`
+	OrderID     int64     `db:"order_id"`
+	Status      string    `db:"status"`
+	Steps       []string  `db:"steps"`
+	CurrentStep int       `db:"current_step"`
+	Data        string    `db:"data"`
+	Error       string    `db:"error"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (s V1SagaDal) IsNull() bool { return false }
+func (s V1SagaDal) Index(i int) any {
+	switch i {
+	case 0:
+		return s.ID
+	case 1:
+		return s.OrderID
+	case 2:
+		return s.Status
+	case 3:
+		return s.Steps
+	case 4:
+		return s.CurrentStep
+	case 5:
+		return s.Data
+	case 6:
+		return s.Error
+	case 7:
+		return s.CreatedAt
+	case 8:
+		return s.UpdatedAt
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type V1StockDal struct {
+	WarehouseID int64     `db:"warehouse_id"`
+	ProductID   int64     `db:"product_id"`
+	Quantity    int       `db:"quantity"`
+	Reserved    int       `db:"reserved"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (s V1StockDal) IsNull() bool { return false }
+func (s V1StockDal) Index(i int) any {
+	switch i {
+	case 0:
+		return s.WarehouseID
+	case 1:
+		return s.ProductID
+	case 2:
+		return s.Quantity
+	case 3:
+		return s.Reserved
+	case 4:
+		return s.CreatedAt
+	case 5:
+		return s.UpdatedAt
+	default:
+		return nil
+	}
+}
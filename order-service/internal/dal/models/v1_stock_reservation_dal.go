@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type V1StockReservationDal struct {
+	ID          int64     `db:"id"`
+	OrderID     int64     `db:"order_id"`
+	OrderItemID int64     `db:"order_item_id"`
+	WarehouseID int64     `db:"warehouse_id"`
+	ProductID   int64     `db:"product_id"`
+	Quantity    int       `db:"quantity"`
+	Status      string    `db:"status"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (r V1StockReservationDal) IsNull() bool { return false }
+func (r V1StockReservationDal) Index(i int) any {
+	switch i {
+	case 0:
+		return r.ID
+	case 1:
+		return r.OrderID
+	case 2:
+		return r.OrderItemID
+	case 3:
+		return r.WarehouseID
+	case 4:
+		return r.ProductID
+	case 5:
+		return r.Quantity
+	case 6:
+		return r.Status
+	case 7:
+		return r.CreatedAt
+	case 8:
+		return r.UpdatedAt
+	default:
+		return nil
+	}
+}
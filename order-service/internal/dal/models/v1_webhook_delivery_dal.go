@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type V1WebhookDeliveryDal struct {
+	ID             int64     `db:"id"`
+	SubscriptionID int64     `db:"subscription_id"`
+	EventType      string    `db:"event_type"`
+	EventID        string    `db:"event_id"`
+	Attempt        int       `db:"attempt"`
+	StatusCode     int       `db:"status_code"`
+	Success        bool      `db:"success"`
+	Error          string    `db:"error"`
+	DurationMs     int64     `db:"duration_ms"`
+	CreatedAt      time.Time `db:"created_at"`
+	TenantID       string    `db:"tenant_id"`
+}
+
+func (d V1WebhookDeliveryDal) IsNull() bool { return false }
+func (d V1WebhookDeliveryDal) Index(i int) any {
+	switch i {
+	case 0:
+		return d.ID
+	case 1:
+		return d.SubscriptionID
+	case 2:
+		return d.EventType
+	case 3:
+		return d.EventID
+	case 4:
+		return d.Attempt
+	case 5:
+		return d.StatusCode
+	case 6:
+		return d.Success
+	case 7:
+		return d.Error
+	case 8:
+		return d.DurationMs
+	case 9:
+		return d.CreatedAt
+	case 10:
+		return d.TenantID
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type V1WebhookSubscriptionDal struct {
+	ID                  int64      `db:"id"`
+	URL                 string     `db:"url"`
+	EventTypes          []string   `db:"event_types"`
+	CustomerIDs         []int64    `db:"customer_ids"`
+	Secret              string     `db:"secret"`
+	IsActive            bool       `db:"is_active"`
+	ConsecutiveFailures int        `db:"consecutive_failures"`
+	DisabledAt          *time.Time `db:"disabled_at"`
+	CreatedAt           time.Time  `db:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at"`
+	TenantID            string     `db:"tenant_id"`
+}
+
+func (s V1WebhookSubscriptionDal) IsNull() bool { return false }
+func (s V1WebhookSubscriptionDal) Index(i int) any {
+	switch i {
+	case 0:
+		return s.ID
+	case 1:
+		return s.URL
+	case 2:
+		return s.EventTypes
+	case 3:
+		return s.CustomerIDs
+	case 4:
+		return s.Secret
+	case 5:
+		return s.IsActive
+	case 6:
+		return s.ConsecutiveFailures
+	case 7:
+		return s.DisabledAt
+	case 8:
+		return s.CreatedAt
+	case 9:
+		return s.UpdatedAt
+	case 10:
+		return s.TenantID
+	default:
+		return nil
+	}
+}
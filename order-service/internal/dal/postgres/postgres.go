@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tracing"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -18,8 +19,15 @@ func NewPostgresClient(ctx context.Context, connString string) (*PostgresClient,
 		return nil, fmt.Errorf("parse config: %w", err)
 	}
 
+	cfg.ConnConfig.Tracer = tracing.PgxTracer{}
+
 	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
-		names := []string{"v1_order", "_v1_order", "v1_order_item", "_v1_order_item", "v1_audit_log_order", "_v1_audit_log_order"}
+		names := []string{
+			"v1_order", "_v1_order", "v1_order_item", "_v1_order_item", "v1_audit_log_order", "_v1_audit_log_order",
+			"v1_webhook_subscription", "_v1_webhook_subscription", "v1_webhook_delivery", "_v1_webhook_delivery",
+			"v1_stock", "_v1_stock", "v1_stock_reservation", "_v1_stock_reservation",
+			"v1_product", "_v1_product", "v1_saga", "_v1_saga", "v1_payment", "_v1_payment",
+		}
 		types, err := conn.LoadTypes(ctx, names)
 		if err != nil {
 			return fmt.Errorf("load types: %w", err)
@@ -44,6 +52,14 @@ func (p *PostgresClient) GetConn(ctx context.Context) (*pgxpool.Conn, error) {
 	return p.pool.Acquire(ctx)
 }
 
+func (p *PostgresClient) Ping(ctx context.Context) error {
+	return p.pool.Ping(ctx)
+}
+
+func (p *PostgresClient) Stat() *pgxpool.Stat {
+	return p.pool.Stat()
+}
+
 func (p *PostgresClient) Close() {
 	if p.pool != nil {
 		p.pool.Close()
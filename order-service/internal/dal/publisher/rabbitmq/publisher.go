@@ -3,13 +3,19 @@ package publisher
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 
 	config "github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/rabbitmq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/metrics"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tracing"
 	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Publisher struct {
@@ -32,11 +38,27 @@ func NewPublisher(cfg *config.RabbitMqPublisherSettings, client *rabbitmq.Rabbit
 	}, nil
 }
 
-func (p *Publisher) PublishBatch(ctx context.Context, messages []messages.Message) error {
+func (p *Publisher) PublishBatch(ctx context.Context, messages []messages.Message) (err error) {
 	if len(messages) == 0 {
 		return nil
 	}
 
+	ctx, span := tracing.Tracer().Start(ctx, "rabbitmq.publish_batch",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", p.cfg.Exchange),
+			attribute.Int("messaging.batch.message_count", len(messages)),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	if err := p.configure(); err != nil {
 		return err
 	}
@@ -44,9 +66,13 @@ func (p *Publisher) PublishBatch(ctx context.Context, messages []messages.Messag
 	for _, msg := range messages {
 		body, err := json.Marshal(msg)
 		if err != nil {
+			metrics.PublisherMessagesTotal.WithLabelValues(p.cfg.Exchange, "failed").Inc()
 			return fmt.Errorf("msg json marshal: %w", err)
 		}
 
+		headers := amqp.Table{}
+		tracing.InjectAmqpHeaders(ctx, headers)
+
 		err = p.ch.PublishWithContext(
 			ctx,
 			p.cfg.Exchange,
@@ -55,17 +81,30 @@ func (p *Publisher) PublishBatch(ctx context.Context, messages []messages.Messag
 			false,
 			amqp.Publishing{
 				ContentType: "application/json",
+				Headers:     headers,
 				Body:        body,
 			},
 		)
 		if err != nil {
+			metrics.PublisherMessagesTotal.WithLabelValues(p.cfg.Exchange, "failed").Inc()
 			return fmt.Errorf("publish: %w", err)
 		}
+		metrics.PublisherMessagesTotal.WithLabelValues(p.cfg.Exchange, "published").Inc()
 	}
 
 	return nil
 }
 
+func (p *Publisher) Ping() error {
+	if err := p.client.Ping(); err != nil {
+		return err
+	}
+	if p.ch == nil || p.ch.IsClosed() {
+		return errors.New("publisher channel closed")
+	}
+	return nil
+}
+
 func (p *Publisher) Close() {
 	if p.ch != nil {
 		p.ch.Close()
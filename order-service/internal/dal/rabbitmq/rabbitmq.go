@@ -1,6 +1,7 @@
 package rabbitmq
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"sync"
@@ -37,6 +38,13 @@ func (c *RabbitMqClient) Channel() (*amqp.Channel, error) {
 	return ch, nil
 }
 
+func (c *RabbitMqClient) Ping() error {
+	if c.conn == nil || c.conn.IsClosed() {
+		return errors.New("rabbitmq connection closed")
+	}
+	return nil
+}
+
 func (c *RabbitMqClient) Close() {
 	if c.conn != nil {
 		c.conn.Close()
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+)
+
+type AdvisoryLockRepository struct {
+	uow *unitofwork.UnitOfWork
+}
+
+func NewAdvisoryLockRepository(uow *unitofwork.UnitOfWork) interfaces.AdvisoryLockRepository {
+	return &AdvisoryLockRepository{uow: uow}
+}
+
+func (r *AdvisoryLockRepository) TryXactLock(ctx context.Context, key int64) (bool, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `select pg_try_advisory_xact_lock($1);`, key).Scan(&locked); err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+func (r *AdvisoryLockRepository) TryLock(ctx context.Context, classID, objID int32) (bool, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `select pg_try_advisory_lock($1, $2);`, classID, objID).Scan(&locked); err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+func (r *AdvisoryLockRepository) Unlock(ctx context.Context, classID, objID int32) error {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return err
+	}
+
+	var unlocked bool
+	if err := conn.QueryRow(ctx, `select pg_advisory_unlock($1, $2);`, classID, objID).Scan(&unlocked); err != nil {
+		return err
+	}
+	if !unlocked {
+		return fmt.Errorf("advisory lock (%d, %d) was not held", classID, objID)
+	}
+	return nil
+}
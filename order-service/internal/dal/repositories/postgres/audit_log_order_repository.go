@@ -6,6 +6,7 @@ import (
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
 	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
 )
 
 type AuditLogOrderRepository struct {
@@ -29,7 +30,9 @@ func (r *AuditLogOrderRepository) BulkInsert(ctx context.Context, items []models
 			customer_id,
 			order_status,
 			created_at,
-			updated_at
+			updated_at,
+			actor,
+			tenant_id
 		)
 		select
 			(i).order_id,
@@ -37,7 +40,9 @@ func (r *AuditLogOrderRepository) BulkInsert(ctx context.Context, items []models
 			(i).customer_id,
 			(i).order_status,
 			(i).created_at,
-			(i).updated_at
+			(i).updated_at,
+			(i).actor,
+			(i).tenant_id
 		from unnest($1::v1_audit_log_order[]) as i
 		returning
 			id,
@@ -46,7 +51,9 @@ func (r *AuditLogOrderRepository) BulkInsert(ctx context.Context, items []models
 			customer_id,
 			order_status,
 			created_at,
-			updated_at;
+			updated_at,
+			actor,
+			tenant_id;
 	`
 
 	rows, err := conn.Query(ctx, sql, items)
@@ -59,7 +66,7 @@ func (r *AuditLogOrderRepository) BulkInsert(ctx context.Context, items []models
 	for rows.Next() {
 		var i models.V1AuditLogOrderDal
 		if err := rows.Scan(&i.ID, &i.OrderID, &i.OrderItemID, &i.CustomerID,
-			&i.OrderStatus, &i.CreatedAt, &i.UpdatedAt,
+			&i.OrderStatus, &i.CreatedAt, &i.UpdatedAt, &i.Actor, &i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -68,3 +75,36 @@ func (r *AuditLogOrderRepository) BulkInsert(ctx context.Context, items []models
 
 	return result, nil
 }
+
+// QueryCreatedOrderIDs returns which of orderIDs already have a "created" entry.
+func (r *AuditLogOrderRepository) QueryCreatedOrderIDs(ctx context.Context, orderIDs []int64) ([]int64, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		select distinct order_id
+		from audit_log_order
+		where order_id = any($1)
+			and order_status = 'created'
+			and ($2::text = '' or tenant_id = $2);
+	`
+
+	rows, err := conn.Query(ctx, sql, orderIDs, utils.TenantFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+
+	return result, rows.Err()
+}
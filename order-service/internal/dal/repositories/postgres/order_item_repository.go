@@ -8,6 +8,7 @@ import (
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
 	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
 )
 
 type OrderItemRepository struct {
@@ -34,7 +35,8 @@ func (r *OrderItemRepository) BulkInsert(ctx context.Context, items []models.V1O
 			price_cents,
 			price_currency,
 			created_at,
-			updated_at
+			updated_at,
+			tenant_id
 		)
 		select
 			(i).order_id,
@@ -45,7 +47,8 @@ func (r *OrderItemRepository) BulkInsert(ctx context.Context, items []models.V1O
 			(i).price_cents,
 			(i).price_currency,
 			(i).created_at,
-			(i).updated_at
+			(i).updated_at,
+			(i).tenant_id
 		from unnest($1::v1_order_item[]) as i
 		returning
 			id,
@@ -57,7 +60,8 @@ func (r *OrderItemRepository) BulkInsert(ctx context.Context, items []models.V1O
 			price_cents,
 			price_currency,
 			created_at,
-			updated_at;
+			updated_at,
+			tenant_id;
 	`
 
 	rows, err := conn.Query(ctx, sql, items)
@@ -70,7 +74,7 @@ func (r *OrderItemRepository) BulkInsert(ctx context.Context, items []models.V1O
 	for rows.Next() {
 		var i models.V1OrderItemDal
 		if err := rows.Scan(&i.ID, &i.OrderID, &i.ProductID, &i.Quantity, &i.ProductTitle,
-			&i.ProductURL, &i.PriceCents, &i.PriceCurr, &i.CreatedAt, &i.UpdatedAt,
+			&i.ProductURL, &i.PriceCents, &i.PriceCurr, &i.CreatedAt, &i.UpdatedAt, &i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -103,10 +107,17 @@ func (r *OrderItemRepository) Query(ctx context.Context, q models.QueryOrderItem
 			price_cents,
 			price_currency,
 			created_at,
-			updated_at
+			updated_at,
+			tenant_id
 		from order_items
 	`)
 
+	if tenantID := utils.TenantFromContext(ctx); tenantID != "" {
+		where = append(where, fmt.Sprintf("tenant_id = $%d", argPos))
+		args = append(args, tenantID)
+		argPos++
+	}
+
 	if len(q.IDs) > 0 {
 		where = append(where, fmt.Sprintf("id = any($%d)", argPos))
 		args = append(args, q.IDs)
@@ -142,7 +153,7 @@ func (r *OrderItemRepository) Query(ctx context.Context, q models.QueryOrderItem
 	for rows.Next() {
 		var i models.V1OrderItemDal
 		if err := rows.Scan(&i.ID, &i.OrderID, &i.ProductID, &i.Quantity, &i.ProductTitle,
-			&i.ProductURL, &i.PriceCents, &i.PriceCurr, &i.CreatedAt, &i.UpdatedAt,
+			&i.ProductURL, &i.PriceCents, &i.PriceCurr, &i.CreatedAt, &i.UpdatedAt, &i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -8,6 +8,7 @@ import (
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
 	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
 )
 
 type OrderRepository struct {
@@ -32,7 +33,8 @@ func (r *OrderRepository) BulkInsert(ctx context.Context, orders []models.V1Orde
 			total_price_currency,
 			created_at,
 			updated_at,
-			status
+			status,
+			tenant_id
 		)
 		select 
 			(o).customer_id,
@@ -41,7 +43,8 @@ func (r *OrderRepository) BulkInsert(ctx context.Context, orders []models.V1Orde
 			(o).total_price_currency,
 			(o).created_at,
 			(o).updated_at,
-			(o).status
+			(o).status,
+			(o).tenant_id
 		from unnest($1::v1_order[]) as o
 		returning 
 			id,
@@ -51,7 +54,8 @@ func (r *OrderRepository) BulkInsert(ctx context.Context, orders []models.V1Orde
 			total_price_currency,
 			created_at,
 			updated_at,
-			status;
+			status,
+			tenant_id;
 	`
 
 	rows, err := conn.Conn().Query(ctx, sql, orders)
@@ -64,7 +68,7 @@ func (r *OrderRepository) BulkInsert(ctx context.Context, orders []models.V1Orde
 	for rows.Next() {
 		var o models.V1OrderDal
 		if err := rows.Scan(&o.ID, &o.CustomerID, &o.DeliveryAddress, &o.TotalPriceCents,
-			&o.TotalPriceCurr, &o.CreatedAt, &o.UpdatedAt, &o.Status,
+			&o.TotalPriceCurr, &o.CreatedAt, &o.UpdatedAt, &o.Status, &o.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -101,6 +105,7 @@ func (r *OrderRepository) BulkUpdate(ctx context.Context, orders []models.V1Orde
 			from unnest($1::v1_order[]) as x
 		) as u
 		where o.id = u.id
+			and ($2::text = '' or o.tenant_id = $2)
 		returning
 			o.id,
 			o.customer_id,
@@ -109,10 +114,11 @@ func (r *OrderRepository) BulkUpdate(ctx context.Context, orders []models.V1Orde
 			o.total_price_currency,
 			o.created_at,
 			o.updated_at,
-			o.status;
+			o.status,
+			o.tenant_id;
 	`
 
-	rows, err := conn.Conn().Query(ctx, sql, orders)
+	rows, err := conn.Conn().Query(ctx, sql, orders, utils.TenantFromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +128,7 @@ func (r *OrderRepository) BulkUpdate(ctx context.Context, orders []models.V1Orde
 	for rows.Next() {
 		var o models.V1OrderDal
 		if err := rows.Scan(&o.ID, &o.CustomerID, &o.DeliveryAddress, &o.TotalPriceCents,
-			&o.TotalPriceCurr, &o.CreatedAt, &o.UpdatedAt, &o.Status,
+			&o.TotalPriceCurr, &o.CreatedAt, &o.UpdatedAt, &o.Status, &o.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -153,10 +159,17 @@ func (r *OrderRepository) Query(ctx context.Context, q models.QueryOrdersDalMode
 			total_price_currency,
 			created_at,
 			updated_at,
-			status
+			status,
+			tenant_id
 		from orders
 	`)
 
+	if tenantID := utils.TenantFromContext(ctx); tenantID != "" {
+		where = append(where, fmt.Sprintf("tenant_id = $%d", argPos))
+		args = append(args, tenantID)
+		argPos++
+	}
+
 	if len(q.IDs) > 0 {
 		where = append(where, fmt.Sprintf("id = any($%d)", argPos))
 		args = append(args, q.IDs)
@@ -167,6 +180,16 @@ func (r *OrderRepository) Query(ctx context.Context, q models.QueryOrdersDalMode
 		args = append(args, q.CustomerIDs)
 		argPos++
 	}
+	if len(q.Statuses) > 0 {
+		where = append(where, fmt.Sprintf("status = any($%d)", argPos))
+		args = append(args, q.Statuses)
+		argPos++
+	}
+	if !q.UpdatedBefore.IsZero() {
+		where = append(where, fmt.Sprintf("updated_at < $%d", argPos))
+		args = append(args, q.UpdatedBefore)
+		argPos++
+	}
 	if len(where) > 0 {
 		sb.WriteString(" where " + strings.Join(where, " and "))
 	}
@@ -181,6 +204,9 @@ func (r *OrderRepository) Query(ctx context.Context, q models.QueryOrdersDalMode
 		args = append(args, q.Offset)
 		argPos++
 	}
+	if q.ForUpdate {
+		sb.WriteString(" for update")
+	}
 
 	rows, err := conn.Conn().Query(ctx, sb.String(), args...)
 	if err != nil {
@@ -193,7 +219,7 @@ func (r *OrderRepository) Query(ctx context.Context, q models.QueryOrdersDalMode
 		var o models.V1OrderDal
 		if err := rows.Scan(
 			&o.ID, &o.CustomerID, &o.DeliveryAddress, &o.TotalPriceCents,
-			&o.TotalPriceCurr, &o.CreatedAt, &o.UpdatedAt, &o.Status,
+			&o.TotalPriceCurr, &o.CreatedAt, &o.UpdatedAt, &o.Status, &o.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+type PaymentRepository struct {
+	uow *unitofwork.UnitOfWork
+}
+
+func NewPaymentRepository(uow *unitofwork.UnitOfWork) interfaces.PaymentRepository {
+	return &PaymentRepository{uow: uow}
+}
+
+func (r *PaymentRepository) BulkInsert(ctx context.Context, payments []models.V1PaymentDal) ([]models.V1PaymentDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		insert into payments (
+			order_id,
+			provider,
+			provider_payment_id,
+			amount_cents,
+			currency,
+			status,
+			failure_reason,
+			created_at,
+			updated_at,
+			tenant_id
+		)
+		select
+			(p).order_id,
+			(p).provider,
+			(p).provider_payment_id,
+			(p).amount_cents,
+			(p).currency,
+			(p).status,
+			(p).failure_reason,
+			(p).created_at,
+			(p).updated_at,
+			(p).tenant_id
+		from unnest($1::v1_payment[]) as p
+		returning
+			id,
+			order_id,
+			provider,
+			provider_payment_id,
+			amount_cents,
+			currency,
+			status,
+			failure_reason,
+			created_at,
+			updated_at,
+			tenant_id;
+	`
+
+	rows, err := conn.Query(ctx, sql, payments)
+	if err != nil {
+		return nil, err
+	}
+	return scanPayments(rows)
+}
+
+func (r *PaymentRepository) BulkUpdate(ctx context.Context, payments []models.V1PaymentDal) ([]models.V1PaymentDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		update payments p
+		set
+			provider_payment_id = u.provider_payment_id,
+			status = u.status,
+			failure_reason = u.failure_reason,
+			updated_at = u.updated_at
+		from (
+			select
+				(x).id,
+				(x).provider_payment_id,
+				(x).status,
+				(x).failure_reason,
+				(x).updated_at
+			from unnest($1::v1_payment[]) as x
+		) as u
+		where p.id = u.id
+			and ($2::text = '' or p.tenant_id = $2)
+		returning
+			p.id,
+			p.order_id,
+			p.provider,
+			p.provider_payment_id,
+			p.amount_cents,
+			p.currency,
+			p.status,
+			p.failure_reason,
+			p.created_at,
+			p.updated_at,
+			p.tenant_id;
+	`
+
+	rows, err := conn.Query(ctx, sql, payments, utils.TenantFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return scanPayments(rows)
+}
+
+func (r *PaymentRepository) Query(ctx context.Context, q models.QueryPaymentsDalModel) ([]models.V1PaymentDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sb     strings.Builder
+		args   []interface{}
+		where  []string
+		argPos = 1
+	)
+
+	sb.WriteString(`
+		select id,
+			order_id,
+			provider,
+			provider_payment_id,
+			amount_cents,
+			currency,
+			status,
+			failure_reason,
+			created_at,
+			updated_at,
+			tenant_id
+		from payments
+	`)
+
+	if tenantID := utils.TenantFromContext(ctx); tenantID != "" {
+		where = append(where, fmt.Sprintf("tenant_id = $%d", argPos))
+		args = append(args, tenantID)
+		argPos++
+	}
+
+	if len(q.IDs) > 0 {
+		where = append(where, fmt.Sprintf("id = any($%d)", argPos))
+		args = append(args, q.IDs)
+		argPos++
+	}
+	if len(q.OrderIDs) > 0 {
+		where = append(where, fmt.Sprintf("order_id = any($%d)", argPos))
+		args = append(args, q.OrderIDs)
+		argPos++
+	}
+	if q.Provider != "" {
+		where = append(where, fmt.Sprintf("provider = $%d", argPos))
+		args = append(args, q.Provider)
+		argPos++
+	}
+	if len(q.ProviderPaymentIDs) > 0 {
+		where = append(where, fmt.Sprintf("provider_payment_id = any($%d)", argPos))
+		args = append(args, q.ProviderPaymentIDs)
+		argPos++
+	}
+	if len(q.Statuses) > 0 {
+		where = append(where, fmt.Sprintf("status = any($%d)", argPos))
+		args = append(args, q.Statuses)
+		argPos++
+	}
+	if len(where) > 0 {
+		sb.WriteString(" where " + strings.Join(where, " and "))
+	}
+
+	sb.WriteString(" order by id")
+
+	if q.Limit > 0 {
+		sb.WriteString(fmt.Sprintf(" limit $%d", argPos))
+		args = append(args, q.Limit)
+		argPos++
+	}
+	if q.Offset > 0 {
+		sb.WriteString(fmt.Sprintf(" offset $%d", argPos))
+		args = append(args, q.Offset)
+		argPos++
+	}
+	if q.ForUpdate {
+		sb.WriteString(" for update")
+	}
+
+	rows, err := conn.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanPayments(rows)
+}
+
+func scanPayments(rows pgx.Rows) ([]models.V1PaymentDal, error) {
+	defer rows.Close()
+
+	var result []models.V1PaymentDal
+	for rows.Next() {
+		var p models.V1PaymentDal
+		if err := rows.Scan(
+			&p.ID, &p.OrderID, &p.Provider, &p.ProviderPaymentID, &p.AmountCents,
+			&p.Currency, &p.Status, &p.FailureReason, &p.CreatedAt, &p.UpdatedAt,
+			&p.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type ProductRepository struct {
+	uow *unitofwork.UnitOfWork
+}
+
+func NewProductRepository(uow *unitofwork.UnitOfWork) interfaces.ProductRepository {
+	return &ProductRepository{uow: uow}
+}
+
+func (r *ProductRepository) BulkUpsert(ctx context.Context, products []models.V1ProductDal) ([]models.V1ProductDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		insert into products (
+			id,
+			title,
+			url,
+			price_cents,
+			price_currency,
+			created_at,
+			updated_at
+		)
+		select
+			(p).id,
+			(p).title,
+			(p).url,
+			(p).price_cents,
+			(p).price_currency,
+			(p).created_at,
+			(p).updated_at
+		from unnest($1::v1_product[]) as p
+		on conflict (id) do update
+		set
+			title = excluded.title,
+			url = excluded.url,
+			price_cents = excluded.price_cents,
+			price_currency = excluded.price_currency,
+			updated_at = excluded.updated_at
+		returning
+			id,
+			title,
+			url,
+			price_cents,
+			price_currency,
+			created_at,
+			updated_at;
+	`
+
+	rows, err := conn.Query(ctx, sql, products)
+	if err != nil {
+		return nil, err
+	}
+	return scanProducts(rows)
+}
+
+func (r *ProductRepository) Delete(ctx context.Context, ids []int64) (int64, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := conn.Exec(ctx, `delete from products where id = any($1)`, ids)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *ProductRepository) Query(ctx context.Context, q models.QueryProductsDalModel) ([]models.V1ProductDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sb     strings.Builder
+		args   []interface{}
+		argPos = 1
+	)
+
+	sb.WriteString(`
+		select id,
+			title,
+			url,
+			price_cents,
+			price_currency,
+			created_at,
+			updated_at
+		from products
+	`)
+
+	if len(q.IDs) > 0 {
+		sb.WriteString(fmt.Sprintf(" where id = any($%d)", argPos))
+		args = append(args, q.IDs)
+		argPos++
+	}
+
+	sb.WriteString(" order by id")
+
+	if q.Limit > 0 {
+		sb.WriteString(fmt.Sprintf(" limit $%d", argPos))
+		args = append(args, q.Limit)
+		argPos++
+	}
+	if q.Offset > 0 {
+		sb.WriteString(fmt.Sprintf(" offset $%d", argPos))
+		args = append(args, q.Offset)
+		argPos++
+	}
+
+	rows, err := conn.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanProducts(rows)
+}
+
+func scanProducts(rows pgx.Rows) ([]models.V1ProductDal, error) {
+	defer rows.Close()
+
+	var result []models.V1ProductDal
+	for rows.Next() {
+		var p models.V1ProductDal
+		if err := rows.Scan(&p.ID, &p.Title, &p.URL, &p.PriceCents, &p.PriceCurrency, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
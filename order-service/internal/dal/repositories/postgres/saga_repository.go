@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type SagaRepository struct {
+	uow *unitofwork.UnitOfWork
+}
+
+func NewSagaRepository(uow *unitofwork.UnitOfWork) interfaces.SagaRepository {
+	return &SagaRepository{uow: uow}
+}
+
+func (r *SagaRepository) BulkInsert(ctx context.Context, sagas []models.V1SagaDal) ([]models.V1SagaDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		insert into sagas (
+			order_id,
+			status,
+			steps,
+			current_step,
+			data,
+			error,
+			created_at,
+			updated_at
+		)
+		select
+			(s).order_id,
+			(s).status,
+			(s).steps,
+			(s).current_step,
+			(s).data,
+			(s).error,
+			(s).created_at,
+			(s).updated_at
+		from unnest($1::v1_saga[]) as s
+		on conflict (order_id) do nothing
+		returning
+			id,
+			order_id,
+			status,
+			steps,
+			current_step,
+			data,
+			error,
+			created_at,
+			updated_at;
+	`
+
+	rows, err := conn.Query(ctx, sql, sagas)
+	if err != nil {
+		return nil, err
+	}
+	return scanSagas(rows)
+}
+
+func (r *SagaRepository) BulkUpdate(ctx context.Context, sagas []models.V1SagaDal) ([]models.V1SagaDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		update sagas s
+		set
+			status = u.status,
+			current_step = u.current_step,
+			data = u.data,
+			error = u.error,
+			updated_at = u.updated_at
+		from (
+			select
+				(x).id,
+				(x).status,
+				(x).current_step,
+				(x).data,
+				(x).error,
+				(x).updated_at
+			from unnest($1::v1_saga[]) as x
+		) as u
+		where s.id = u.id
+		returning
+			s.id,
+			s.order_id,
+			s.status,
+			s.steps,
+			s.current_step,
+			s.data,
+			s.error,
+			s.created_at,
+			s.updated_at;
+	`
+
+	rows, err := conn.Query(ctx, sql, sagas)
+	if err != nil {
+		return nil, err
+	}
+	return scanSagas(rows)
+}
+
+func (r *SagaRepository) Query(ctx context.Context, q models.QuerySagasDalModel) ([]models.V1SagaDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sb     strings.Builder
+		args   []interface{}
+		where  []string
+		argPos = 1
+	)
+
+	sb.WriteString(`
+		select id,
+			order_id,
+			status,
+			steps,
+			current_step,
+			data,
+			error,
+			created_at,
+			updated_at
+		from sagas
+	`)
+
+	if len(q.IDs) > 0 {
+		where = append(where, fmt.Sprintf("id = any($%d)", argPos))
+		args = append(args, q.IDs)
+		argPos++
+	}
+	if len(q.OrderIDs) > 0 {
+		where = append(where, fmt.Sprintf("order_id = any($%d)", argPos))
+		args = append(args, q.OrderIDs)
+		argPos++
+	}
+	if len(q.Statuses) > 0 {
+		where = append(where, fmt.Sprintf("status = any($%d)", argPos))
+		args = append(args, q.Statuses)
+		argPos++
+	}
+	if len(where) > 0 {
+		sb.WriteString(" where " + strings.Join(where, " and "))
+	}
+
+	sb.WriteString(" order by id")
+
+	if q.Limit > 0 {
+		sb.WriteString(fmt.Sprintf(" limit $%d", argPos))
+		args = append(args, q.Limit)
+		argPos++
+	}
+	if q.Offset > 0 {
+		sb.WriteString(fmt.Sprintf(" offset $%d", argPos))
+		args = append(args, q.Offset)
+		argPos++
+	}
+
+	rows, err := conn.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanSagas(rows)
+}
+
+func scanSagas(rows pgx.Rows) ([]models.V1SagaDal, error) {
+	defer rows.Close()
+
+	var result []models.V1SagaDal
+	for rows.Next() {
+		var s models.V1SagaDal
+		if err := rows.Scan(
+			&s.ID, &s.OrderID, &s.Status, &s.Steps, &s.CurrentStep,
+			&s.Data, &s.Error, &s.CreatedAt, &s.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
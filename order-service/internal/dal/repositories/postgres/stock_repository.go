@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type StockRepository struct {
+	uow *unitofwork.UnitOfWork
+}
+
+func NewStockRepository(uow *unitofwork.UnitOfWork) interfaces.StockRepository {
+	return &StockRepository{uow: uow}
+}
+
+func (r *StockRepository) BulkUpsert(ctx context.Context, stocks []models.V1StockDal) ([]models.V1StockDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		insert into stocks (
+			warehouse_id,
+			product_id,
+			quantity,
+			reserved,
+			created_at,
+			updated_at
+		)
+		select
+			(s).warehouse_id,
+			(s).product_id,
+			(s).quantity,
+			(s).reserved,
+			(s).created_at,
+			(s).updated_at
+		from unnest($1::v1_stock[]) as s
+		on conflict (warehouse_id, product_id) do update
+		set
+			quantity = excluded.quantity,
+			reserved = excluded.reserved,
+			updated_at = excluded.updated_at
+		returning
+			warehouse_id,
+			product_id,
+			quantity,
+			reserved,
+			created_at,
+			updated_at;
+	`
+
+	rows, err := conn.Query(ctx, sql, stocks)
+	if err != nil {
+		return nil, err
+	}
+	return scanStocks(rows)
+}
+
+func (r *StockRepository) Query(ctx context.Context, q models.QueryStocksDalModel) ([]models.V1StockDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sb     strings.Builder
+		args   []interface{}
+		where  []string
+		argPos = 1
+	)
+
+	sb.WriteString(`
+		select warehouse_id,
+			product_id,
+			quantity,
+			reserved,
+			created_at,
+			updated_at
+		from stocks
+	`)
+
+	if len(q.ProductIDs) > 0 {
+		where = append(where, fmt.Sprintf("product_id = any($%d)", argPos))
+		args = append(args, q.ProductIDs)
+		argPos++
+	}
+	if len(q.WarehouseIDs) > 0 {
+		where = append(where, fmt.Sprintf("warehouse_id = any($%d)", argPos))
+		args = append(args, q.WarehouseIDs)
+		argPos++
+	}
+	if len(where) > 0 {
+		sb.WriteString(" where " + strings.Join(where, " and "))
+	}
+
+	sb.WriteString(" order by product_id, warehouse_id")
+
+	if q.Limit > 0 {
+		sb.WriteString(fmt.Sprintf(" limit $%d", argPos))
+		args = append(args, q.Limit)
+		argPos++
+	}
+	if q.Offset > 0 {
+		sb.WriteString(fmt.Sprintf(" offset $%d", argPos))
+		args = append(args, q.Offset)
+		argPos++
+	}
+	if q.ForUpdate {
+		sb.WriteString(" for update")
+	}
+
+	rows, err := conn.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanStocks(rows)
+}
+
+func scanStocks(rows pgx.Rows) ([]models.V1StockDal, error) {
+	defer rows.Close()
+
+	var result []models.V1StockDal
+	for rows.Next() {
+		var s models.V1StockDal
+		if err := rows.Scan(&s.WarehouseID, &s.ProductID, &s.Quantity, &s.Reserved, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
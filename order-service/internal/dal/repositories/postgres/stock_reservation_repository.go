@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type StockReservationRepository struct {
+	uow *unitofwork.UnitOfWork
+}
+
+func NewStockReservationRepository(uow *unitofwork.UnitOfWork) interfaces.StockReservationRepository {
+	return &StockReservationRepository{uow: uow}
+}
+
+func (r *StockReservationRepository) BulkInsert(ctx context.Context, reservations []models.V1StockReservationDal) ([]models.V1StockReservationDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		insert into stock_reservations (
+			order_id,
+			order_item_id,
+			warehouse_id,
+			product_id,
+			quantity,
+			status,
+			created_at,
+			updated_at
+		)
+		select
+			(r).order_id,
+			(r).order_item_id,
+			(r).warehouse_id,
+			(r).product_id,
+			(r).quantity,
+			(r).status,
+			(r).created_at,
+			(r).updated_at
+		from unnest($1::v1_stock_reservation[]) as r
+		returning
+			id,
+			order_id,
+			order_item_id,
+			warehouse_id,
+			product_id,
+			quantity,
+			status,
+			created_at,
+			updated_at;
+	`
+
+	rows, err := conn.Query(ctx, sql, reservations)
+	if err != nil {
+		return nil, err
+	}
+	return scanStockReservations(rows)
+}
+
+func (r *StockReservationRepository) Query(ctx context.Context, q models.QueryStockReservationsDalModel) ([]models.V1StockReservationDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sb     strings.Builder
+		args   []interface{}
+		where  []string
+		argPos = 1
+	)
+
+	sb.WriteString(`
+		select id,
+			order_id,
+			order_item_id,
+			warehouse_id,
+			product_id,
+			quantity,
+			status,
+			created_at,
+			updated_at
+		from stock_reservations
+	`)
+
+	if len(q.OrderIDs) > 0 {
+		where = append(where, fmt.Sprintf("order_id = any($%d)", argPos))
+		args = append(args, q.OrderIDs)
+		argPos++
+	}
+	if len(q.Statuses) > 0 {
+		where = append(where, fmt.Sprintf("status = any($%d)", argPos))
+		args = append(args, q.Statuses)
+		argPos++
+	}
+	if len(where) > 0 {
+		sb.WriteString(" where " + strings.Join(where, " and "))
+	}
+
+	sb.WriteString(" order by id")
+	if q.ForUpdate {
+		sb.WriteString(" for update")
+	}
+
+	rows, err := conn.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanStockReservations(rows)
+}
+
+func (r *StockReservationRepository) UpdateStatus(ctx context.Context, ids []int64, status string, now time.Time) error {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx, `update stock_reservations set status = $2, updated_at = $3 where id = any($1)`, ids, status, now)
+	return err
+}
+
+func scanStockReservations(rows pgx.Rows) ([]models.V1StockReservationDal, error) {
+	defer rows.Close()
+
+	var result []models.V1StockReservationDal
+	for rows.Next() {
+		var r models.V1StockReservationDal
+		if err := rows.Scan(&r.ID, &r.OrderID, &r.OrderItemID, &r.WarehouseID, &r.ProductID,
+			&r.Quantity, &r.Status, &r.CreatedAt, &r.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+type WebhookDeliveryRepository struct {
+	uow *unitofwork.UnitOfWork
+}
+
+func NewWebhookDeliveryRepository(uow *unitofwork.UnitOfWork) interfaces.WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{uow: uow}
+}
+
+func (r *WebhookDeliveryRepository) BulkInsert(ctx context.Context, deliveries []models.V1WebhookDeliveryDal) ([]models.V1WebhookDeliveryDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		insert into webhook_deliveries (
+			subscription_id,
+			event_type,
+			event_id,
+			attempt,
+			status_code,
+			success,
+			error,
+			duration_ms,
+			created_at,
+			tenant_id
+		)
+		select
+			(d).subscription_id,
+			(d).event_type,
+			(d).event_id,
+			(d).attempt,
+			(d).status_code,
+			(d).success,
+			(d).error,
+			(d).duration_ms,
+			(d).created_at,
+			(d).tenant_id
+		from unnest($1::v1_webhook_delivery[]) as d
+		returning
+			id,
+			subscription_id,
+			event_type,
+			event_id,
+			attempt,
+			status_code,
+			success,
+			error,
+			duration_ms,
+			created_at,
+			tenant_id;
+	`
+
+	rows, err := conn.Query(ctx, sql, deliveries)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookDeliveries(rows)
+}
+
+func (r *WebhookDeliveryRepository) Query(ctx context.Context, q models.QueryWebhookDeliveriesDalModel) ([]models.V1WebhookDeliveryDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sb     strings.Builder
+		args   []interface{}
+		where  []string
+		argPos = 1
+	)
+
+	sb.WriteString(`
+		select id,
+			subscription_id,
+			event_type,
+			event_id,
+			attempt,
+			status_code,
+			success,
+			error,
+			duration_ms,
+			created_at,
+			tenant_id
+		from webhook_deliveries
+	`)
+
+	if tenantID := utils.TenantFromContext(ctx); tenantID != "" {
+		where = append(where, fmt.Sprintf("tenant_id = $%d", argPos))
+		args = append(args, tenantID)
+		argPos++
+	}
+
+	if len(q.SubscriptionIDs) > 0 {
+		where = append(where, fmt.Sprintf("subscription_id = any($%d)", argPos))
+		args = append(args, q.SubscriptionIDs)
+		argPos++
+	}
+	if len(q.EventIDs) > 0 {
+		where = append(where, fmt.Sprintf("event_id = any($%d)", argPos))
+		args = append(args, q.EventIDs)
+		argPos++
+	}
+	if q.OnlyFailed {
+		where = append(where, "not success")
+	}
+	if q.OnlySucceeded {
+		where = append(where, "success")
+	}
+	if len(where) > 0 {
+		sb.WriteString(" where " + strings.Join(where, " and "))
+	}
+
+	sb.WriteString(" order by created_at desc, id desc")
+
+	if q.Limit > 0 {
+		sb.WriteString(fmt.Sprintf(" limit $%d", argPos))
+		args = append(args, q.Limit)
+		argPos++
+	}
+	if q.Offset > 0 {
+		sb.WriteString(fmt.Sprintf(" offset $%d", argPos))
+		args = append(args, q.Offset)
+		argPos++
+	}
+
+	rows, err := conn.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookDeliveries(rows)
+}
+
+func scanWebhookDeliveries(rows pgx.Rows) ([]models.V1WebhookDeliveryDal, error) {
+	defer rows.Close()
+
+	var result []models.V1WebhookDeliveryDal
+	for rows.Next() {
+		var d models.V1WebhookDeliveryDal
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.EventType, &d.EventID, &d.Attempt,
+			&d.StatusCode, &d.Success, &d.Error, &d.DurationMs, &d.CreatedAt,
+			&d.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,274 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+const webhookSubscriptionColumns = `
+	id,
+	url,
+	event_types,
+	customer_ids,
+	secret,
+	is_active,
+	consecutive_failures,
+	disabled_at,
+	created_at,
+	updated_at,
+	tenant_id`
+
+type WebhookSubscriptionRepository struct {
+	uow *unitofwork.UnitOfWork
+}
+
+func NewWebhookSubscriptionRepository(uow *unitofwork.UnitOfWork) interfaces.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{uow: uow}
+}
+
+func (r *WebhookSubscriptionRepository) BulkInsert(ctx context.Context, subscriptions []models.V1WebhookSubscriptionDal) ([]models.V1WebhookSubscriptionDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		insert into webhook_subscriptions (
+			url,
+			event_types,
+			customer_ids,
+			secret,
+			is_active,
+			consecutive_failures,
+			disabled_at,
+			created_at,
+			updated_at,
+			tenant_id
+		)
+		select
+			(s).url,
+			(s).event_types,
+			(s).customer_ids,
+			(s).secret,
+			(s).is_active,
+			(s).consecutive_failures,
+			(s).disabled_at,
+			(s).created_at,
+			(s).updated_at,
+			(s).tenant_id
+		from unnest($1::v1_webhook_subscription[]) as s
+		returning` + webhookSubscriptionColumns + ";"
+
+	rows, err := conn.Query(ctx, sql, subscriptions)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookSubscriptions(rows)
+}
+
+func (r *WebhookSubscriptionRepository) BulkUpdate(ctx context.Context, subscriptions []models.V1WebhookSubscriptionDal) ([]models.V1WebhookSubscriptionDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		update webhook_subscriptions s
+		set
+			url = u.url,
+			event_types = u.event_types,
+			customer_ids = u.customer_ids,
+			secret = u.secret,
+			is_active = u.is_active,
+			consecutive_failures = u.consecutive_failures,
+			disabled_at = u.disabled_at,
+			updated_at = u.updated_at
+		from (
+			select
+				(x).id,
+				(x).url,
+				(x).event_types,
+				(x).customer_ids,
+				(x).secret,
+				(x).is_active,
+				(x).consecutive_failures,
+				(x).disabled_at,
+				(x).updated_at
+			from unnest($1::v1_webhook_subscription[]) as x
+		) as u
+		where s.id = u.id
+			and ($2::text = '' or s.tenant_id = $2)
+		returning
+			s.id,
+			s.url,
+			s.event_types,
+			s.customer_ids,
+			s.secret,
+			s.is_active,
+			s.consecutive_failures,
+			s.disabled_at,
+			s.created_at,
+			s.updated_at,
+			s.tenant_id;
+	`
+
+	rows, err := conn.Query(ctx, sql, subscriptions, utils.TenantFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookSubscriptions(rows)
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, ids []int64) (int64, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sql := `
+		delete from webhook_subscriptions
+		where id = any($1)
+			and ($2::text = '' or tenant_id = $2);
+	`
+
+	tag, err := conn.Exec(ctx, sql, ids, utils.TenantFromContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *WebhookSubscriptionRepository) Query(ctx context.Context, q models.QueryWebhookSubscriptionsDalModel) ([]models.V1WebhookSubscriptionDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sb     strings.Builder
+		args   []interface{}
+		where  []string
+		argPos = 1
+	)
+
+	sb.WriteString("select" + webhookSubscriptionColumns + "\nfrom webhook_subscriptions")
+
+	if tenantID := utils.TenantFromContext(ctx); tenantID != "" {
+		where = append(where, fmt.Sprintf("tenant_id = $%d", argPos))
+		args = append(args, tenantID)
+		argPos++
+	}
+
+	if len(q.IDs) > 0 {
+		where = append(where, fmt.Sprintf("id = any($%d)", argPos))
+		args = append(args, q.IDs)
+		argPos++
+	}
+	if len(q.EventTypes) > 0 {
+		where = append(where, fmt.Sprintf("event_types && $%d", argPos))
+		args = append(args, q.EventTypes)
+		argPos++
+	}
+	if q.OnlyActive {
+		where = append(where, "is_active")
+	}
+	if len(where) > 0 {
+		sb.WriteString(" where " + strings.Join(where, " and "))
+	}
+
+	sb.WriteString(" order by id")
+
+	if q.Limit > 0 {
+		sb.WriteString(fmt.Sprintf(" limit $%d", argPos))
+		args = append(args, q.Limit)
+		argPos++
+	}
+	if q.Offset > 0 {
+		sb.WriteString(fmt.Sprintf(" offset $%d", argPos))
+		args = append(args, q.Offset)
+		argPos++
+	}
+
+	rows, err := conn.Query(ctx, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookSubscriptions(rows)
+}
+
+func (r *WebhookSubscriptionRepository) RegisterSuccess(ctx context.Context, id int64, now time.Time) error {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return err
+	}
+
+	sql := `
+		update webhook_subscriptions
+		set consecutive_failures = 0, updated_at = $2
+		where id = $1 and consecutive_failures <> 0
+			and ($3::text = '' or tenant_id = $3);
+	`
+
+	_, err = conn.Exec(ctx, sql, id, now, utils.TenantFromContext(ctx))
+	return err
+}
+
+func (r *WebhookSubscriptionRepository) RegisterFailure(ctx context.Context, id int64, disableAfter int, now time.Time) (models.V1WebhookSubscriptionDal, error) {
+	conn, err := r.uow.GetConn(ctx)
+	if err != nil {
+		return models.V1WebhookSubscriptionDal{}, err
+	}
+
+	sql := `
+		update webhook_subscriptions
+		set
+			consecutive_failures = consecutive_failures + 1,
+			is_active = is_active and ($2 <= 0 or consecutive_failures + 1 < $2),
+			disabled_at = case
+				when is_active and $2 > 0 and consecutive_failures + 1 >= $2 then $3
+				else disabled_at
+			end,
+			updated_at = $3
+		where id = $1
+			and ($4::text = '' or tenant_id = $4)
+		returning` + webhookSubscriptionColumns + ";"
+
+	rows, err := conn.Query(ctx, sql, id, disableAfter, now, utils.TenantFromContext(ctx))
+	if err != nil {
+		return models.V1WebhookSubscriptionDal{}, err
+	}
+	result, err := scanWebhookSubscriptions(rows)
+	if err != nil {
+		return models.V1WebhookSubscriptionDal{}, err
+	}
+	if len(result) == 0 {
+		return models.V1WebhookSubscriptionDal{}, pgx.ErrNoRows
+	}
+	return result[0], nil
+}
+
+func scanWebhookSubscriptions(rows pgx.Rows) ([]models.V1WebhookSubscriptionDal, error) {
+	defer rows.Close()
+
+	var result []models.V1WebhookSubscriptionDal
+	for rows.Next() {
+		var s models.V1WebhookSubscriptionDal
+		if err := rows.Scan(&s.ID, &s.URL, &s.EventTypes, &s.CustomerIDs, &s.Secret, &s.IsActive,
+			&s.ConsecutiveFailures, &s.DisabledAt, &s.CreatedAt, &s.UpdatedAt,
+			&s.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,299 @@
+package dlq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	rabbitmqconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer/rabbitmq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"golang.org/x/time/rate"
+)
+
+// REPLAY_COUNT_HEADER counts how often a message was replayed from a DLQ.
+const REPLAY_COUNT_HEADER = "x-replay-count"
+
+var (
+	ErrUnknownQueue = errors.New("unknown dlq")
+	ErrAmbiguousID  = errors.New("id matches more than one message")
+	// ErrReplayedNotRemoved reports a message that was replayed but couldn't be acked in
+	// the DLQ, so it is now in both queues.
+	ErrReplayedNotRemoved = errors.New("replayed message is still in the dlq")
+)
+
+// Manager reads DLQs with basic.get and holds every fetched message unacked until the
+// scan ends. Messages that were not replayed or purged are then requeued, so the DLQ
+// keeps them; if the tool dies mid-scan the broker requeues them on channel close.
+type Manager struct {
+	client *rabbitmq.RabbitMqClient
+	cfg    settings.DlqSettings
+}
+
+func NewManager(client *rabbitmq.RabbitMqClient, cfg settings.DlqSettings) *Manager {
+	return &Manager{client: client, cfg: cfg}
+}
+
+func (m *Manager) Queues() []settings.DlqQueueSettings {
+	return m.cfg.Queues
+}
+
+func (m *Manager) queue(dlq string) (settings.DlqQueueSettings, error) {
+	for _, q := range m.cfg.Queues {
+		if q.Dlq == dlq {
+			return q, nil
+		}
+	}
+	return settings.DlqQueueSettings{}, fmt.Errorf("%w: %s", ErrUnknownQueue, dlq)
+}
+
+// List returns up to limit messages matching filter together with the number of
+// messages scanned. A limit of 0 means no limit beyond MaxScanMessages.
+func (m *Manager) List(ctx context.Context, dlq string, filter Filter, limit int) ([]Message, int, error) {
+	if _, err := m.queue(dlq); err != nil {
+		return nil, 0, err
+	}
+
+	ch, err := m.client.Channel()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer ch.Close()
+
+	var msgs []Message
+	scanned, lastTag, err := m.scan(ctx, ch, dlq, func(_ amqp.Delivery, msg Message) bool {
+		if filter.Match(msg) {
+			msgs = append(msgs, msg)
+		}
+		return limit > 0 && len(msgs) >= limit
+	})
+	return msgs, scanned, requeue(ch, lastTag, err)
+}
+
+// Replay publishes the selected messages straight to the queue they were dead-lettered
+// from, at most ratePerSecond per second, and removes them from the DLQ. The retry count
+// is reset so a replayed message gets the full set of retries again.
+//
+// A non-zero deadline stops the replay before the first message that couldn't be sent in
+// time; the returned bool then reports that the scan didn't finish. Each message is acked
+// in the DLQ only after its publish is confirmed. If that ack fails the message is both
+// back in its queue and still in the DLQ; the error wraps ErrReplayedNotRemoved and names
+// it, so it must be purged rather than replayed again.
+func (m *Manager) Replay(ctx context.Context, dlq string, sel Selection, ratePerSecond float64, deadline time.Time) (int, int, bool, error) {
+	q, err := m.queue(dlq)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if ratePerSecond <= 0 {
+		ratePerSecond = m.cfg.ReplayRatePerSecond
+	}
+
+	ch, err := m.client.Channel()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	defer ch.Close()
+
+	// Publishing to a queue that doesn't exist is silently dropped, which would lose the
+	// message once it is acked in the DLQ.
+	if _, err := ch.QueueDeclarePassive(q.Queue, false, false, false, false, nil); err != nil {
+		return 0, 0, false, fmt.Errorf("target queue %s: %w", q.Queue, err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		return 0, 0, false, fmt.Errorf("confirm mode: %w", err)
+	}
+
+	picked, scanned, lastTag, err := m.pick(ctx, ch, dlq, sel)
+	if err != nil {
+		return 0, scanned, false, requeue(ch, lastTag, err)
+	}
+
+	waitCtx := ctx
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(ratePerSecond), 1)
+	replayed := 0
+	timedOut := false
+	for _, p := range picked {
+		// The limiter fails straight away when the wait would pass the deadline.
+		if err = limiter.Wait(waitCtx); err != nil {
+			if ctx.Err() == nil {
+				timedOut, err = true, nil
+			}
+			break
+		}
+		if err = publish(ctx, ch, q.Queue, replayPublishing(p.d, p.msg)); err != nil {
+			break
+		}
+		if ackErr := ch.Ack(p.d.DeliveryTag, false); ackErr != nil {
+			err = fmt.Errorf("%w: %s: %v", ErrReplayedNotRemoved, p.msg.ID, ackErr)
+			break
+		}
+		replayed++
+	}
+	return replayed, scanned, timedOut, requeue(ch, lastTag, err)
+}
+
+// Purge deletes the selected messages from the DLQ.
+func (m *Manager) Purge(ctx context.Context, dlq string, sel Selection) (int, int, error) {
+	if _, err := m.queue(dlq); err != nil {
+		return 0, 0, err
+	}
+
+	ch, err := m.client.Channel()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer ch.Close()
+
+	picked, scanned, lastTag, err := m.pick(ctx, ch, dlq, sel)
+	if err != nil {
+		return 0, scanned, requeue(ch, lastTag, err)
+	}
+
+	purged := 0
+	for _, p := range picked {
+		if err = ch.Ack(p.d.DeliveryTag, false); err != nil {
+			err = fmt.Errorf("ack: %w", err)
+			break
+		}
+		purged++
+	}
+	return purged, scanned, requeue(ch, lastTag, err)
+}
+
+type picked struct {
+	d   amqp.Delivery
+	msg Message
+}
+
+// pick scans the DLQ and returns the selected messages, still unacked, once the whole
+// scan is done. Ids are checked in the same scan that the caller acts on, so every id a
+// selection lists stands for a single held message and acting on it can't hit an
+// identical one as well.
+func (m *Manager) pick(ctx context.Context, ch *amqp.Channel, dlq string, sel Selection) ([]picked, int, uint64, error) {
+	selected := sel.matcher()
+	var msgs []picked
+	scanned, lastTag, err := m.scan(ctx, ch, dlq, func(d amqp.Delivery, msg Message) bool {
+		if selected(msg) {
+			msgs = append(msgs, picked{d: d, msg: msg})
+		}
+		return false
+	})
+	if err != nil {
+		return nil, scanned, lastTag, err
+	}
+
+	if !sel.All && len(sel.IDs) > 0 {
+		seen := make(map[string]int, len(sel.IDs))
+		for _, p := range msgs {
+			seen[p.msg.ID]++
+		}
+		if err := ambiguousIDs(seen); err != nil {
+			return nil, scanned, lastTag, err
+		}
+	}
+	return msgs, scanned, lastTag, nil
+}
+
+func ambiguousIDs(seen map[string]int) error {
+	var ids []string
+	for id, n := range seen {
+		if n > 1 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	slices.Sort(ids)
+	return fmt.Errorf("%w: %s; select them with a filter and all instead", ErrAmbiguousID, strings.Join(ids, ", "))
+}
+
+// scan hands every fetched message to visit, which reports whether to stop. Fetched
+// messages stay unacked on ch; the caller acks the ones it acts on and then requeues the
+// rest up to the returned delivery tag.
+func (m *Manager) scan(ctx context.Context, ch *amqp.Channel, dlq string, visit func(amqp.Delivery, Message) (stop bool)) (int, uint64, error) {
+	var (
+		scanned int
+		lastTag uint64
+	)
+	for scanned < m.cfg.MaxScanMessages {
+		if err := ctx.Err(); err != nil {
+			return scanned, lastTag, err
+		}
+
+		d, ok, err := ch.Get(dlq, false)
+		if err != nil {
+			return scanned, lastTag, fmt.Errorf("get from %s: %w", dlq, err)
+		}
+		if !ok {
+			break
+		}
+		scanned++
+		lastTag = d.DeliveryTag
+
+		if visit(d, toMessage(d)) {
+			break
+		}
+	}
+	return scanned, lastTag, nil
+}
+
+// requeue hands every message still held on ch back to the DLQ and returns err, or the
+// requeue failure when there was none.
+func requeue(ch *amqp.Channel, lastTag uint64, err error) error {
+	if lastTag > 0 && !ch.IsClosed() {
+		if nackErr := ch.Nack(lastTag, true, true); nackErr != nil && err == nil {
+			err = fmt.Errorf("requeue kept messages: %w", nackErr)
+		}
+	}
+	return err
+}
+
+func replayPublishing(d amqp.Delivery, msg Message) amqp.Publishing {
+	headers := make(amqp.Table, len(d.Headers)+2)
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	delete(headers, rabbitmqconsumer.DEAD_LETTER_REASON_HEADER)
+	headers[rabbitmqconsumer.RETRY_COUNT_HEADER] = int32(0)
+	headers[rabbitmqconsumer.ORIGINAL_ROUTING_KEY_HEADER] = msg.RoutingKey
+	headers[REPLAY_COUNT_HEADER] = int32(toInt(d.Headers[REPLAY_COUNT_HEADER]) + 1)
+
+	return amqp.Publishing{
+		Headers:         headers,
+		ContentType:     d.ContentType,
+		ContentEncoding: d.ContentEncoding,
+		DeliveryMode:    d.DeliveryMode,
+		CorrelationId:   d.CorrelationId,
+		MessageId:       d.MessageId,
+		Timestamp:       d.Timestamp,
+		Type:            d.Type,
+		AppId:           d.AppId,
+		Body:            d.Body,
+	}
+}
+
+func publish(ctx context.Context, ch *amqp.Channel, queue string, p amqp.Publishing) error {
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, "", queue, false, false, p)
+	if err != nil {
+		return fmt.Errorf("publish to %s: %w", queue, err)
+	}
+	ok, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("wait confirm: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("publish to %s: nacked by broker", queue)
+	}
+	return nil
+}
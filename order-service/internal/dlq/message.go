@@ -0,0 +1,164 @@
+package dlq
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	rabbitmqconsumer "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/consumer/rabbitmq"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type Message struct {
+	ID             string            `json:"id"`
+	RoutingKey     string            `json:"routing_key"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	Body           string            `json:"body"`
+	DeathReason    string            `json:"death_reason,omitempty"`
+	DeathQueue     string            `json:"death_queue,omitempty"`
+	DeathCount     int               `json:"death_count"`
+	DeadLetteredAt time.Time         `json:"dead_lettered_at"`
+	RetryCount     int               `json:"retry_count"`
+	RetryReason    string            `json:"retry_reason,omitempty"`
+}
+
+type Filter struct {
+	RoutingKey   string
+	From         time.Time
+	To           time.Time
+	BodyContains string
+}
+
+// Match treats zero fields as "any". RoutingKey also matches by prefix, so
+// "order.created" selects every tenant's order.created.* messages.
+func (f Filter) Match(m Message) bool {
+	if f.RoutingKey != "" && m.RoutingKey != f.RoutingKey && !strings.HasPrefix(m.RoutingKey, f.RoutingKey+".") {
+		return false
+	}
+	if !f.From.IsZero() && m.DeadLetteredAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && m.DeadLetteredAt.After(f.To) {
+		return false
+	}
+	if f.BodyContains != "" && !strings.Contains(m.Body, f.BodyContains) {
+		return false
+	}
+	return true
+}
+
+// Selection picks the messages a replay or purge acts on: those matching Filter that are
+// either listed in IDs or, with All set, every match.
+type Selection struct {
+	Filter Filter
+	IDs    []string
+	All    bool
+}
+
+func (s Selection) matcher() func(Message) bool {
+	ids := make(map[string]struct{}, len(s.IDs))
+	for _, id := range s.IDs {
+		ids[id] = struct{}{}
+	}
+	return func(m Message) bool {
+		if !s.Filter.Match(m) {
+			return false
+		}
+		if s.All {
+			return true
+		}
+		_, ok := ids[m.ID]
+		return ok
+	}
+}
+
+func toMessage(d amqp.Delivery) Message {
+	deaths := xDeaths(d.Headers)
+	m := Message{
+		RoutingKey: originalRoutingKey(d, deaths),
+		Headers:    stringHeaders(d.Headers),
+		Body:       string(d.Body),
+	}
+
+	// x-death is ordered most recent first.
+	if len(deaths) > 0 {
+		m.DeathReason, _ = deaths[0]["reason"].(string)
+		m.DeathQueue, _ = deaths[0]["queue"].(string)
+		m.DeadLetteredAt, _ = deaths[0]["time"].(time.Time)
+	}
+	for _, death := range deaths {
+		m.DeathCount += toInt(death["count"])
+	}
+	if reason, ok := d.Headers[rabbitmqconsumer.DEAD_LETTER_REASON_HEADER].(string); ok {
+		m.DeathReason = reason
+	}
+	if d.Timestamp.After(m.DeadLetteredAt) {
+		m.DeadLetteredAt = d.Timestamp
+	}
+	m.RetryCount = toInt(d.Headers[rabbitmqconsumer.RETRY_COUNT_HEADER])
+	m.RetryReason, _ = d.Headers[rabbitmqconsumer.RETRY_REASON_HEADER].(string)
+
+	// DLQ messages have no stable id of their own, so the id is derived from what is
+	// stable about them; it stays the same between a list and the replay that follows.
+	// Identical messages that died the same way in the same second still share an id,
+	// which the manager refuses to act on.
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%s\x00%s\x00%d\x00%d\x00%s\x00",
+		d.MessageId, d.CorrelationId, m.RoutingKey, m.DeadLetteredAt.UTC().Format(time.RFC3339Nano),
+		m.DeathQueue, m.DeathReason, m.DeathCount, m.RetryCount, m.RetryReason)
+	h.Write(d.Body)
+	m.ID = hex.EncodeToString(h.Sum(nil))[:16]
+
+	return m
+}
+
+func originalRoutingKey(d amqp.Delivery, deaths []amqp.Table) string {
+	if key, ok := d.Headers[rabbitmqconsumer.ORIGINAL_ROUTING_KEY_HEADER].(string); ok && key != "" {
+		return key
+	}
+	for _, death := range deaths {
+		if keys, ok := death["routing-keys"].([]interface{}); ok && len(keys) > 0 {
+			if key, ok := keys[0].(string); ok {
+				return key
+			}
+		}
+	}
+	return d.RoutingKey
+}
+
+func xDeaths(headers amqp.Table) []amqp.Table {
+	raw, _ := headers["x-death"].([]interface{})
+	deaths := make([]amqp.Table, 0, len(raw))
+	for _, d := range raw {
+		if death, ok := d.(amqp.Table); ok {
+			deaths = append(deaths, death)
+		}
+	}
+	return deaths
+}
+
+// x-death is summarized by the death fields, everything else is shown as text.
+func stringHeaders(headers amqp.Table) map[string]string {
+	out := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if k == "x-death" {
+			continue
+		}
+		out[k] = fmt.Sprint(v)
+	}
+	return out
+}
+
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+package dlq
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func deadLettered(body string, count int64, queue string, at time.Time) amqp.Delivery {
+	return amqp.Delivery{
+		RoutingKey: "order.created.dlq",
+		Body:       []byte(body),
+		Headers: amqp.Table{
+			"x-death": []interface{}{amqp.Table{
+				"count":        count,
+				"queue":        queue,
+				"reason":       "rejected",
+				"time":         at,
+				"routing-keys": []interface{}{"order.created.acme"},
+			}},
+		},
+	}
+}
+
+func TestToMessage(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	m := toMessage(deadLettered(`{"id":1}`, 2, "order.created", at))
+
+	if m.RoutingKey != "order.created.acme" || m.DeathQueue != "order.created" || m.DeathReason != "rejected" ||
+		m.DeathCount != 2 || !m.DeadLetteredAt.Equal(at) || m.Body != `{"id":1}` {
+		t.Errorf("message = %+v", m)
+	}
+	if _, ok := m.Headers["x-death"]; ok {
+		t.Error("x-death is shown as a header")
+	}
+}
+
+func TestToMessageID(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	base := toMessage(deadLettered(`{"id":1}`, 1, "order.created", at)).ID
+
+	if again := toMessage(deadLettered(`{"id":1}`, 1, "order.created", at)).ID; again != base {
+		t.Errorf("id changed between reads: %s != %s", again, base)
+	}
+
+	tests := map[string]amqp.Delivery{
+		"body":        deadLettered(`{"id":2}`, 1, "order.created", at),
+		"death count": deadLettered(`{"id":1}`, 2, "order.created", at),
+		"death queue": deadLettered(`{"id":1}`, 1, "order.status.changed", at),
+		"time":        deadLettered(`{"id":1}`, 1, "order.created", at.Add(time.Second)),
+	}
+	for name, d := range tests {
+		if id := toMessage(d).ID; id == base {
+			t.Errorf("changing the %s doesn't change the id", name)
+		}
+	}
+
+	withMessageID := deadLettered(`{"id":1}`, 1, "order.created", at)
+	withMessageID.MessageId = "m-1"
+	if toMessage(withMessageID).ID == base {
+		t.Error("the message id doesn't change the id")
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	m := Message{RoutingKey: "order.created.acme", Body: `{"order_id":42}`, DeadLetteredAt: at}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"empty", Filter{}, true},
+		{"exact routing key", Filter{RoutingKey: "order.created.acme"}, true},
+		{"routing key prefix", Filter{RoutingKey: "order.created"}, true},
+		{"partial segment", Filter{RoutingKey: "order.create"}, false},
+		{"other routing key", Filter{RoutingKey: "order.status.changed"}, false},
+		{"inside time range", Filter{From: at.Add(-time.Minute), To: at.Add(time.Minute)}, true},
+		{"before from", Filter{From: at.Add(time.Minute)}, false},
+		{"after to", Filter{To: at.Add(-time.Minute)}, false},
+		{"body contains", Filter{BodyContains: `"order_id":42`}, true},
+		{"body doesn't contain", Filter{BodyContains: `"order_id":43`}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Match(m); got != tt.want {
+			t.Errorf("%s: Match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSelectionMatcher(t *testing.T) {
+	a := Message{ID: "a", RoutingKey: "order.created"}
+	b := Message{ID: "b", RoutingKey: "order.status.changed"}
+
+	tests := []struct {
+		name  string
+		sel   Selection
+		wantA bool
+		wantB bool
+	}{
+		{"nothing selected", Selection{}, false, false},
+		{"by id", Selection{IDs: []string{"b"}}, false, true},
+		{"all", Selection{All: true}, true, true},
+		{"all matching filter", Selection{All: true, Filter: Filter{RoutingKey: "order.created"}}, true, false},
+		{"id outside filter", Selection{IDs: []string{"b"}, Filter: Filter{RoutingKey: "order.created"}}, false, false},
+	}
+	for _, tt := range tests {
+		match := tt.sel.matcher()
+		if match(a) != tt.wantA || match(b) != tt.wantB {
+			t.Errorf("%s: a = %v, b = %v; want %v, %v", tt.name, match(a), match(b), tt.wantA, tt.wantB)
+		}
+	}
+}
+
+func TestAmbiguousIDs(t *testing.T) {
+	if err := ambiguousIDs(map[string]int{"a": 1, "b": 1}); err != nil {
+		t.Errorf("unique ids: %v", err)
+	}
+	err := ambiguousIDs(map[string]int{"a": 1, "b": 2, "c": 3})
+	if !errors.Is(err, ErrAmbiguousID) {
+		t.Fatalf("err = %v, want ErrAmbiguousID", err)
+	}
+	if got := err.Error(); got != "id matches more than one message: b, c; select them with a filter and all instead" {
+		t.Errorf("err = %q", got)
+	}
+}
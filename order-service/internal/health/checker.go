@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	checkTimeout  = 2 * time.Second
+	watchInterval = 5 * time.Second
+)
+
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the readiness checks of a process. Liveness does not depend on them:
+// a process that is up but can't reach its dependencies should be taken out of
+// rotation, not restarted.
+type Checker struct {
+	mu     sync.RWMutex
+	checks []namedCheck
+}
+
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Run executes all checks concurrently and reports each one's result.
+func (c *Checker) Run(ctx context.Context) (map[string]string, bool) {
+	c.mu.RLock()
+	checks := c.checks
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		ready   = true
+		results = make(map[string]string, len(checks))
+	)
+	for _, nc := range checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+			err := nc.check(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				results[nc.name] = err.Error()
+				ready = false
+				return
+			}
+			results[nc.name] = "ok"
+		}(nc)
+	}
+	wg.Wait()
+
+	return results, ready
+}
+
+// Watch re-runs the checks until ctx is done and reports every result to onChange.
+func (c *Checker) Watch(ctx context.Context, onChange func(ready bool)) {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		_, ready := c.Run(ctx)
+		onChange(ready)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, "ok", nil)
+	})
+}
+
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results, ready := c.Run(r.Context())
+		if !ready {
+			writeStatus(w, http.StatusServiceUnavailable, "unavailable", results)
+			return
+		}
+		writeStatus(w, http.StatusOK, "ok", results)
+	})
+}
+
+func writeStatus(w http.ResponseWriter, code int, status string, checks map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks,omitempty"`
+	}{Status: status, Checks: checks})
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// componentCore filters entries by the level of the named logger that wrote them, falling
+// back to the shared level. Names are matched on their first segment, so a level set for
+// "consumer" also applies to "consumer.orders". Names are case-insensitive because viper
+// lowercases map keys.
+type componentCore struct {
+	zapcore.Core
+	levels map[string]zapcore.Level
+	min    zapcore.Level
+}
+
+func newComponentCore(core zapcore.Core, levels map[string]zapcore.Level) zapcore.Core {
+	min := zapcore.InvalidLevel
+	for _, l := range levels {
+		if min == zapcore.InvalidLevel || l < min {
+			min = l
+		}
+	}
+	return &componentCore{Core: core, levels: levels, min: min}
+}
+
+func (c *componentCore) Enabled(l zapcore.Level) bool {
+	if level.Enabled(l) {
+		return true
+	}
+	return c.min != zapcore.InvalidLevel && l >= c.min
+}
+
+func (c *componentCore) With(fields []zapcore.Field) zapcore.Core {
+	return &componentCore{Core: c.Core.With(fields), levels: c.levels, min: c.min}
+}
+
+func (c *componentCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levelFor(ent.LoggerName).Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func (c *componentCore) levelFor(name string) zapcore.LevelEnabler {
+	if len(c.levels) > 0 && name != "" {
+		first, _, _ := strings.Cut(name, ".")
+		if l, ok := c.levels[strings.ToLower(first)]; ok {
+			return l
+		}
+	}
+	return level
+}
@@ -2,20 +2,88 @@ package logger
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func NewLogger() (*zap.SugaredLogger, error) {
-	cfg := zap.NewProductionConfig()
-	cfg.EncoderConfig.TimeKey = "ts"
-	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+// level is shared by every logger built here, so it can be changed at runtime.
+var level = zap.NewAtomicLevelAt(zap.InfoLevel)
 
-	l, err := cfg.Build()
-	if err != nil {
-		return nil, fmt.Errorf("error creating logger: %w", err)
+func NewLogger(cfg settings.LoggingSettings) (*zap.SugaredLogger, error) {
+	if err := SetLevel(cfg.Level); err != nil {
+		return nil, err
 	}
 
+	components := make(map[string]zapcore.Level, len(cfg.Components))
+	for name, text := range cfg.Components {
+		var l zapcore.Level
+		if err := l.UnmarshalText([]byte(text)); err != nil {
+			return nil, fmt.Errorf("log level for %s: %w", name, err)
+		}
+		components[strings.ToLower(name)] = l
+	}
+
+	encCfg := zap.NewProductionEncoderConfig()
+	encCfg.TimeKey = "ts"
+	encCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	var enc zapcore.Encoder
+	switch cfg.Format {
+	case "", "json":
+		enc = zapcore.NewJSONEncoder(encCfg)
+	case "console":
+		encCfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		enc = zapcore.NewConsoleEncoder(encCfg)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	var outputs []zapcore.WriteSyncer
+	if cfg.Output.Console {
+		outputs = append(outputs, zapcore.Lock(os.Stderr))
+	}
+	if cfg.Output.File.Path != "" {
+		outputs = append(outputs, zapcore.AddSync(&lumberjack.Logger{
+			Filename:   cfg.Output.File.Path,
+			MaxSize:    cfg.Output.File.MaxSizeMb,
+			MaxBackups: cfg.Output.File.MaxBackups,
+			MaxAge:     cfg.Output.File.MaxAgeDays,
+			Compress:   cfg.Output.File.Compress,
+		}))
+	}
+
+	var core zapcore.Core = zapcore.NewCore(enc, zapcore.NewMultiWriteSyncer(outputs...), zapcore.DebugLevel)
+	if cfg.Redaction.Enabled && len(cfg.Redaction.Fields) > 0 {
+		core = newRedactCore(core, cfg.Redaction.Fields)
+	}
+	if cfg.Sampling.Enabled {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, cfg.Sampling.Initial, cfg.Sampling.Thereafter)
+	}
+	core = newComponentCore(core, components)
+
+	l := zap.New(core,
+		zap.AddCaller(),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+	)
 	return l.Sugar(), nil
 }
+
+func SetLevel(text string) error {
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(text)); err != nil {
+		return fmt.Errorf("log level: %w", err)
+	}
+	level.SetLevel(l)
+	return nil
+}
+
+func Level() string {
+	return level.Level().String()
+}
@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const redactedValue = "[REDACTED]"
+
+var fieldNameReplacer = strings.NewReplacer("_", "", "-", "")
+
+// redactCore masks configured fields before entries reach the encoder. Structured values
+// (protobuf messages, structs, JSON message bodies) are walked through their JSON form,
+// so a nested delivery_address is masked as well as a top-level one.
+type redactCore struct {
+	zapcore.Core
+	fields map[string]struct{}
+}
+
+func newRedactCore(core zapcore.Core, fields []string) zapcore.Core {
+	set := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		set[normalizeFieldName(f)] = struct{}{}
+	}
+	return &redactCore{Core: core, fields: set}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redact(fields)), fields: c.fields}
+}
+
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.redact(fields))
+}
+
+func (c *redactCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		redacted, changed := c.redactField(f)
+		if !changed {
+			if out != nil {
+				out = append(out, f)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, i, len(fields))
+			copy(out, fields[:i])
+		}
+		out = append(out, redacted)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+func (c *redactCore) redactField(f zapcore.Field) (zapcore.Field, bool) {
+	if c.matches(f.Key) {
+		return zap.String(f.Key, redactedValue), true
+	}
+
+	switch f.Type {
+	case zapcore.ReflectType, zapcore.StringerType, zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+		if f.Interface == nil {
+			return f, false
+		}
+		raw, err := json.Marshal(f.Interface)
+		if err != nil {
+			return f, false
+		}
+		if v, ok := c.redactJSON(raw); ok {
+			return zap.Any(f.Key, v), true
+		}
+	case zapcore.StringType, zapcore.ByteStringType:
+		s := f.String
+		if f.Type == zapcore.ByteStringType {
+			b, _ := f.Interface.([]byte)
+			s = string(b)
+		}
+		if !looksLikeJSON(s) {
+			return f, false
+		}
+		if v, ok := c.redactJSON([]byte(s)); ok {
+			raw, _ := json.Marshal(v)
+			return zap.String(f.Key, string(raw)), true
+		}
+	}
+	return f, false
+}
+
+func (c *redactCore) redactJSON(raw []byte) (any, bool) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, false
+	}
+	changed := c.redactValue(v)
+	return v, changed
+}
+
+func (c *redactCore) redactValue(v any) bool {
+	changed := false
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if c.matches(k) {
+				t[k] = redactedValue
+				changed = true
+				continue
+			}
+			if c.redactValue(child) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, child := range t {
+			if c.redactValue(child) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func (c *redactCore) matches(key string) bool {
+	_, ok := c.fields[normalizeFieldName(key)]
+	return ok
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(fieldNameReplacer.Replace(name))
+}
+
+func looksLikeJSON(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[")
+}
@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type webhookSubscription struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+func TestRedactCore(t *testing.T) {
+	tests := []struct {
+		name  string
+		field zapcore.Field
+		want  any
+	}{
+		{"configured key", zap.String("password", "hunter2"), redactedValue},
+		{"key spelled differently", zap.String("Delivery-Address", "Lenina 1"), redactedValue},
+		{"camel case key", zap.Int("deliveryAddress", 1), redactedValue},
+		{"other key", zap.String("order_id", "42"), "42"},
+		{"plain string", zap.String("body", "secret stuff"), "secret stuff"},
+		{"json body", zap.String("body", `{"order":{"delivery_address":"Lenina 1","id":42}}`), `{"order":{"delivery_address":"[REDACTED]","id":42}}`},
+		{"json array body", zap.ByteString("body", []byte(`[{"token":"abc"},{"id":1}]`)), `[{"token":"[REDACTED]"},{"id":1}]`},
+		{"json body without secrets", zap.String("body", `{"id":42}`), `{"id":42}`},
+		{"struct", zap.Any("subscription", webhookSubscription{URL: "https://example.com", Secret: "whsec"}), map[string]any{"url": "https://example.com", "secret": redactedValue}},
+		{"slice of structs", zap.Any("subscriptions", []webhookSubscription{{Secret: "a"}}), []any{map[string]any{"url": "", "secret": redactedValue}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			log := zap.New(newRedactCore(core, []string{"password", "delivery_address", "secret", "token"}))
+
+			log.Info("test", tt.field)
+
+			entries := logs.All()
+			if len(entries) != 1 {
+				t.Fatalf("entries = %d, want 1", len(entries))
+			}
+			got := entries[0].ContextMap()[tt.field.Key]
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %#v, want %#v", tt.field.Key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactCoreWith(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	log := zap.New(newRedactCore(core, []string{"token"})).With(zap.String("token", "abc"), zap.String("queue", "orders"))
+
+	log.Info("test")
+
+	fields := logs.All()[0].ContextMap()
+	if fields["token"] != redactedValue || fields["queue"] != "orders" {
+		t.Errorf("fields = %v, want token redacted and queue kept", fields)
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "oms"
+
+var (
+	GrpcRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handled_total",
+		Help:      "RPCs completed on the server, by method and status code.",
+	}, []string{"method", "code"})
+
+	GrpcRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handling_seconds",
+		Help:      "RPC handling latency, by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	PublisherMessagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "publisher",
+		Name:      "messages_total",
+		Help:      "Messages handed to RabbitMQ, by exchange and result (published, failed).",
+	}, []string{"exchange", "result"})
+
+	ConsumerBatchSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "batch_size",
+		Help:      "Messages per processed batch.",
+		Buckets:   []float64{1, 5, 10, 20, 50, 100, 200, 500},
+	}, []string{"queue"})
+
+	ConsumerProcessingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "processing_seconds",
+		Help:      "Time spent in the message processor per batch.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"queue"})
+
+	ConsumerMessagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "messages_total",
+		Help:      "Messages settled by the consumer, by queue and result (ack, requeue, retry, dead_letter).",
+	}, []string{"queue", "result"})
+
+	ConsumerBufferDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "buffer_depth",
+		Help:      "Messages received and waiting for the next batch.",
+	}, []string{"queue"})
+
+	ConsumerReconnectAttemptsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "reconnect_attempts_total",
+		Help:      "Reconnect attempts made after the consume loop failed.",
+	}, []string{"queue"})
+
+	StaleOrdersSkippedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "stale_order_canceller",
+		Name:      "orders_skipped_total",
+		Help:      "Stale orders skipped because they left the status before being cancelled.",
+	}, []string{"status"})
+)
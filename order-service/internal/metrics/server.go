@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server exposes /metrics, plus any extra handlers such as health probes, for
+// processes that have no HTTP server of their own.
+type Server struct {
+	srv *http.Server
+}
+
+func NewServer(port int, handlers map[string]http.Handler) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	for pattern, h := range handlers {
+		mux.Handle(pattern, h)
+	}
+
+	return &Server{srv: &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: mux,
+	}}
+}
+
+func (s *Server) Start() error {
+	return s.srv.ListenAndServe()
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
+
+func (s *Server) Addr() string {
+	return s.srv.Addr
+}
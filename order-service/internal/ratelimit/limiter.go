@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"golang.org/x/time/rate"
+)
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter keeps one token bucket per key. Buckets that were not touched for the idle
+// ttl are dropped, so a burst of distinct clients does not grow the map forever.
+type Limiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	idleTtl   time.Duration
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func New(idleTtl time.Duration) *Limiter {
+	if idleTtl <= 0 {
+		idleTtl = 10 * time.Minute
+	}
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		idleTtl: idleTtl,
+		now:     time.Now,
+	}
+}
+
+// Allow takes n tokens from the key's bucket. When the bucket is empty it returns the
+// time after which the same request would be admitted.
+func (l *Limiter) Allow(key string, rule settings.RateLimitRule, n int) (bool, time.Duration) {
+	if rule.RequestsPerSecond <= 0 || n <= 0 {
+		return true, 0
+	}
+
+	now := l.now()
+	lim := l.get(key, rule, now)
+
+	burst := lim.Burst()
+	if n > burst {
+		// Can never fit in the bucket; report how long a full refill would take.
+		return false, durationFor(float64(n), rule.RequestsPerSecond)
+	}
+
+	r := lim.ReserveN(now, n)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+func (l *Limiter) get(key string, rule settings.RateLimitRule, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > l.idleTtl {
+		for k, b := range l.buckets {
+			if now.Sub(b.lastSeen) > l.idleTtl {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	burst := rule.Burst
+	if burst <= 0 {
+		burst = int(math.Ceil(rule.RequestsPerSecond))
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(rule.RequestsPerSecond), burst)}
+		l.buckets[key] = b
+	} else if b.limiter.Limit() != rate.Limit(rule.RequestsPerSecond) || b.limiter.Burst() != burst {
+		b.limiter.SetLimitAt(now, rate.Limit(rule.RequestsPerSecond))
+		b.limiter.SetBurstAt(now, burst)
+	}
+	b.lastSeen = now
+	return b.limiter
+}
+
+func durationFor(tokens, perSecond float64) time.Duration {
+	return time.Duration(tokens / perSecond * float64(time.Second))
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func newTestLimiter(idleTtl time.Duration) (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(idleTtl)
+	l.now = clock.Now
+	return l, clock
+}
+
+type step struct {
+	advance        time.Duration
+	key            string
+	n              int
+	wantAllowed    bool
+	wantRetryAfter time.Duration
+}
+
+func TestAllow(t *testing.T) {
+	rule := settings.RateLimitRule{RequestsPerSecond: 2, Burst: 3}
+	tests := []struct {
+		name  string
+		rule  settings.RateLimitRule
+		steps []step
+	}{
+		{"burst then empty", rule, []step{
+			{0, "a", 1, true, 0},
+			{0, "a", 1, true, 0},
+			{0, "a", 1, true, 0},
+			{0, "a", 1, false, 500 * time.Millisecond},
+		}},
+		{"refill", rule, []step{
+			{0, "a", 3, true, 0},
+			{0, "a", 1, false, 500 * time.Millisecond},
+			{500 * time.Millisecond, "a", 1, true, 0},
+			{0, "a", 2, false, time.Second},
+			{10 * time.Second, "a", 3, true, 0},
+		}},
+		{"refill is capped at the burst", rule, []step{
+			{time.Hour, "a", 3, true, 0},
+			{0, "a", 1, false, 500 * time.Millisecond},
+		}},
+		{"keys are isolated", rule, []step{
+			{0, "a", 3, true, 0},
+			{0, "a", 1, false, 500 * time.Millisecond},
+			{0, "b", 3, true, 0},
+			{0, "b", 1, false, 500 * time.Millisecond},
+		}},
+		{"request larger than the burst", rule, []step{
+			{0, "a", 4, false, 2 * time.Second},
+			{0, "a", 3, true, 0},
+		}},
+		{"denied request takes no tokens", rule, []step{
+			{0, "a", 2, true, 0},
+			{0, "a", 2, false, 500 * time.Millisecond},
+			{0, "a", 1, true, 0},
+		}},
+		{"default burst is the rate rounded up", settings.RateLimitRule{RequestsPerSecond: 1.5}, []step{
+			{0, "a", 2, true, 0},
+			{0, "a", 1, false, 666666666 * time.Nanosecond},
+		}},
+		{"no rate means no limit", settings.RateLimitRule{}, []step{
+			{0, "a", 1000, true, 0},
+			{0, "a", 1000, true, 0},
+		}},
+		{"nothing requested", rule, []step{
+			{0, "a", 3, true, 0},
+			{0, "a", 0, true, 0},
+		}},
+	}
+	for _, tt := range tests {
+		l, clock := newTestLimiter(time.Hour)
+		for i, s := range tt.steps {
+			clock.Advance(s.advance)
+			allowed, retryAfter := l.Allow(s.key, tt.rule, s.n)
+			if allowed != s.wantAllowed || retryAfter.Round(time.Millisecond) != s.wantRetryAfter.Round(time.Millisecond) {
+				t.Errorf("%s: step %d: Allow(%s, %d) = %v, %v; want %v, %v",
+					tt.name, i+1, s.key, s.n, allowed, retryAfter, s.wantAllowed, s.wantRetryAfter)
+			}
+		}
+	}
+}
+
+func TestAllowRuleChange(t *testing.T) {
+	l, _ := newTestLimiter(time.Hour)
+	if allowed, _ := l.Allow("a", settings.RateLimitRule{RequestsPerSecond: 1, Burst: 1}, 1); !allowed {
+		t.Fatal("first request denied")
+	}
+	if allowed, _ := l.Allow("a", settings.RateLimitRule{RequestsPerSecond: 1, Burst: 1}, 1); allowed {
+		t.Fatal("second request allowed")
+	}
+	// A raised limit applies to the existing bucket.
+	if allowed, _ := l.Allow("a", settings.RateLimitRule{RequestsPerSecond: 1, Burst: 5}, 5); allowed {
+		t.Error("raised burst is filled immediately")
+	}
+	if allowed, _ := l.Allow("a", settings.RateLimitRule{RequestsPerSecond: 1, Burst: 5}, 6); allowed {
+		t.Error("request above the new burst allowed")
+	}
+}
+
+func TestIdleBucketsAreDropped(t *testing.T) {
+	l, clock := newTestLimiter(time.Minute)
+	rule := settings.RateLimitRule{RequestsPerSecond: 1, Burst: 1}
+
+	l.Allow("idle", rule, 1)
+	clock.Advance(30 * time.Second)
+	l.Allow("active", rule, 1)
+	clock.Advance(45 * time.Second)
+	l.Allow("active", rule, 1)
+
+	if _, ok := l.buckets["idle"]; ok {
+		t.Error("idle bucket was kept")
+	}
+	if _, ok := l.buckets["active"]; !ok {
+		t.Error("active bucket was dropped")
+	}
+}
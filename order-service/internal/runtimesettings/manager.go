@@ -0,0 +1,127 @@
+package runtimesettings
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ADMIN_TOKEN_HEADER = "X-Admin-Token"
+	maxBodyLength      = 1 << 20
+)
+
+// Manager owns the live runtime settings. Every change, whether it comes from a watched
+// config file or the admin endpoint, goes through Update, so apply is never called
+// concurrently and each change is logged once.
+type Manager struct {
+	mu      sync.Mutex
+	current Settings
+	file    Settings
+	apply   func(Settings) error
+	log     *zap.SugaredLogger
+}
+
+func NewManager(initial Settings, apply func(Settings) error, log *zap.SugaredLogger) *Manager {
+	return &Manager{
+		current: initial.clone(),
+		file:    initial.clone(),
+		apply:   apply,
+		log:     log,
+	}
+}
+
+func (m *Manager) Current() Settings {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current.clone()
+}
+
+func (m *Manager) Update(next Settings, source string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(next, source)
+}
+
+// FileChanged applies the fields that differ from the previous file contents.
+func (m *Manager) FileChanged(next Settings) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.update(merge(m.current, m.file, next), "file"); err != nil {
+		return err
+	}
+	m.file = next.clone()
+	return nil
+}
+
+func (m *Manager) update(next Settings, source string) error {
+	if err := validate(m.current, next); err != nil {
+		return err
+	}
+
+	changes := diff(m.current, next)
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := m.apply(next); err != nil {
+		return fmt.Errorf("apply runtime settings: %w", err)
+	}
+
+	for _, c := range changes {
+		m.log.Infow("runtime_settings.changed", "source", source, "field", c.field, "old", c.old, "new", c.new)
+	}
+	m.current = next.clone()
+	return nil
+}
+
+func (m *Manager) patch(body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	next := m.current.clone()
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&next); err != nil {
+		return fmt.Errorf("decode body: %w", err)
+	}
+	return m.update(next, "admin")
+}
+
+// Handler serves GET and PUT for the runtime settings. PUT takes a partial document that
+// is merged over the current settings. An empty token lets nobody in.
+func (m *Manager) Handler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get(ADMIN_TOKEN_HEADER)), []byte(token)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyLength))
+			if err != nil {
+				http.Error(w, "failed to read body", http.StatusBadRequest)
+				return
+			}
+
+			if err := m.patch(body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(m.Current())
+	})
+}
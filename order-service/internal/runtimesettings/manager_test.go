@@ -0,0 +1,24 @@
+package runtimesettings
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestHandlerEmptyTokenLetsNobodyIn(t *testing.T) {
+	m := NewManager(Settings{LogLevel: "info"}, func(Settings) error { return nil }, zap.NewNop().Sugar())
+	h := m.Handler("")
+
+	for _, header := range []string{"", "anything"} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/settings", nil)
+		req.Header.Set(ADMIN_TOKEN_HEADER, header)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("header %q: status = %d, want %d", header, w.Code, http.StatusUnauthorized)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package runtimesettings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"go.uber.org/zap/zapcore"
+)
+
+// Settings is the subset of the configuration that can be changed without a restart.
+// Fields an app doesn't have are left nil.
+type Settings struct {
+	LogLevel  string
+	RateLimit *settings.RateLimitSettings `json:",omitempty"`
+	Consumers map[string]ConsumerBatch    `json:",omitempty"`
+}
+
+type ConsumerBatch struct {
+	BatchSize           int
+	BatchTimeoutSeconds int
+}
+
+type change struct {
+	field    string
+	old, new any
+}
+
+func (s Settings) clone() Settings {
+	out := Settings{LogLevel: s.LogLevel}
+	if s.RateLimit != nil {
+		rl := *s.RateLimit
+		rl.Methods = append([]settings.MethodRateLimit(nil), s.RateLimit.Methods...)
+		rl.Clients = append([]settings.ClientQuota(nil), s.RateLimit.Clients...)
+		out.RateLimit = &rl
+	}
+	if s.Consumers != nil {
+		out.Consumers = make(map[string]ConsumerBatch, len(s.Consumers))
+		for name, c := range s.Consumers {
+			out.Consumers[name] = c
+		}
+	}
+	return out
+}
+
+// validate checks next against the shape of current: an update can change values but
+// can't add sections the app doesn't run.
+func validate(current, next Settings) error {
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(next.LogLevel)); err != nil {
+		return fmt.Errorf("LogLevel: %w", err)
+	}
+
+	if (current.RateLimit == nil) != (next.RateLimit == nil) {
+		return fmt.Errorf("RateLimit: not configurable in this app")
+	}
+	if rl := next.RateLimit; rl != nil {
+		rules := map[string]settings.RateLimitRule{"Default": rl.Default, "Orders": rl.Orders}
+		for _, m := range rl.Methods {
+			rules["Methods."+m.Method] = m.Limit
+		}
+		for _, c := range rl.Clients {
+			rules["Clients."+c.Client+".Requests"] = c.Requests
+			rules["Clients."+c.Client+".Orders"] = c.Orders
+		}
+		for name, rule := range rules {
+			if rule.RequestsPerSecond < 0 || rule.Burst < 0 {
+				return fmt.Errorf("RateLimit.%s: must not be negative", name)
+			}
+		}
+	}
+
+	for name, c := range next.Consumers {
+		if _, ok := current.Consumers[name]; !ok {
+			return fmt.Errorf("Consumers.%s: unknown consumer", name)
+		}
+		if c.BatchSize <= 0 {
+			return fmt.Errorf("Consumers.%s.BatchSize: must be positive", name)
+		}
+		if c.BatchTimeoutSeconds <= 0 {
+			return fmt.Errorf("Consumers.%s.BatchTimeoutSeconds: must be positive", name)
+		}
+	}
+	if len(next.Consumers) != len(current.Consumers) {
+		return fmt.Errorf("Consumers: every consumer must be present")
+	}
+	return nil
+}
+
+func diff(old, next Settings) []change {
+	var changes []change
+	if old.LogLevel != next.LogLevel {
+		changes = append(changes, change{"LogLevel", old.LogLevel, next.LogLevel})
+	}
+	if !sameJSON(old.RateLimit, next.RateLimit) {
+		changes = append(changes, change{"RateLimit", old.RateLimit, next.RateLimit})
+	}
+
+	names := make([]string, 0, len(next.Consumers))
+	for name := range next.Consumers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if old.Consumers[name] != next.Consumers[name] {
+			changes = append(changes, change{"Consumers." + name, old.Consumers[name], next.Consumers[name]})
+		}
+	}
+	return changes
+}
+
+// merge applies only what changed between two snapshots of the same source onto current,
+// so a file reload doesn't revert values set through the admin endpoint.
+func merge(current, prev, next Settings) Settings {
+	out := current.clone()
+	if prev.LogLevel != next.LogLevel {
+		out.LogLevel = next.LogLevel
+	}
+	if !sameJSON(prev.RateLimit, next.RateLimit) {
+		out.RateLimit = next.clone().RateLimit
+	}
+	for name, c := range next.Consumers {
+		if prev.Consumers[name] != c {
+			if out.Consumers == nil {
+				out.Consumers = make(map[string]ConsumerBatch)
+			}
+			out.Consumers[name] = c
+		}
+	}
+	return out
+}
+
+func sameJSON(a, b any) bool {
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return bytes.Equal(ja, jb)
+}
@@ -0,0 +1,75 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/client/payment"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"go.uber.org/zap"
+)
+
+const dataPaymentID = "payment_id"
+
+type AuthorizePaymentStep struct {
+	pgClient *postgres.PostgresClient
+	provider payment.PaymentProvider
+	log      *zap.SugaredLogger
+}
+
+func NewAuthorizePaymentStep(pgClient *postgres.PostgresClient, provider payment.PaymentProvider, log *zap.SugaredLogger) *AuthorizePaymentStep {
+	return &AuthorizePaymentStep{
+		pgClient: pgClient,
+		provider: provider,
+		log:      log,
+	}
+}
+
+func (s *AuthorizePaymentStep) Name() string {
+	return STEP_AUTHORIZE_PAYMENT
+}
+
+func (s *AuthorizePaymentStep) Execute(ctx context.Context, sc *StepContext) error {
+	paymentSvc := s.createBllPaymentService()
+	defer paymentSvc.UnitOfWork().Close()
+
+	p, err := paymentSvc.Authorize(ctx, sc.Order)
+	if err != nil {
+		return err
+	}
+
+	sc.Data[dataPaymentID] = strconv.FormatInt(p.ID, 10)
+	if p.Status == bll.PAYMENT_STATUS_FAILED {
+		return fmt.Errorf("%w: %s", ErrStepRejected, p.FailureReason)
+	}
+	return nil
+}
+
+func (s *AuthorizePaymentStep) Compensate(ctx context.Context, sc *StepContext) error {
+	raw := sc.Data[dataPaymentID]
+	if raw == "" {
+		return nil
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", dataPaymentID, raw, err)
+	}
+
+	paymentSvc := s.createBllPaymentService()
+	defer paymentSvc.UnitOfWork().Close()
+
+	return paymentSvc.Void(ctx, id)
+}
+
+// Order statuses are owned by the orchestrator, so the payment service gets no order service.
+func (s *AuthorizePaymentStep) createBllPaymentService() *bllServices.PaymentService {
+	uow := unitofwork.New(s.pgClient)
+	paymentRepo := repositories.NewPaymentRepository(uow)
+	orderRepo := repositories.NewOrderRepository(uow)
+	return bllServices.NewPaymentService(uow, paymentRepo, orderRepo, s.provider, nil, s.log)
+}
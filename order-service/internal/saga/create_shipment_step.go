@@ -0,0 +1,53 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/client/shipping"
+)
+
+const dataShipmentID = "shipment_id"
+
+type CreateShipmentStep struct {
+	provider shipping.ShippingProvider
+}
+
+func NewCreateShipmentStep(provider shipping.ShippingProvider) *CreateShipmentStep {
+	return &CreateShipmentStep{provider: provider}
+}
+
+func (s *CreateShipmentStep) Name() string {
+	return STEP_CREATE_SHIPMENT
+}
+
+func (s *CreateShipmentStep) Execute(ctx context.Context, sc *StepContext) error {
+	if sc.Data[dataShipmentID] != "" {
+		return nil
+	}
+
+	id, err := s.provider.CreateShipment(ctx, shipping.CreateShipmentRequest{
+		OrderID:         sc.Order.ID,
+		DeliveryAddress: sc.Order.DeliveryAddress,
+		ItemsCount:      len(sc.Order.OrderItems),
+		IdempotencyKey:  fmt.Sprintf("order-%d", sc.Order.ID),
+	})
+	if err != nil {
+		if errors.Is(err, shipping.ErrRejected) {
+			return fmt.Errorf("%w: %v", ErrStepRejected, err)
+		}
+		return err
+	}
+
+	sc.Data[dataShipmentID] = id
+	return nil
+}
+
+func (s *CreateShipmentStep) Compensate(ctx context.Context, sc *StepContext) error {
+	id := sc.Data[dataShipmentID]
+	if id == "" {
+		return nil
+	}
+	return s.provider.CancelShipment(ctx, id)
+}
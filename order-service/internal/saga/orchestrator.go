@@ -0,0 +1,246 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	publisher "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/publisher/rabbitmq"
+	"go.uber.org/zap"
+)
+
+// Orchestrator drives an order through the configured steps. The saga row is saved after
+// every step, so a restarted process continues from the last recorded position. A saga
+// only runs while its replica holds the claim on it.
+type Orchestrator struct {
+	cfg   *settings.SagaSettings
+	store store
+	steps map[string]Step
+	log   *zap.SugaredLogger
+}
+
+func NewOrchestrator(
+	cfg *settings.SagaSettings,
+	inventorySettings settings.InventorySettings,
+	pgClient *postgres.PostgresClient,
+	omsPublisher *publisher.Publisher,
+	steps []Step,
+	log *zap.SugaredLogger,
+) (*Orchestrator, error) {
+	byName := make(map[string]Step, len(steps))
+	for _, st := range steps {
+		byName[st.Name()] = st
+	}
+	for _, name := range cfg.Steps {
+		if _, ok := byName[name]; !ok {
+			return nil, fmt.Errorf("unknown saga step %q", name)
+		}
+	}
+
+	return &Orchestrator{
+		cfg: cfg,
+		store: &postgresStore{
+			inventorySettings: inventorySettings,
+			pgClient:          pgClient,
+			omsPublisher:      omsPublisher,
+			log:               log,
+		},
+		steps: byName,
+		log:   log,
+	}, nil
+}
+
+// Start creates a saga for the order and runs it. An order that already has a saga in
+// flight, left by a start that failed partway, gets that saga run again; finished ones
+// are skipped.
+func (o *Orchestrator) Start(ctx context.Context, orderID int64) error {
+	saga, created, err := o.store.CreateSaga(ctx, orderID, o.cfg.Steps)
+	if err != nil {
+		return err
+	}
+	if !created {
+		var found bool
+		if saga, found, err = o.store.GetOrderSaga(ctx, orderID); err != nil || !found {
+			return err
+		}
+	}
+
+	return o.runClaimed(ctx, saga.ID)
+}
+
+func (o *Orchestrator) Resume(ctx context.Context) error {
+	sagas, err := o.store.GetInFlightSagas(ctx)
+	if err != nil {
+		return err
+	}
+
+	o.log.Infow("saga_orchestrator.resume_start", "sagas_count", len(sagas))
+	for _, saga := range sagas {
+		if err := o.runClaimed(ctx, saga.ID); err != nil {
+			o.log.Errorw("saga_orchestrator.resume_saga_failed", "saga_id", saga.ID, "order_id", saga.OrderID, "err", err)
+		}
+	}
+	return nil
+}
+
+// runClaimed runs the saga if no other replica holds it. The saga is re-read once claimed,
+// since whoever held it before may have moved it on or finished it.
+func (o *Orchestrator) runClaimed(ctx context.Context, sagaID int64) error {
+	l := o.log.With("saga_id", sagaID)
+
+	release, claimed, err := o.store.Claim(ctx, sagaID, l)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		l.Infow("saga_orchestrator.saga_claimed_elsewhere")
+		return nil
+	}
+	defer release()
+
+	saga, found, err := o.store.GetSaga(ctx, sagaID)
+	if err != nil {
+		return err
+	}
+	if !found || (saga.Status != bll.SAGA_STATUS_RUNNING && saga.Status != bll.SAGA_STATUS_COMPENSATING) {
+		l.Infow("saga_orchestrator.saga_no_longer_in_flight", "found", found, "status", saga.Status)
+		return nil
+	}
+
+	return o.run(ctx, saga)
+}
+
+func (o *Orchestrator) run(ctx context.Context, saga bll.Saga) error {
+	l := o.log.With("saga_id", saga.ID, "order_id", saga.OrderID)
+
+	order, err := o.store.LoadOrder(ctx, saga.OrderID, l)
+	if err != nil {
+		return err
+	}
+	sc := &StepContext{Order: order, Data: saga.Data}
+
+	if saga.Status == bll.SAGA_STATUS_RUNNING {
+		if order.Status == bll.ORDER_STATUS_CREATED {
+			if err := o.store.SetOrderStatus(ctx, order.ID, bll.ORDER_STATUS_PROCESSING, l); err != nil {
+				return o.fail(ctx, saga, sc, err, l)
+			}
+		}
+
+		for saga.CurrentStep < len(saga.Steps) {
+			step := o.steps[saga.Steps[saga.CurrentStep]]
+			if step == nil {
+				return o.fail(ctx, saga, sc, fmt.Errorf("unknown saga step %q", saga.Steps[saga.CurrentStep]), l)
+			}
+
+			l.Infow("saga_orchestrator.step_start", "step", step.Name())
+			if err := o.attempt(ctx, step.Execute, sc); err != nil {
+				l.Warnw("saga_orchestrator.step_failed", "step", step.Name(), "err", err)
+				return o.fail(ctx, saga, sc, err, l)
+			}
+
+			saga.CurrentStep++
+			if saga, err = o.save(ctx, saga); err != nil {
+				return err
+			}
+			l.Infow("saga_orchestrator.step_success", "step", step.Name())
+		}
+
+		if err := o.store.SetOrderStatus(ctx, order.ID, bll.ORDER_STATUS_COMPLETED, l); err != nil {
+			return o.fail(ctx, saga, sc, err, l)
+		}
+
+		saga.Status = bll.SAGA_STATUS_COMPLETED
+		if _, err := o.save(ctx, saga); err != nil {
+			return err
+		}
+		l.Infow("saga_orchestrator.saga_completed")
+		return nil
+	}
+
+	return o.compensate(ctx, saga, sc, l)
+}
+
+func (o *Orchestrator) fail(ctx context.Context, saga bll.Saga, sc *StepContext, cause error, l *zap.SugaredLogger) error {
+	saga.Status = bll.SAGA_STATUS_COMPENSATING
+	saga.Error = cause.Error()
+
+	saga, err := o.save(ctx, saga)
+	if err != nil {
+		return err
+	}
+	return o.compensate(ctx, saga, sc, l)
+}
+
+func (o *Orchestrator) compensate(ctx context.Context, saga bll.Saga, sc *StepContext, l *zap.SugaredLogger) error {
+	l.Infow("saga_orchestrator.compensation_start", "completed_steps", saga.CurrentStep)
+
+	// The step at CurrentStep did not complete but may have partially applied, so it is
+	// compensated too; compensations are no-ops for work that was never done.
+	last := min(saga.CurrentStep, len(saga.Steps)-1)
+	for i := last; i >= 0; i-- {
+		step := o.steps[saga.Steps[i]]
+		if step == nil {
+			continue
+		}
+
+		if err := o.attempt(ctx, step.Compensate, sc); err != nil {
+			l.Errorw("saga_orchestrator.compensation_step_failed", "step", step.Name(), "err", err)
+			return err
+		}
+
+		saga.CurrentStep = i
+		var err error
+		if saga, err = o.save(ctx, saga); err != nil {
+			return err
+		}
+		l.Infow("saga_orchestrator.compensation_step_success", "step", step.Name())
+	}
+
+	if err := o.store.SetOrderStatus(ctx, saga.OrderID, bll.ORDER_STATUS_CANCELLED, l); err != nil {
+		l.Warnw("saga_orchestrator.cancel_order_failed", "err", err)
+	}
+
+	saga.Status = bll.SAGA_STATUS_FAILED
+	if _, err := o.save(ctx, saga); err != nil {
+		return err
+	}
+	l.Infow("saga_orchestrator.saga_failed", "reason", saga.Error)
+	return nil
+}
+
+func (o *Orchestrator) attempt(ctx context.Context, fn func(context.Context, *StepContext) error, sc *StepContext) error {
+	attempts := max(o.cfg.MaxStepAttempts, 1)
+	backoff := time.Duration(o.cfg.StepRetryBackoffMs) * time.Millisecond
+
+	var err error
+	for i := 1; i <= attempts; i++ {
+		stepCtx, cancel := context.WithTimeout(ctx, time.Duration(o.cfg.StepTimeoutSeconds)*time.Second)
+		err = fn(stepCtx, sc)
+		cancel()
+
+		if err == nil || errors.Is(err, ErrStepRejected) || i == attempts {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff * time.Duration(i)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+func (o *Orchestrator) save(ctx context.Context, saga bll.Saga) (bll.Saga, error) {
+	saved, err := o.store.SaveSaga(ctx, saga)
+	if err != nil {
+		return bll.Saga{}, err
+	}
+	// Keep sharing the map with the step context so later step output is persisted too.
+	saved.Data = saga.Data
+	return saved, nil
+}
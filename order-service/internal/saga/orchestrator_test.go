@@ -0,0 +1,288 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"go.uber.org/zap"
+)
+
+// fakeStore keeps sagas and order statuses in memory, one saga per order like the
+// unique order_id column does.
+type fakeStore struct {
+	sagas   map[int64]bll.Saga
+	orders  map[int64]bll.OrderStatus
+	claimed map[int64]bool
+	nextID  int64
+	// failSaves fails that many SaveSaga calls, counting from the next one.
+	failSaves int
+}
+
+func newFakeStore(orders ...int64) *fakeStore {
+	s := &fakeStore{sagas: map[int64]bll.Saga{}, orders: map[int64]bll.OrderStatus{}, claimed: map[int64]bool{}}
+	for _, id := range orders {
+		s.orders[id] = bll.ORDER_STATUS_CREATED
+	}
+	return s
+}
+
+func (s *fakeStore) CreateSaga(_ context.Context, orderID int64, steps []string) (bll.Saga, bool, error) {
+	if _, found, _ := s.GetOrderSaga(context.Background(), orderID); found {
+		return bll.Saga{}, false, nil
+	}
+	s.nextID++
+	saga := bll.Saga{ID: s.nextID, OrderID: orderID, Status: bll.SAGA_STATUS_RUNNING, Steps: steps, Data: map[string]string{}}
+	s.sagas[saga.ID] = saga
+	return saga, true, nil
+}
+
+func (s *fakeStore) GetSaga(_ context.Context, sagaID int64) (bll.Saga, bool, error) {
+	saga, ok := s.sagas[sagaID]
+	saga.Data = maps.Clone(saga.Data)
+	return saga, ok, nil
+}
+
+func (s *fakeStore) GetOrderSaga(ctx context.Context, orderID int64) (bll.Saga, bool, error) {
+	for id, saga := range s.sagas {
+		if saga.OrderID == orderID {
+			return s.GetSaga(ctx, id)
+		}
+	}
+	return bll.Saga{}, false, nil
+}
+
+func (s *fakeStore) GetInFlightSagas(ctx context.Context) ([]bll.Saga, error) {
+	var result []bll.Saga
+	for _, id := range slices.Sorted(maps.Keys(s.sagas)) {
+		if st := s.sagas[id].Status; st == bll.SAGA_STATUS_RUNNING || st == bll.SAGA_STATUS_COMPENSATING {
+			saga, _, _ := s.GetSaga(ctx, id)
+			result = append(result, saga)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeStore) SaveSaga(_ context.Context, saga bll.Saga) (bll.Saga, error) {
+	if s.failSaves > 0 {
+		s.failSaves--
+		return bll.Saga{}, errors.New("connection reset")
+	}
+	saga.Data = maps.Clone(saga.Data)
+	s.sagas[saga.ID] = saga
+	return saga, nil
+}
+
+func (s *fakeStore) LoadOrder(_ context.Context, orderID int64, _ *zap.SugaredLogger) (bll.OrderUnit, error) {
+	st, ok := s.orders[orderID]
+	if !ok {
+		return bll.OrderUnit{}, fmt.Errorf("order %d not found", orderID)
+	}
+	return bll.OrderUnit{ID: orderID, Status: st}, nil
+}
+
+func (s *fakeStore) SetOrderStatus(_ context.Context, orderID int64, status bll.OrderStatus, _ *zap.SugaredLogger) error {
+	if !s.orders[orderID].CanTransition(status) {
+		return fmt.Errorf("invalid transition from %s to %s", s.orders[orderID], status)
+	}
+	s.orders[orderID] = status
+	return nil
+}
+
+func (s *fakeStore) Claim(_ context.Context, sagaID int64, _ *zap.SugaredLogger) (func(), bool, error) {
+	if s.claimed[sagaID] {
+		return nil, false, nil
+	}
+	s.claimed[sagaID] = true
+	return func() { delete(s.claimed, sagaID) }, true, nil
+}
+
+// fakeStep records its calls in the shared log, as "name" and "-name" for compensations.
+type fakeStep struct {
+	name string
+	fail bool
+	log  *[]string
+}
+
+func (s *fakeStep) Name() string { return s.name }
+
+func (s *fakeStep) Execute(_ context.Context, _ *StepContext) error {
+	*s.log = append(*s.log, s.name)
+	if s.fail {
+		return fmt.Errorf("%s: %w", s.name, ErrStepRejected)
+	}
+	return nil
+}
+
+func (s *fakeStep) Compensate(_ context.Context, _ *StepContext) error {
+	*s.log = append(*s.log, "-"+s.name)
+	return nil
+}
+
+var testSteps = []string{"reserve", "pay", "ship"}
+
+// newTestOrchestrator runs testSteps, failing the one named by failAt.
+func newTestOrchestrator(store *fakeStore, failAt string) (*Orchestrator, *[]string) {
+	calls := &[]string{}
+	steps := make(map[string]Step, len(testSteps))
+	for _, name := range testSteps {
+		steps[name] = &fakeStep{name: name, fail: name == failAt, log: calls}
+	}
+	return &Orchestrator{
+		cfg:   &settings.SagaSettings{Steps: testSteps, MaxStepAttempts: 1, StepTimeoutSeconds: 1},
+		store: store,
+		steps: steps,
+		log:   zap.NewNop().Sugar(),
+	}, calls
+}
+
+func TestStartRunsSagaLeftInFlight(t *testing.T) {
+	store := newFakeStore(1)
+	o, calls := newTestOrchestrator(store, "")
+
+	// The save after the first step fails, so the saga stays running at step 0.
+	store.failSaves = 1
+	if err := o.Start(context.Background(), 1); err == nil {
+		t.Fatal("first Start succeeded, want the save error")
+	}
+	if saga, _, _ := store.GetOrderSaga(context.Background(), 1); saga.Status != bll.SAGA_STATUS_RUNNING {
+		t.Fatalf("saga status after failed start = %s, want running", saga.Status)
+	}
+
+	// What a redelivered saga message does.
+	if err := o.Start(context.Background(), 1); err != nil {
+		t.Fatalf("second Start: %v", err)
+	}
+	saga, _, _ := store.GetOrderSaga(context.Background(), 1)
+	if saga.Status != bll.SAGA_STATUS_COMPLETED {
+		t.Errorf("saga status = %s, want completed", saga.Status)
+	}
+	if st := store.orders[1]; st != bll.ORDER_STATUS_COMPLETED {
+		t.Errorf("order status = %s, want completed", st)
+	}
+	if want := []string{"reserve", "reserve", "pay", "ship"}; !slices.Equal(*calls, want) {
+		t.Errorf("calls = %v, want %v", *calls, want)
+	}
+	if len(store.claimed) != 0 {
+		t.Errorf("claims left held: %v", store.claimed)
+	}
+
+	// Once finished, another redelivery doesn't run anything.
+	*calls = nil
+	if err := o.Start(context.Background(), 1); err != nil || len(*calls) != 0 {
+		t.Errorf("Start of a finished saga = %v, calls %v", err, *calls)
+	}
+}
+
+func TestOrchestratorRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		failAt     string
+		wantCalls  []string
+		wantSaga   bll.SagaStatus
+		wantOrder  bll.OrderStatus
+		wantReason bool
+	}{
+		{"every step succeeds", "", []string{"reserve", "pay", "ship"}, bll.SAGA_STATUS_COMPLETED, bll.ORDER_STATUS_COMPLETED, false},
+		{"first step fails", "reserve", []string{"reserve", "-reserve"}, bll.SAGA_STATUS_FAILED, bll.ORDER_STATUS_CANCELLED, true},
+		{"middle step fails", "pay", []string{"reserve", "pay", "-pay", "-reserve"}, bll.SAGA_STATUS_FAILED, bll.ORDER_STATUS_CANCELLED, true},
+		{"last step fails", "ship", []string{"reserve", "pay", "ship", "-ship", "-pay", "-reserve"}, bll.SAGA_STATUS_FAILED, bll.ORDER_STATUS_CANCELLED, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore(1)
+			o, calls := newTestOrchestrator(store, tt.failAt)
+
+			if err := o.Start(context.Background(), 1); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+
+			if !slices.Equal(*calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", *calls, tt.wantCalls)
+			}
+			saga, _, _ := store.GetOrderSaga(context.Background(), 1)
+			if saga.Status != tt.wantSaga {
+				t.Errorf("saga status = %s, want %s", saga.Status, tt.wantSaga)
+			}
+			if (saga.Error != "") != tt.wantReason {
+				t.Errorf("saga error = %q", saga.Error)
+			}
+			if st := store.orders[1]; st != tt.wantOrder {
+				t.Errorf("order status = %s, want %s", st, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestOrchestratorResume(t *testing.T) {
+	tests := []struct {
+		name      string
+		saga      bll.Saga
+		order     bll.OrderStatus
+		claimed   bool
+		wantCalls []string
+		wantSaga  bll.SagaStatus
+		wantOrder bll.OrderStatus
+	}{
+		{
+			name:      "running saga continues from its current step",
+			saga:      bll.Saga{Status: bll.SAGA_STATUS_RUNNING, CurrentStep: 1},
+			order:     bll.ORDER_STATUS_PROCESSING,
+			wantCalls: []string{"pay", "ship"},
+			wantSaga:  bll.SAGA_STATUS_COMPLETED,
+			wantOrder: bll.ORDER_STATUS_COMPLETED,
+		},
+		{
+			name:      "compensating saga compensates from its current step",
+			saga:      bll.Saga{Status: bll.SAGA_STATUS_COMPENSATING, CurrentStep: 1},
+			order:     bll.ORDER_STATUS_PROCESSING,
+			wantCalls: []string{"-pay", "-reserve"},
+			wantSaga:  bll.SAGA_STATUS_FAILED,
+			wantOrder: bll.ORDER_STATUS_CANCELLED,
+		},
+		{
+			name:      "saga claimed elsewhere is skipped",
+			saga:      bll.Saga{Status: bll.SAGA_STATUS_RUNNING, CurrentStep: 1},
+			order:     bll.ORDER_STATUS_PROCESSING,
+			claimed:   true,
+			wantSaga:  bll.SAGA_STATUS_RUNNING,
+			wantOrder: bll.ORDER_STATUS_PROCESSING,
+		},
+		{
+			name:      "finished saga is left alone",
+			saga:      bll.Saga{Status: bll.SAGA_STATUS_COMPLETED, CurrentStep: 3},
+			order:     bll.ORDER_STATUS_COMPLETED,
+			wantSaga:  bll.SAGA_STATUS_COMPLETED,
+			wantOrder: bll.ORDER_STATUS_COMPLETED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore()
+			store.orders[1] = tt.order
+			tt.saga.ID, tt.saga.OrderID, tt.saga.Steps = 1, 1, testSteps
+			store.sagas[1] = tt.saga
+			store.claimed[1] = tt.claimed
+			o, calls := newTestOrchestrator(store, "")
+
+			if err := o.Resume(context.Background()); err != nil {
+				t.Fatalf("Resume: %v", err)
+			}
+
+			if !slices.Equal(*calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", *calls, tt.wantCalls)
+			}
+			if st := store.sagas[1].Status; st != tt.wantSaga {
+				t.Errorf("saga status = %s, want %s", st, tt.wantSaga)
+			}
+			if st := store.orders[1]; st != tt.wantOrder {
+				t.Errorf("order status = %s, want %s", st, tt.wantOrder)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"go.uber.org/zap"
+)
+
+type ReserveStockStep struct {
+	pgClient           *postgres.PostgresClient
+	defaultWarehouseID int64
+	log                *zap.SugaredLogger
+}
+
+func NewReserveStockStep(pgClient *postgres.PostgresClient, defaultWarehouseID int64, log *zap.SugaredLogger) *ReserveStockStep {
+	return &ReserveStockStep{
+		pgClient:           pgClient,
+		defaultWarehouseID: defaultWarehouseID,
+		log:                log,
+	}
+}
+
+func (s *ReserveStockStep) Name() string {
+	return STEP_RESERVE_STOCK
+}
+
+func (s *ReserveStockStep) Execute(ctx context.Context, sc *StepContext) error {
+	inventorySvc := s.createBllInventoryService()
+	defer inventorySvc.UnitOfWork().Close()
+
+	errs, err := inventorySvc.ReserveOrder(ctx, sc.Order)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %v", ErrStepRejected, errs)
+	}
+	return nil
+}
+
+func (s *ReserveStockStep) Compensate(ctx context.Context, sc *StepContext) error {
+	inventorySvc := s.createBllInventoryService()
+	defer inventorySvc.UnitOfWork().Close()
+
+	return inventorySvc.ReleaseOrders(ctx, []int64{sc.Order.ID})
+}
+
+func (s *ReserveStockStep) createBllInventoryService() *bllServices.InventoryService {
+	uow := unitofwork.New(s.pgClient)
+	stockRepo := repositories.NewStockRepository(uow)
+	reservationRepo := repositories.NewStockReservationRepository(uow)
+	return bllServices.NewInventoryService(uow, stockRepo, reservationRepo, s.defaultWarehouseID, s.log)
+}
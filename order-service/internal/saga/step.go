@@ -0,0 +1,31 @@
+package saga
+
+import (
+	"context"
+	"errors"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+)
+
+const (
+	STEP_RESERVE_STOCK     = "reserve_stock"
+	STEP_AUTHORIZE_PAYMENT = "authorize_payment"
+	STEP_CREATE_SHIPMENT   = "create_shipment"
+)
+
+// ErrStepRejected marks business failures that must not be retried.
+var ErrStepRejected = errors.New("step rejected")
+
+type StepContext struct {
+	Order bll.OrderUnit
+	// Data is persisted with the saga and is how steps hand identifiers to their compensations.
+	Data map[string]string
+}
+
+// Step is a single saga action. Both Execute and Compensate may be repeated after
+// a restart, so implementations must be idempotent.
+type Step interface {
+	Name() string
+	Execute(ctx context.Context, sc *StepContext) error
+	Compensate(ctx context.Context, sc *StepContext) error
+}
@@ -0,0 +1,150 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	publisher "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/publisher/rabbitmq"
+	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"go.uber.org/zap"
+)
+
+// Sagas are claimed with session-level advisory locks in this class, keyed by saga id.
+const sagaLockClassID int32 = 7310002
+
+// store is everything the orchestrator reads and writes besides its steps.
+type store interface {
+	// CreateSaga returns false when the order already has a saga.
+	CreateSaga(ctx context.Context, orderID int64, steps []string) (bll.Saga, bool, error)
+	GetSaga(ctx context.Context, sagaID int64) (bll.Saga, bool, error)
+	GetOrderSaga(ctx context.Context, orderID int64) (bll.Saga, bool, error)
+	GetInFlightSagas(ctx context.Context) ([]bll.Saga, error)
+	SaveSaga(ctx context.Context, saga bll.Saga) (bll.Saga, error)
+	LoadOrder(ctx context.Context, orderID int64, l *zap.SugaredLogger) (bll.OrderUnit, error)
+	SetOrderStatus(ctx context.Context, orderID int64, status bll.OrderStatus, l *zap.SugaredLogger) error
+	// Claim holds the saga for this replica until release is called; it reports false
+	// when someone else holds it.
+	Claim(ctx context.Context, sagaID int64, l *zap.SugaredLogger) (release func(), claimed bool, err error)
+}
+
+type postgresStore struct {
+	inventorySettings settings.InventorySettings
+	pgClient          *postgres.PostgresClient
+	omsPublisher      *publisher.Publisher
+	log               *zap.SugaredLogger
+}
+
+func (s *postgresStore) CreateSaga(ctx context.Context, orderID int64, steps []string) (bll.Saga, bool, error) {
+	sagaSvc := s.createBllSagaService()
+	defer sagaSvc.UnitOfWork().Close()
+	return sagaSvc.CreateSaga(ctx, orderID, steps)
+}
+
+func (s *postgresStore) GetSaga(ctx context.Context, sagaID int64) (bll.Saga, bool, error) {
+	sagaSvc := s.createBllSagaService()
+	defer sagaSvc.UnitOfWork().Close()
+	return sagaSvc.GetSaga(ctx, sagaID)
+}
+
+func (s *postgresStore) GetOrderSaga(ctx context.Context, orderID int64) (bll.Saga, bool, error) {
+	sagaSvc := s.createBllSagaService()
+	defer sagaSvc.UnitOfWork().Close()
+	return sagaSvc.GetOrderSaga(ctx, orderID)
+}
+
+func (s *postgresStore) GetInFlightSagas(ctx context.Context) ([]bll.Saga, error) {
+	sagaSvc := s.createBllSagaService()
+	defer sagaSvc.UnitOfWork().Close()
+	return sagaSvc.GetInFlightSagas(ctx)
+}
+
+func (s *postgresStore) SaveSaga(ctx context.Context, saga bll.Saga) (bll.Saga, error) {
+	sagaSvc := s.createBllSagaService()
+	defer sagaSvc.UnitOfWork().Close()
+	return sagaSvc.SaveSaga(ctx, saga)
+}
+
+func (s *postgresStore) LoadOrder(ctx context.Context, orderID int64, l *zap.SugaredLogger) (bll.OrderUnit, error) {
+	orderSvc := s.createBllOrderService(l)
+	defer orderSvc.UnitOfWork().Close()
+
+	orders, err := orderSvc.GetOrders(ctx, bll.QueryOrderItemsModel{
+		IDs:               []int64{orderID},
+		IncludeOrderItems: true,
+		Page:              1,
+		PageSize:          1,
+	})
+	if err != nil {
+		return bll.OrderUnit{}, err
+	}
+	if len(orders) == 0 {
+		return bll.OrderUnit{}, fmt.Errorf("order %d not found", orderID)
+	}
+	return orders[0], nil
+}
+
+func (s *postgresStore) SetOrderStatus(ctx context.Context, orderID int64, status bll.OrderStatus, l *zap.SugaredLogger) error {
+	orderSvc := s.createBllOrderService(l)
+	defer orderSvc.UnitOfWork().Close()
+
+	_, err := orderSvc.UpdateOrdersStatus(ctx, []int64{orderID}, status)
+	return err
+}
+
+// Claim holds a connection with the saga's advisory lock until release is called. If the
+// process dies the connection drops and the lock with it, so another replica can resume.
+func (s *postgresStore) Claim(ctx context.Context, sagaID int64, l *zap.SugaredLogger) (func(), bool, error) {
+	uow := unitofwork.New(s.pgClient)
+	lockRepo := repositories.NewAdvisoryLockRepository(uow)
+	// Ids past the int4 range wrap, so two sagas may share a key. The one that finds the
+	// key taken is skipped like any saga claimed elsewhere and runs on the next Resume.
+	objID := int32(sagaID)
+
+	locked, err := lockRepo.TryLock(ctx, sagaLockClassID, objID)
+	if err != nil || !locked {
+		uow.Close()
+		return nil, false, err
+	}
+
+	release := func() {
+		// The run's context may be done by now, but the lock still has to go.
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := lockRepo.Unlock(unlockCtx, sagaLockClassID, objID); err != nil {
+			// A pooled connection must not go back still holding the lock.
+			l.Errorw("saga_orchestrator.release_claim_failed", "err", err)
+			if conn, connErr := uow.GetConn(unlockCtx); connErr == nil {
+				conn.Conn().Close(unlockCtx)
+			}
+		}
+		uow.Close()
+	}
+	return release, true, nil
+}
+
+func (s *postgresStore) createBllSagaService() *bllServices.SagaService {
+	uow := unitofwork.New(s.pgClient)
+	repo := repositories.NewSagaRepository(uow)
+	return bllServices.NewSagaService(uow, repo, s.log)
+}
+
+func (s *postgresStore) createBllOrderService(log *zap.SugaredLogger) *bllServices.OrderService {
+	uow := unitofwork.New(s.pgClient)
+	orderRepo := repositories.NewOrderRepository(uow)
+	orderItemRepo := repositories.NewOrderItemRepository(uow)
+
+	// Reservations may come from the reserve_stock step even when BatchCreate does not reserve,
+	// so status changes always settle them.
+	stockRepo := repositories.NewStockRepository(uow)
+	reservationRepo := repositories.NewStockReservationRepository(uow)
+	inventorySvc := bllServices.NewInventoryService(uow, stockRepo, reservationRepo, s.inventorySettings.DefaultWarehouseId, log)
+
+	return bllServices.NewOrderService(uow, orderRepo, orderItemRepo, inventorySvc, s.omsPublisher, log)
+}
@@ -0,0 +1,201 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	publisher "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/publisher/rabbitmq"
+	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/metrics"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+// StaleOrderCanceller periodically cancels orders that stayed in a status longer than its TTL.
+// Every run holds a Postgres advisory lock, so only one replica does the work at a time.
+type StaleOrderCanceller struct {
+	cfg               *settings.StaleOrderSchedulerSettings
+	inventorySettings settings.InventorySettings
+	pgClient          *postgres.PostgresClient
+	omsPublisher      *publisher.Publisher
+	ttls              map[bll.OrderStatus]time.Duration
+	log               *zap.SugaredLogger
+}
+
+func NewStaleOrderCanceller(
+	cfg *settings.StaleOrderSchedulerSettings,
+	inventorySettings settings.InventorySettings,
+	pgClient *postgres.PostgresClient,
+	omsPublisher *publisher.Publisher,
+	log *zap.SugaredLogger,
+) (*StaleOrderCanceller, error) {
+	ttls := make(map[bll.OrderStatus]time.Duration, len(cfg.StatusTtlSeconds))
+	for name, seconds := range cfg.StatusTtlSeconds {
+		st := bll.StringToOrderStatus(name)
+		if st == "" {
+			return nil, fmt.Errorf("unknown order status %q", name)
+		}
+		if st == bll.ORDER_STATUS_CANCELLED || !st.CanTransition(bll.ORDER_STATUS_CANCELLED) {
+			return nil, fmt.Errorf("orders in status %q can't be cancelled", name)
+		}
+		if seconds <= 0 {
+			return nil, fmt.Errorf("ttl for status %q must be greater than 0", name)
+		}
+		ttls[st] = time.Duration(seconds) * time.Second
+	}
+
+	return &StaleOrderCanceller{
+		cfg:               cfg,
+		inventorySettings: inventorySettings,
+		pgClient:          pgClient,
+		omsPublisher:      omsPublisher,
+		ttls:              ttls,
+		log:               log,
+	}, nil
+}
+
+func (c *StaleOrderCanceller) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(c.cfg.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := c.runOnce(ctx); err != nil && ctx.Err() == nil {
+			c.log.Errorw("stale_order_canceller.run_failed", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *StaleOrderCanceller) runOnce(ctx context.Context) error {
+	uow := unitofwork.New(c.pgClient)
+	defer uow.Close()
+
+	if _, err := uow.BeginTransaction(ctx); err != nil {
+		return err
+	}
+	locked, err := repositories.NewAdvisoryLockRepository(uow).TryXactLock(ctx, c.cfg.LockKey)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		c.log.Debugw("stale_order_canceller.lock_busy")
+		return nil
+	}
+
+	statuses := make([]bll.OrderStatus, 0, len(c.ttls))
+	for st := range c.ttls {
+		statuses = append(statuses, st)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+
+	ctx = utils.WithActor(ctx, utils.ACTOR_SYSTEM)
+	for _, st := range statuses {
+		if err := c.cancelStale(ctx, st, time.Now().UTC().Add(-c.ttls[st])); err != nil {
+			return err
+		}
+	}
+
+	return uow.Commit(ctx)
+}
+
+func (c *StaleOrderCanceller) cancelStale(ctx context.Context, status bll.OrderStatus, updatedBefore time.Time) error {
+	batchSize := max(c.cfg.BatchSize, 1)
+	total := 0
+
+	for {
+		orderSvc := c.createBllOrderService()
+		cancelled, skipped, err := c.cancelBatch(ctx, orderSvc, status, updatedBefore, batchSize)
+		orderSvc.UnitOfWork().Close()
+		if err != nil {
+			return err
+		}
+
+		total += cancelled
+		if cancelled+skipped < batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		c.log.Infow("stale_order_canceller.orders_cancelled", "status", status, "count", total)
+	}
+	return nil
+}
+
+func (c *StaleOrderCanceller) cancelBatch(
+	ctx context.Context,
+	orderSvc *bllServices.OrderService,
+	status bll.OrderStatus,
+	updatedBefore time.Time,
+	batchSize int,
+) (cancelled, skipped int, err error) {
+	orders, err := orderSvc.GetOrders(ctx, bll.QueryOrderItemsModel{
+		Statuses:      []bll.OrderStatus{status},
+		UpdatedBefore: updatedBefore,
+		Page:          1,
+		PageSize:      batchSize,
+	})
+	if err != nil || len(orders) == 0 {
+		return 0, 0, err
+	}
+
+	ids := make([]int64, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+	}
+
+	_, err = orderSvc.UpdateOrdersStatus(ctx, ids, bll.ORDER_STATUS_CANCELLED)
+	if err == nil {
+		return len(ids), 0, nil
+	}
+	if !isInvalidTransition(err) {
+		return 0, 0, err
+	}
+
+	// Some order left the stale status since the query (for example it got paid), which
+	// fails the whole batch. Cancel the orders one by one and skip the ones that moved on.
+	for _, id := range ids {
+		if _, err := orderSvc.UpdateOrdersStatus(ctx, []int64{id}, bll.ORDER_STATUS_CANCELLED); err != nil {
+			if !isInvalidTransition(err) {
+				return cancelled, skipped, err
+			}
+			c.log.Warnw("stale_order_canceller.order_skipped", "order_id", id, "status", status, "err", err)
+			metrics.StaleOrdersSkippedTotal.WithLabelValues(status.String()).Inc()
+			skipped++
+			continue
+		}
+		cancelled++
+	}
+	return cancelled, skipped, nil
+}
+
+func isInvalidTransition(err error) bool {
+	st, stErr := utils.GetGrpcErrStatus(err)
+	return stErr == nil && st.Code() == codes.InvalidArgument
+}
+
+func (c *StaleOrderCanceller) createBllOrderService() *bllServices.OrderService {
+	uow := unitofwork.New(c.pgClient)
+	orderRepo := repositories.NewOrderRepository(uow)
+	orderItemRepo := repositories.NewOrderItemRepository(uow)
+
+	// Stale orders may hold reservations made by the saga, so cancellation always releases them.
+	stockRepo := repositories.NewStockRepository(uow)
+	reservationRepo := repositories.NewStockReservationRepository(uow)
+	inventorySvc := bllServices.NewInventoryService(uow, stockRepo, reservationRepo, c.inventorySettings.DefaultWarehouseId, c.log)
+
+	return bllServices.NewOrderService(uow, orderRepo, orderItemRepo, inventorySvc, c.omsPublisher, c.log)
+}
@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	bll "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/interfaces"
+	dal "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/pkg/messages"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// fakeOrderRepo applies moved right after the stale orders are selected, like a payment
+// that lands between the select and the update.
+type fakeOrderRepo struct {
+	interfaces.OrderRepository
+
+	orders    map[int64]string
+	moved     map[int64]string
+	updateErr error
+}
+
+func (r *fakeOrderRepo) Query(ctx context.Context, q dal.QueryOrdersDalModel) ([]dal.V1OrderDal, error) {
+	var result []dal.V1OrderDal
+	for _, id := range slices.Sorted(maps.Keys(r.orders)) {
+		if len(q.IDs) > 0 && !slices.Contains(q.IDs, id) || len(q.Statuses) > 0 && !slices.Contains(q.Statuses, r.orders[id]) {
+			continue
+		}
+		result = append(result, dal.V1OrderDal{ID: id, Status: r.orders[id]})
+	}
+	if !q.ForUpdate {
+		for id, st := range r.moved {
+			r.orders[id] = st
+		}
+		r.moved = nil
+	}
+	return result, nil
+}
+
+func (r *fakeOrderRepo) BulkUpdate(ctx context.Context, orders []dal.V1OrderDal) ([]dal.V1OrderDal, error) {
+	if r.updateErr != nil {
+		return nil, r.updateErr
+	}
+	for _, o := range orders {
+		r.orders[o.ID] = o.Status
+	}
+	return orders, nil
+}
+
+type fakeUnitOfWork struct{}
+
+func (fakeUnitOfWork) BeginTransaction(ctx context.Context) (pgx.Tx, error) { return nil, nil }
+func (fakeUnitOfWork) Commit(ctx context.Context) error                     { return nil }
+func (fakeUnitOfWork) Rollback(ctx context.Context) error                   { return nil }
+func (fakeUnitOfWork) Close()                                               {}
+
+type fakePublisher struct{}
+
+func (fakePublisher) PublishBatch(ctx context.Context, msgs []messages.Message) error { return nil }
+
+func TestCancelBatchSkipsOrdersThatMovedOn(t *testing.T) {
+	tests := []struct {
+		name          string
+		moved         map[int64]string
+		updateErr     error
+		wantCancelled int
+		wantSkipped   int
+		wantErr       bool
+		wantStatuses  map[int64]string
+	}{
+		{
+			name:          "every order still stale",
+			wantCancelled: 3,
+			wantStatuses:  map[int64]string{1: "cancelled", 2: "cancelled", 3: "cancelled"},
+		},
+		{
+			name:          "one order completed meanwhile",
+			moved:         map[int64]string{2: "completed"},
+			wantCancelled: 2,
+			wantSkipped:   1,
+			wantStatuses:  map[int64]string{1: "cancelled", 2: "completed", 3: "cancelled"},
+		},
+		{
+			name:         "every order moved on",
+			moved:        map[int64]string{1: "completed", 2: "completed", 3: "completed"},
+			wantSkipped:  3,
+			wantStatuses: map[int64]string{1: "completed", 2: "completed", 3: "completed"},
+		},
+		{
+			name:         "infrastructure error aborts",
+			updateErr:    errors.New("connection reset"),
+			wantErr:      true,
+			wantStatuses: map[int64]string{1: "processing", 2: "processing", 3: "processing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOrderRepo{
+				orders:    map[int64]string{1: "processing", 2: "processing", 3: "processing"},
+				moved:     tt.moved,
+				updateErr: tt.updateErr,
+			}
+			log := zap.NewNop().Sugar()
+			orderSvc := bllServices.NewOrderService(fakeUnitOfWork{}, repo, nil, nil, fakePublisher{}, log)
+			c := &StaleOrderCanceller{log: log}
+
+			cancelled, skipped, err := c.cancelBatch(context.Background(), orderSvc, bll.ORDER_STATUS_PROCESSING, time.Now(), 10)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cancelBatch err = %v", err)
+			}
+			if cancelled != tt.wantCancelled || skipped != tt.wantSkipped {
+				t.Errorf("cancelled, skipped = %d, %d; want %d, %d", cancelled, skipped, tt.wantCancelled, tt.wantSkipped)
+			}
+			for id, want := range tt.wantStatuses {
+				if got := repo.orders[id]; got != want {
+					t.Errorf("order %d status = %s, want %s", id, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func AccessLogUnaryInterceptor(log *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		writeAccessLog(ctx, log, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func AccessLogStreamInterceptor(log *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		writeAccessLog(ss.Context(), log, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func writeAccessLog(ctx context.Context, log *zap.SugaredLogger, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = p.Addr.String()
+	}
+
+	l := utils.LoggerFromContext(ctx, log)
+	fields := []any{
+		"method", method,
+		"code", code.String(),
+		"latency_ms", time.Since(start).Milliseconds(),
+		"peer", addr,
+	}
+	switch code {
+	case codes.OK:
+		l.Infow("access_log", fields...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		l.Errorw("access_log", fields...)
+	default:
+		l.Warnw("access_log", fields...)
+	}
+}
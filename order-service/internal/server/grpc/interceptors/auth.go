@@ -0,0 +1,83 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/auth"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Payment callbacks are authenticated by their HMAC signature instead.
+var authExemptMethods = map[string]struct{}{
+	pb.PaymentService_HandlePaymentCallback_FullMethodName: {},
+	healthpb.Health_Check_FullMethodName:                   {},
+	healthpb.Health_Watch_FullMethodName:                   {},
+}
+
+func AuthUnaryInterceptor(verifier *auth.Verifier, log *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, info.FullMethod, verifier, log)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func AuthStreamInterceptor(verifier *auth.Verifier, log *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod, verifier, log)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, method string, verifier *auth.Verifier, log *zap.SugaredLogger) (context.Context, error) {
+	if _, ok := authExemptMethods[method]; ok {
+		return ctx, nil
+	}
+
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+	}
+
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		utils.LoggerFromContext(ctx, log).Warnw("auth_interceptor.token_missing", "method", method)
+		return nil, status.Errorf(codes.Unauthenticated, "bearer token is required")
+	}
+
+	claims, err := verifier.Verify(token)
+	if err != nil {
+		utils.LoggerFromContext(ctx, log).Warnw("auth_interceptor.token_invalid", "method", method, "err", err)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+
+	ctx = auth.WithClaims(ctx, claims)
+	if utils.ActorFromContext(ctx) == "" {
+		ctx = utils.WithActor(ctx, claims.Subject)
+	}
+	return ctx, nil
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,34 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func MetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRpc(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func MetricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRpc(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeRpc(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	metrics.GrpcRequestsTotal.WithLabelValues(method, code).Inc()
+	metrics.GrpcRequestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,181 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/auth"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/ratelimit"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const API_KEY_METADATA_KEY = "x-api-key"
+
+// Provider callbacks are retried by the provider and must not be dropped on our side.
+var rateLimitExemptMethods = map[string]struct{}{
+	pb.PaymentService_HandlePaymentCallback_FullMethodName: {},
+	healthpb.Health_Check_FullMethodName:                   {},
+	healthpb.Health_Watch_FullMethodName:                   {},
+}
+
+type rateLimitRules struct {
+	cfg     settings.RateLimitSettings
+	methods map[string]settings.RateLimitRule
+	clients map[string]settings.ClientQuota
+	apiKeys map[string]struct{}
+}
+
+// RateLimiter holds the current limits behind an atomic pointer so they can be
+// replaced at runtime without dropping the buckets already filled by clients.
+type RateLimiter struct {
+	limiter *ratelimit.Limiter
+	rules   atomic.Pointer[rateLimitRules]
+	log     *zap.SugaredLogger
+}
+
+func NewRateLimiter(cfg settings.RateLimitSettings, log *zap.SugaredLogger) *RateLimiter {
+	r := &RateLimiter{
+		limiter: ratelimit.New(time.Duration(cfg.IdleTtlSeconds) * time.Second),
+		log:     log,
+	}
+	r.Update(cfg)
+	return r
+}
+
+func (r *RateLimiter) Update(cfg settings.RateLimitSettings) {
+	rules := &rateLimitRules{
+		cfg:     cfg,
+		methods: make(map[string]settings.RateLimitRule, len(cfg.Methods)),
+		clients: make(map[string]settings.ClientQuota, len(cfg.Clients)),
+		apiKeys: make(map[string]struct{}, len(cfg.ApiKeys)),
+	}
+	for _, m := range cfg.Methods {
+		rules.methods[m.Method] = m.Limit
+	}
+	for _, c := range cfg.Clients {
+		rules.clients[c.Client] = c
+	}
+	for _, key := range cfg.ApiKeys {
+		rules.apiKeys[key] = struct{}{}
+	}
+	r.rules.Store(rules)
+}
+
+func (r *RateLimiter) Settings() settings.RateLimitSettings {
+	return r.rules.Load().cfg
+}
+
+// UnaryInterceptor must run after the auth interceptor so authenticated callers
+// are keyed by their token rather than by address.
+func (r *RateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rules := r.rules.Load()
+		if !rules.cfg.Enabled {
+			return handler(ctx, req)
+		}
+		if _, ok := rateLimitExemptMethods[info.FullMethod]; ok {
+			return handler(ctx, req)
+		}
+
+		client := rules.clientKey(ctx)
+		quota, hasQuota := rules.clients[client]
+
+		rule, ok := rules.methods[info.FullMethod]
+		if !ok {
+			rule = rules.cfg.Default
+		}
+		if hasQuota && quota.Requests.RequestsPerSecond > 0 {
+			rule = quota.Requests
+		}
+		if allowed, retryAfter := r.limiter.Allow(info.FullMethod+"|"+client, rule, 1); !allowed {
+			utils.LoggerFromContext(ctx, r.log).Warnw("rate_limit_interceptor.requests_exhausted", "method", info.FullMethod, "client", client, "retry_after", retryAfter)
+			return nil, resourceExhausted(client, "requests per second for "+info.FullMethod, retryAfter)
+		}
+
+		if batch, ok := req.(*pb.BatchCreateRequest); ok {
+			orders := rules.cfg.Orders
+			if hasQuota && quota.Orders.RequestsPerSecond > 0 {
+				orders = quota.Orders
+			}
+			if allowed, retryAfter := r.limiter.Allow("orders|"+client, orders, len(batch.GetOrders())); !allowed {
+				utils.LoggerFromContext(ctx, r.log).Warnw("rate_limit_interceptor.orders_exhausted", "client", client, "orders", len(batch.GetOrders()), "retry_after", retryAfter)
+				return nil, resourceExhausted(client, fmt.Sprintf("orders per second, batch of %d", len(batch.GetOrders())), retryAfter)
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// clientKey only trusts what the caller can't choose freely: the token, an api key from
+// the allow list, or the peer address. Forwarded addresses are only taken from the local
+// gateway, which appends the address it saw to x-forwarded-for, so only the last hop
+// is trusted.
+func (rules *rateLimitRules) clientKey(ctx context.Context) string {
+	if claims := auth.ClaimsFromContext(ctx); claims != nil {
+		if claims.CustomerID > 0 {
+			return fmt.Sprintf("customer:%d", claims.CustomerID)
+		}
+		return "client:" + claims.Subject
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(API_KEY_METADATA_KEY); len(values) > 0 {
+		if _, ok := rules.apiKeys[values[0]]; ok {
+			// Keys end up in logs and error details, so only a digest is used.
+			sum := sha256.Sum256([]byte(values[0]))
+			return "apikey:" + hex.EncodeToString(sum[:8])
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "anonymous"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			hops := strings.Split(values[len(values)-1], ",")
+			if last := strings.TrimSpace(hops[len(hops)-1]); last != "" {
+				return "ip:" + last
+			}
+		}
+	}
+	return "ip:" + host
+}
+
+func resourceExhausted(client, description string, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	stWithDetails, err := st.WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{Subject: client, Description: description},
+			},
+		},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
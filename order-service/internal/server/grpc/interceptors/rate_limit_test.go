@@ -0,0 +1,145 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/auth"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func peerContext(addr string, md ...string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(md...))
+	if addr == "" {
+		return ctx
+	}
+	tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		panic(err)
+	}
+	return peer.NewContext(ctx, &peer.Peer{Addr: tcpAddr})
+}
+
+func digest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+func TestRateLimitClientKey(t *testing.T) {
+	r := NewRateLimiter(settings.RateLimitSettings{ApiKeys: []string{"known-key"}}, zap.NewNop().Sugar())
+	rules := r.rules.Load()
+
+	withClaims := func(ctx context.Context, claims auth.Claims) context.Context {
+		return auth.WithClaims(ctx, &claims)
+	}
+	subject := auth.Claims{}
+	subject.Subject = "order-generator"
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"customer token", withClaims(peerContext("10.0.0.1:5000"), auth.Claims{CustomerID: 42}), "customer:42"},
+		{"client token", withClaims(peerContext("10.0.0.1:5000"), subject), "client:order-generator"},
+		{"known api key", peerContext("10.0.0.1:5000", API_KEY_METADATA_KEY, "known-key"), "apikey:" + digest("known-key")},
+		{"unknown api key", peerContext("10.0.0.1:5000", API_KEY_METADATA_KEY, "made-up"), "ip:10.0.0.1"},
+		{"direct client", peerContext("10.0.0.1:5000"), "ip:10.0.0.1"},
+		{"forwarded for from a direct client", peerContext("10.0.0.1:5000", "x-forwarded-for", "1.2.3.4"), "ip:10.0.0.1"},
+		{"gateway", peerContext("127.0.0.1:5000", "x-forwarded-for", "203.0.113.7"), "ip:203.0.113.7"},
+		{"gateway with spoofed hops", peerContext("127.0.0.1:5000", "x-forwarded-for", "1.2.3.4, 5.6.7.8, 203.0.113.7"), "ip:203.0.113.7"},
+		{"gateway over ipv6 loopback", peerContext("[::1]:5000", "x-forwarded-for", "203.0.113.7"), "ip:203.0.113.7"},
+		{"gateway without forwarded for", peerContext("127.0.0.1:5000"), "ip:127.0.0.1"},
+		{"no peer", peerContext(""), "anonymous"},
+	}
+	for _, tt := range tests {
+		if got := rules.clientKey(tt.ctx); got != tt.want {
+			t.Errorf("%s: clientKey = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	// One request per 100 seconds, so the second call is always over the limit.
+	slow := settings.RateLimitRule{RequestsPerSecond: 0.01, Burst: 1}
+	cfg := settings.RateLimitSettings{
+		Enabled: true,
+		Default: slow,
+		Orders:  settings.RateLimitRule{RequestsPerSecond: 1, Burst: 2},
+		Clients: []settings.ClientQuota{{Client: "client:order-generator", Requests: settings.RateLimitRule{RequestsPerSecond: 1000, Burst: 1000}}},
+	}
+	ok := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: pb.OrderService_QueryOrders_FullMethodName}
+	ctx := peerContext("10.0.0.1:5000")
+
+	r := NewRateLimiter(cfg, zap.NewNop().Sugar())
+	interceptor := r.UnaryInterceptor()
+	if _, err := interceptor(ctx, nil, info, ok); err != nil {
+		t.Fatalf("first request = %v", err)
+	}
+	_, err := interceptor(ctx, nil, info, ok)
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("second request = %v, want ResourceExhausted", err)
+	}
+	var retryInfo *errdetails.RetryInfo
+	var quota *errdetails.QuotaFailure
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.RetryInfo:
+			retryInfo = d
+		case *errdetails.QuotaFailure:
+			quota = d
+		}
+	}
+	if retryInfo == nil || retryInfo.GetRetryDelay().AsDuration().Round(time.Second) != 100*time.Second {
+		t.Errorf("retry info = %v, want 100s", retryInfo)
+	}
+	if quota == nil || len(quota.GetViolations()) != 1 || quota.GetViolations()[0].GetSubject() != "ip:10.0.0.1" {
+		t.Errorf("quota failure = %v", quota)
+	}
+
+	if _, err := interceptor(peerContext("10.0.0.2:5000"), nil, info, ok); err != nil {
+		t.Errorf("another client = %v", err)
+	}
+	health := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	for i := 0; i < 3; i++ {
+		if _, err := interceptor(ctx, nil, health, ok); err != nil {
+			t.Errorf("exempt method = %v", err)
+		}
+	}
+
+	generator := &auth.Claims{}
+	generator.Subject = "order-generator"
+	quotaCtx := auth.WithClaims(ctx, generator)
+	batch := func(n int) *pb.BatchCreateRequest {
+		return &pb.BatchCreateRequest{Orders: make([]*pb.Order, n)}
+	}
+	create := &grpc.UnaryServerInfo{FullMethod: pb.OrderService_BatchCreate_FullMethodName}
+	if _, err := interceptor(quotaCtx, batch(2), create, ok); err != nil {
+		t.Errorf("batch within the order limit = %v", err)
+	}
+	if _, err := interceptor(quotaCtx, batch(1), create, ok); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("batch over the order limit = %v, want ResourceExhausted", err)
+	}
+
+	cfg.Enabled = false
+	r.Update(cfg)
+	for i := 0; i < 3; i++ {
+		if _, err := interceptor(ctx, nil, info, ok); err != nil {
+			t.Errorf("disabled limiter = %v", err)
+		}
+	}
+}
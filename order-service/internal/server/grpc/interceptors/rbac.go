@@ -0,0 +1,69 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/auth"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RbacUnaryInterceptor must run after the auth interceptor. Requests without claims are
+// the ones auth lets through unauthenticated and are not checked here either.
+func RbacUnaryInterceptor(policy *auth.Policy, log *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, info.FullMethod, policy, log)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func RbacStreamInterceptor(policy *auth.Policy, log *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), info.FullMethod, policy, log)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authorize(ctx context.Context, method string, policy *auth.Policy, log *zap.SugaredLogger) (context.Context, error) {
+	claims := auth.ClaimsFromContext(ctx)
+	if claims == nil {
+		return ctx, nil
+	}
+
+	allowed, scope := policy.Authorize(claims.Roles, method, claims.CustomerID)
+	if !allowed {
+		utils.LoggerFromContext(ctx, log).Warnw("rbac_interceptor.permission_denied", "method", method, "sub", claims.Subject, "roles", claims.Roles)
+		return nil, permissionDenied(method, claims.Roles)
+	}
+	if scope != nil {
+		ctx = utils.WithAccessScope(ctx, scope)
+	}
+	return ctx, nil
+}
+
+func permissionDenied(method string, roles []string) error {
+	st := status.New(codes.PermissionDenied, "permission denied")
+	stWithDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: "METHOD_NOT_ALLOWED",
+		Domain: "order-service",
+		Metadata: map[string]string{
+			"method": method,
+			"roles":  strings.Join(roles, ","),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
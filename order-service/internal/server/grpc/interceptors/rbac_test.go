@@ -0,0 +1,72 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/auth"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const rbacTestMethod = "/order_service.v1.OrderService/QueryOrders"
+
+func rbacTestPolicy() *auth.Policy {
+	return &auth.Policy{Roles: map[string]auth.RolePolicy{
+		"customer": {Methods: []string{rbacTestMethod}, OwnOrdersOnly: true},
+		"operator": {Methods: []string{"/order_service.v1.OrderService/*"}},
+	}}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestRbacInterceptors(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    *auth.Claims
+		wantCode  codes.Code
+		wantScope bool
+	}{
+		{"unauthenticated request", nil, codes.OK, false},
+		{"no roles", &auth.Claims{}, codes.PermissionDenied, false},
+		{"unrestricted role", &auth.Claims{Roles: []string{"operator"}}, codes.OK, false},
+		{"own orders only", &auth.Claims{Roles: []string{"customer"}, CustomerID: 42}, codes.OK, true},
+	}
+	unary := RbacUnaryInterceptor(rbacTestPolicy(), zap.NewNop().Sugar())
+	stream := RbacStreamInterceptor(rbacTestPolicy(), zap.NewNop().Sugar())
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.claims != nil {
+			ctx = auth.WithClaims(ctx, tt.claims)
+		}
+
+		var unaryScope *utils.AccessScope
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: rbacTestMethod}, func(ctx context.Context, req any) (any, error) {
+			unaryScope = utils.AccessScopeFromContext(ctx)
+			return nil, nil
+		})
+		if status.Code(err) != tt.wantCode || (unaryScope != nil) != tt.wantScope {
+			t.Errorf("%s: unary err = %v, scope = %+v", tt.name, err, unaryScope)
+		}
+
+		var streamScope *utils.AccessScope
+		err = stream(nil, testServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: rbacTestMethod}, func(srv any, ss grpc.ServerStream) error {
+			streamScope = utils.AccessScopeFromContext(ss.Context())
+			return nil
+		})
+		if status.Code(err) != tt.wantCode || (streamScope != nil) != tt.wantScope {
+			t.Errorf("%s: stream err = %v, scope = %+v", tt.name, err, streamScope)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDUnaryInterceptor goes first in the chain: everything after it logs through the
+// request-scoped logger it puts into the context.
+func RequestIDUnaryInterceptor(log *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withRequestID(ctx, log), req)
+	}
+}
+
+func RequestIDStreamInterceptor(log *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: withRequestID(ss.Context(), log)})
+	}
+}
+
+func withRequestID(ctx context.Context, log *zap.SugaredLogger) context.Context {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(utils.REQUEST_ID_METADATA_KEY); len(values) > 0 && utils.IsValidRequestID(values[0]) {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = utils.NewRequestID()
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(utils.REQUEST_ID_METADATA_KEY, requestID))
+
+	l := log.With("request_id", requestID)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		l = l.With("trace_id", sc.TraceID().String())
+	}
+
+	ctx = utils.WithRequestID(ctx, requestID)
+	return utils.WithLogger(ctx, l)
+}
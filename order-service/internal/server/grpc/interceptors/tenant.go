@@ -0,0 +1,83 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/auth"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Provider callbacks are not made on behalf of a tenant; the payment they refer to
+// already identifies its order.
+var tenantExemptMethods = map[string]struct{}{
+	pb.PaymentService_HandlePaymentCallback_FullMethodName: {},
+	healthpb.Health_Check_FullMethodName:                   {},
+	healthpb.Health_Watch_FullMethodName:                   {},
+}
+
+// TenantUnaryInterceptor puts the tenant from the request metadata into the context,
+// where repositories pick it up to scope their queries.
+func TenantUnaryInterceptor(cfg settings.TenantSettings, log *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := tenantExemptMethods[info.FullMethod]; ok {
+			return handler(ctx, req)
+		}
+
+		var tenantID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(utils.TENANT_METADATA_KEY); len(values) > 0 {
+				tenantID = values[0]
+			}
+		}
+
+		// A tenant bound to the token wins. Only service tokens may carry none and act for
+		// the tenant in metadata; any other token without a tenant is refused.
+		if claims := auth.ClaimsFromContext(ctx); claims != nil {
+			switch {
+			case claims.TenantID != "":
+				if tenantID != "" && tenantID != claims.TenantID {
+					utils.LoggerFromContext(ctx, log).Warnw("tenant_interceptor.tenant_mismatch", "method", info.FullMethod, "tenant_id", tenantID, "token_tenant_id", claims.TenantID)
+					return nil, status.Errorf(codes.PermissionDenied, "tenant %q is not allowed for this token", tenantID)
+				}
+				tenantID = claims.TenantID
+			case !hasServiceRole(claims.Roles, cfg.ServiceRoles):
+				utils.LoggerFromContext(ctx, log).Warnw("tenant_interceptor.token_tenant_missing", "method", info.FullMethod, "sub", claims.Subject, "roles", claims.Roles)
+				return nil, status.Error(codes.PermissionDenied, "token is not bound to a tenant")
+			}
+		}
+
+		if tenantID == "" {
+			if cfg.Required {
+				utils.LoggerFromContext(ctx, log).Warnw("tenant_interceptor.tenant_missing", "method", info.FullMethod)
+				return nil, status.Errorf(codes.Unauthenticated, "%s metadata is required", utils.TENANT_METADATA_KEY)
+			}
+			tenantID = cfg.DefaultTenantId
+		}
+		if !utils.IsValidTenantID(tenantID) {
+			utils.LoggerFromContext(ctx, log).Warnw("tenant_interceptor.tenant_invalid", "method", info.FullMethod, "tenant_id", tenantID)
+			return nil, status.Errorf(codes.InvalidArgument, "invalid tenant id %q", tenantID)
+		}
+
+		return handler(utils.WithTenant(ctx, tenantID), req)
+	}
+}
+
+func hasServiceRole(roles, serviceRoles []string) bool {
+	for _, role := range roles {
+		for _, sr := range serviceRoles {
+			if strings.EqualFold(role, sr) {
+				return true
+			}
+		}
+	}
+	return false
+}
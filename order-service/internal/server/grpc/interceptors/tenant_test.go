@@ -0,0 +1,55 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/auth"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     *auth.Claims
+		mdTenant   string
+		wantCode   codes.Code
+		wantTenant string
+	}{
+		{"unauthenticated uses metadata", nil, "acme", codes.OK, "acme"},
+		{"unauthenticated falls back to default", nil, "", codes.OK, "default"},
+		{"token tenant", &auth.Claims{TenantID: "acme", Roles: []string{"customer"}}, "", codes.OK, "acme"},
+		{"token tenant matches metadata", &auth.Claims{TenantID: "acme", Roles: []string{"customer"}}, "acme", codes.OK, "acme"},
+		{"token tenant mismatch", &auth.Claims{TenantID: "acme", Roles: []string{"customer"}}, "other", codes.PermissionDenied, ""},
+		{"service token acts for metadata tenant", &auth.Claims{Roles: []string{"Consumer"}}, "other", codes.OK, "other"},
+		{"customer token without tenant", &auth.Claims{Roles: []string{"customer"}, CustomerID: 42}, "other", codes.PermissionDenied, ""},
+		{"operator token without tenant", &auth.Claims{Roles: []string{"operator"}}, "", codes.PermissionDenied, ""},
+	}
+	cfg := settings.TenantSettings{DefaultTenantId: "default", ServiceRoles: []string{"consumer", "gateway"}}
+	interceptor := TenantUnaryInterceptor(cfg, zap.NewNop().Sugar())
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.claims != nil {
+			ctx = auth.WithClaims(ctx, tt.claims)
+		}
+		if tt.mdTenant != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(utils.TENANT_METADATA_KEY, tt.mdTenant))
+		}
+
+		var tenant string
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: rbacTestMethod}, func(ctx context.Context, req any) (any, error) {
+			tenant = utils.TenantFromContext(ctx)
+			return nil, nil
+		})
+		if status.Code(err) != tt.wantCode || tenant != tt.wantTenant {
+			t.Errorf("%s: err = %v, tenant = %q, want %v, %q", tt.name, err, tenant, tt.wantCode, tt.wantTenant)
+		}
+	}
+}
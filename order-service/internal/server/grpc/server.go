@@ -8,15 +8,32 @@ import (
 	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/server/grpc/services"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type Server struct {
-	srv          *grpc.Server
-	listener     net.Listener
-	orderService *services.OrderService
+	srv              *grpc.Server
+	listener         net.Listener
+	health           *grpchealth.Server
+	orderService     *services.OrderService
+	webhookService   *services.WebhookService
+	inventoryService *services.InventoryService
+	productService   *services.ProductService
+	paymentService   *services.PaymentService
+	dlqService       *services.DlqService
 }
 
-func NewServer(port int, orderService *services.OrderService) (*Server, error) {
+func NewServer(
+	port int,
+	opts []grpc.ServerOption,
+	orderService *services.OrderService,
+	webhookService *services.WebhookService,
+	inventoryService *services.InventoryService,
+	productService *services.ProductService,
+	paymentService *services.PaymentService,
+	dlqService *services.DlqService,
+) (*Server, error) {
 	addr := fmt.Sprintf(":%d", port)
 
 	lis, err := net.Listen("tcp", addr)
@@ -24,13 +41,29 @@ func NewServer(port int, orderService *services.OrderService) (*Server, error) {
 		return nil, fmt.Errorf("failed to listen: %w", err)
 	}
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(opts...)
 	pb.RegisterOrderServiceServer(s, orderService)
+	pb.RegisterWebhookServiceServer(s, webhookService)
+	pb.RegisterInventoryServiceServer(s, inventoryService)
+	pb.RegisterProductServiceServer(s, productService)
+	pb.RegisterPaymentServiceServer(s, paymentService)
+	pb.RegisterDlqServiceServer(s, dlqService)
+
+	// Not serving until the first readiness check passes.
+	health := grpchealth.NewServer()
+	health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s, health)
 
 	return &Server{
-		srv:          s,
-		listener:     lis,
-		orderService: orderService,
+		srv:              s,
+		listener:         lis,
+		health:           health,
+		orderService:     orderService,
+		webhookService:   webhookService,
+		inventoryService: inventoryService,
+		productService:   productService,
+		paymentService:   paymentService,
+		dlqService:       dlqService,
 	}, nil
 }
 
@@ -38,7 +71,18 @@ func (s *Server) Start() error {
 	return s.srv.Serve(s.listener)
 }
 
+// SetServing reports readiness through grpc.health.v1 for the whole server.
+func (s *Server) SetServing(ready bool) {
+	st := healthpb.HealthCheckResponse_NOT_SERVING
+	if ready {
+		st = healthpb.HealthCheckResponse_SERVING
+	}
+	s.health.SetServingStatus("", st)
+}
+
 func (s *Server) Stop(ctx context.Context) error {
+	s.health.Shutdown()
+
 	stopped := make(chan struct{})
 	go func() {
 		s.srv.GracefulStop()
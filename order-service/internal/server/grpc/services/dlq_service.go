@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/bllerrors"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dlq"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/validators"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type DlqService struct {
+	pb.UnimplementedDlqServiceServer
+
+	log           *zap.SugaredLogger
+	manager       *dlq.Manager
+	replayTimeout time.Duration
+}
+
+func NewDlqService(manager *dlq.Manager, replayTimeout time.Duration, log *zap.SugaredLogger) *DlqService {
+	return &DlqService{
+		manager:       manager,
+		replayTimeout: replayTimeout,
+		log:           log,
+	}
+}
+
+func (s *DlqService) ListDlqMessages(ctx context.Context, req *pb.ListDlqMessagesRequest) (*pb.ListDlqMessagesResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "list_dlq_messages", "queue", req.Queue)
+	l.Infow("dlq_controller.list_start")
+
+	if errs := validators.ValidateListDlqMessagesRequest(req); errs != nil {
+		l.Errorw("dlq_controller.list_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 100
+	}
+	msgs, scanned, err := s.manager.List(ctx, req.Queue, pbDlqFilterToDlq(req.Filter), limit)
+	if err != nil {
+		l.Errorw("dlq_controller.list_failed", "err", err)
+		return nil, toStatus(dlqError(err))
+	}
+
+	l.Infow("dlq_controller.list_success", "count", len(msgs), "scanned", scanned)
+
+	resp := &pb.ListDlqMessagesResponse{Scanned: int32(scanned)}
+	for _, m := range msgs {
+		resp.Messages = append(resp.Messages, dlqMessageToPb(m))
+	}
+	return resp, nil
+}
+
+func (s *DlqService) ReplayDlqMessages(ctx context.Context, req *pb.ReplayDlqMessagesRequest) (*pb.ReplayDlqMessagesResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "replay_dlq_messages", "queue", req.Queue)
+	l.Infow("dlq_controller.replay_start", "ids", len(req.Ids), "all", req.All)
+
+	if errs := validators.ValidateReplayDlqMessagesRequest(req); errs != nil {
+		l.Errorw("dlq_controller.replay_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	// The replay is rate limited, so a large one is split across calls rather than
+	// outliving client and gateway deadlines.
+	deadline := time.Now().Add(s.replayTimeout)
+	// Leave the last publish time to be confirmed before the caller gives up.
+	if d, ok := ctx.Deadline(); ok && d.Add(-time.Second).Before(deadline) {
+		deadline = d.Add(-time.Second)
+	}
+
+	sel := dlq.Selection{Filter: pbDlqFilterToDlq(req.Filter), IDs: req.Ids, All: req.All}
+	replayed, scanned, hasMore, err := s.manager.Replay(ctx, req.Queue, sel, req.RatePerSecond, deadline)
+	if err != nil {
+		// Messages replayed before the failure are already gone from the DLQ; the one
+		// named by ErrReplayedNotRemoved was delivered but is still there.
+		l.Errorw("dlq_controller.replay_failed", "err", err, "replayed", replayed)
+		return nil, toStatus(dlqError(err))
+	}
+
+	l.Infow("dlq_controller.replay_success", "replayed", replayed, "scanned", scanned, "has_more", hasMore)
+	return &pb.ReplayDlqMessagesResponse{Replayed: int32(replayed), Scanned: int32(scanned), HasMore: hasMore}, nil
+}
+
+func (s *DlqService) PurgeDlqMessages(ctx context.Context, req *pb.PurgeDlqMessagesRequest) (*pb.PurgeDlqMessagesResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "purge_dlq_messages", "queue", req.Queue)
+	l.Infow("dlq_controller.purge_start", "ids", len(req.Ids), "all", req.All)
+
+	if errs := validators.ValidatePurgeDlqMessagesRequest(req); errs != nil {
+		l.Errorw("dlq_controller.purge_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	sel := dlq.Selection{Filter: pbDlqFilterToDlq(req.Filter), IDs: req.Ids, All: req.All}
+	purged, scanned, err := s.manager.Purge(ctx, req.Queue, sel)
+	if err != nil {
+		l.Errorw("dlq_controller.purge_failed", "err", err, "purged", purged)
+		return nil, toStatus(dlqError(err))
+	}
+
+	l.Warnw("dlq_controller.purge_success", "purged", purged, "scanned", scanned)
+	return &pb.PurgeDlqMessagesResponse{Purged: int32(purged), Scanned: int32(scanned)}, nil
+}
+
+func dlqError(err error) error {
+	if errors.Is(err, dlq.ErrUnknownQueue) {
+		return bllerrors.NotFound("DLQ_NOT_FOUND", "%v", err)
+	}
+	if errors.Is(err, dlq.ErrAmbiguousID) {
+		return bllerrors.PreconditionFailed("DLQ_AMBIGUOUS_ID", "%v", err)
+	}
+	if errors.Is(err, dlq.ErrReplayedNotRemoved) {
+		return bllerrors.Conflict("DLQ_REPLAYED_NOT_REMOVED", "%v", err)
+	}
+	return err
+}
+
+func pbDlqFilterToDlq(f *pb.DlqMessageFilter) dlq.Filter {
+	if f == nil {
+		return dlq.Filter{}
+	}
+	filter := dlq.Filter{
+		RoutingKey:   f.RoutingKey,
+		BodyContains: f.BodyContains,
+	}
+	if f.DeadLetteredFrom != nil {
+		filter.From = f.DeadLetteredFrom.AsTime()
+	}
+	if f.DeadLetteredTo != nil {
+		filter.To = f.DeadLetteredTo.AsTime()
+	}
+	return filter
+}
+
+func dlqMessageToPb(m dlq.Message) *pb.DlqMessage {
+	msg := &pb.DlqMessage{
+		Id:          m.ID,
+		RoutingKey:  m.RoutingKey,
+		Headers:     m.Headers,
+		Body:        m.Body,
+		DeathReason: m.DeathReason,
+		DeathQueue:  m.DeathQueue,
+		DeathCount:  int32(m.DeathCount),
+		RetryCount:  int32(m.RetryCount),
+		RetryReason: m.RetryReason,
+	}
+	if !m.DeadLetteredAt.IsZero() {
+		msg.DeadLetteredAt = timestamppb.New(m.DeadLetteredAt)
+	}
+	return msg
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/bllerrors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const ERROR_DOMAIN = "order-service"
+
+var kindCodes = map[bllerrors.Kind]codes.Code{
+	bllerrors.KIND_NOT_FOUND:           codes.NotFound,
+	bllerrors.KIND_CONFLICT:            codes.AlreadyExists,
+	bllerrors.KIND_PRECONDITION_FAILED: codes.FailedPrecondition,
+	bllerrors.KIND_UNAVAILABLE:         codes.Unavailable,
+	bllerrors.KIND_CANCELED:            codes.Canceled,
+	bllerrors.KIND_DEADLINE_EXCEEDED:   codes.DeadlineExceeded,
+	bllerrors.KIND_ABORTED:             codes.Aborted,
+	bllerrors.KIND_INTERNAL:            codes.Internal,
+}
+
+// toStatus maps an error returned by the BLL to a gRPC status. Status errors built by the
+// validators pass through; typed and infrastructure errors get a code, ErrorInfo and,
+// when retrying makes sense, RetryInfo. Nothing about unclassified errors is exposed.
+func toStatus(err error) error {
+	var typed *bllerrors.Error
+	if !errors.As(err, &typed) {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		typed = bllerrors.As(err)
+	}
+
+	code, ok := kindCodes[typed.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+	st := status.New(code, typed.Message)
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: typed.Reason, Domain: ERROR_DOMAIN, Metadata: typed.Metadata},
+	}
+	if typed.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(typed.RetryAfter)})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
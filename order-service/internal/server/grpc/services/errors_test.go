@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/bllerrors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantReason string
+		wantRetry  bool
+	}{
+		{"unique violation", &pgconn.PgError{Code: "23505"}, codes.AlreadyExists, "ALREADY_EXISTS", false},
+		{"foreign key violation", &pgconn.PgError{Code: "23503"}, codes.FailedPrecondition, "REFERENCED_RESOURCE_MISSING", false},
+		{"deadlock", fmt.Errorf("update: %w", &pgconn.PgError{Code: "40P01"}), codes.Unavailable, "CONCURRENT_UPDATE", true},
+		{"statement timeout", &pgconn.PgError{Code: "57014"}, codes.DeadlineExceeded, "QUERY_CANCELED", false},
+		{"connection lost", &pgconn.PgError{Code: "08006"}, codes.Unavailable, "DATABASE_UNAVAILABLE", true},
+		{"not found", bllerrors.NotFound("ORDER_NOT_FOUND", "order 1 not found"), codes.NotFound, "ORDER_NOT_FOUND", false},
+		{"aborted", bllerrors.Aborted("ORDER_NOT_CREATED_YET", "later"), codes.Aborted, "ORDER_NOT_CREATED_YET", false},
+		{"unclassified", errors.New("pq: secret detail"), codes.Internal, "INTERNAL", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(toStatus(tt.err))
+			if st.Code() != tt.wantCode {
+				t.Errorf("code = %s, want %s", st.Code(), tt.wantCode)
+			}
+
+			var reason string
+			var retry bool
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *errdetails.ErrorInfo:
+					reason = d.Reason
+				case *errdetails.RetryInfo:
+					retry = true
+				}
+			}
+			if reason != tt.wantReason || retry != tt.wantRetry {
+				t.Errorf("reason, retry = %s, %v; want %s, %v", reason, retry, tt.wantReason, tt.wantRetry)
+			}
+			if tt.wantCode == codes.Internal && st.Message() != "Internal server error" {
+				t.Errorf("internal error message %q leaks the cause", st.Message())
+			}
+		})
+	}
+
+	validation := status.Error(codes.InvalidArgument, "validation error")
+	if got := toStatus(validation); got != validation {
+		t.Errorf("status error = %v, want it passed through", got)
+	}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/validators"
+	"go.uber.org/zap"
+)
+
+type InventoryService struct {
+	pb.UnimplementedInventoryServiceServer
+
+	log               *zap.SugaredLogger
+	pgClient          *postgres.PostgresClient
+	inventorySettings settings.InventorySettings
+}
+
+func NewInventoryService(pgClient *postgres.PostgresClient, inventorySettings settings.InventorySettings, log *zap.SugaredLogger) *InventoryService {
+	return &InventoryService{
+		pgClient:          pgClient,
+		inventorySettings: inventorySettings,
+		log:               log,
+	}
+}
+
+func (s *InventoryService) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.AdjustStockResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "adjust_stock")
+	l.Infow("inventory_controller.adjust_stock_start")
+
+	if errs := validators.ValidateAdjustStockRequest(req); errs != nil {
+		l.Errorw("inventory_controller.adjust_stock_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	var adjustments []models.StockAdjustment
+	for _, a := range req.Adjustments {
+		adjustments = append(adjustments, mappers.PbStockAdjustmentToBll(a))
+	}
+
+	inventorySvc := s.createBllInventoryService(l)
+	defer inventorySvc.UnitOfWork().Close()
+
+	result, err := inventorySvc.AdjustStock(ctx, adjustments)
+	if err != nil {
+		l.Errorw("inventory_controller.adjust_stock_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("inventory_controller.adjust_stock_success")
+
+	var resp pb.AdjustStockResponse
+	for _, st := range result {
+		resp.Stocks = append(resp.Stocks, mappers.BllStockToPb(st))
+	}
+
+	return &resp, nil
+}
+
+func (s *InventoryService) QueryStock(ctx context.Context, req *pb.QueryStockRequest) (*pb.QueryStockResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "query_stock")
+	l.Infow("inventory_controller.query_stock_start")
+
+	if errs := validators.ValidateQueryStockRequest(req); errs != nil {
+		l.Errorw("inventory_controller.query_stock_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	inventorySvc := s.createBllInventoryService(l)
+	defer inventorySvc.UnitOfWork().Close()
+
+	result, err := inventorySvc.GetStocks(ctx, mappers.PbQueryStockToBll(req))
+	if err != nil {
+		l.Errorw("inventory_controller.get_stocks_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("inventory_controller.query_stock_success")
+
+	var resp pb.QueryStockResponse
+	for _, st := range result {
+		resp.Stocks = append(resp.Stocks, mappers.BllStockToPb(st))
+	}
+
+	return &resp, nil
+}
+
+func (s *InventoryService) createBllInventoryService(log *zap.SugaredLogger) *bllServices.InventoryService {
+	uow := unitofwork.New(s.pgClient)
+	stockRepo := repositories.NewStockRepository(uow)
+	reservationRepo := repositories.NewStockReservationRepository(uow)
+	return bllServices.NewInventoryService(uow, stockRepo, reservationRepo, s.inventorySettings.DefaultWarehouseId, log)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 
 	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/cache"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
 	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
 	publisher "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/publisher/rabbitmq"
 	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
@@ -14,8 +16,6 @@ import (
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
 	"github.com/ZaiiiRan/backend_labs/order-service/internal/validators"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type OrderService struct {
@@ -24,21 +24,34 @@ type OrderService struct {
 	log                   *zap.SugaredLogger
 	pgClient              *postgres.PostgresClient
 	omsPublisher *publisher.Publisher
+	inventorySettings     settings.InventorySettings
+	catalogMode           models.ProductValidationMode
+	productCache          *cache.ProductCache
 }
 
-func NewOrderService(pgClient *postgres.PostgresClient, omsPublisher *publisher.Publisher, log *zap.SugaredLogger) *OrderService {
+func NewOrderService(
+	pgClient *postgres.PostgresClient,
+	omsPublisher *publisher.Publisher,
+	inventorySettings settings.InventorySettings,
+	catalogSettings settings.ProductCatalogSettings,
+	productCache *cache.ProductCache,
+	log *zap.SugaredLogger,
+) *OrderService {
 	return &OrderService{
 		pgClient:              pgClient,
 		omsPublisher: omsPublisher,
+		inventorySettings:     inventorySettings,
+		catalogMode:           models.StringToProductValidationMode(catalogSettings.ValidationMode),
+		productCache:          productCache,
 		log:                   log,
 	}
 }
 
 func (s *OrderService) BatchCreate(ctx context.Context, req *pb.BatchCreateRequest) (*pb.BatchCreateResponse, error) {
-	l := s.log.With("op", "batch_create")
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "batch_create")
 	l.Infow("order_controller.batch_create_start")
 
-	if errs := validators.ValidateBatchCreateRequest(req); errs != nil {
+	if errs := validators.ValidateBatchCreateRequest(req, s.catalogMode); errs != nil {
 		l.Errorw("order_controller.batch_create_request_validation_failed", "err", errs)
 		return nil, errs.ToStatus()
 	}
@@ -50,13 +63,28 @@ func (s *OrderService) BatchCreate(ctx context.Context, req *pb.BatchCreateReque
 		orders = append(orders, order)
 	}
 
+	if s.catalogMode != models.PRODUCT_VALIDATION_MODE_OFF {
+		productSvc := s.createBllProductService(l)
+		defer productSvc.UnitOfWork().Close()
+
+		errs, err := productSvc.ApplyCatalog(ctx, orders, s.catalogMode)
+		if err != nil {
+			l.Errorw("order_controller.apply_catalog_failed", "err", err)
+			return nil, toStatus(err)
+		}
+		if errs != nil {
+			l.Errorw("order_controller.batch_create_catalog_validation_failed", "err", errs)
+			return nil, errs.ToStatus()
+		}
+	}
+
 	orderSvc := s.createBllOrderService(l)
 	defer orderSvc.UnitOfWork().Close()
 
 	result, err := orderSvc.BatchInsert(ctx, orders)
 	if err != nil {
-		l.Errorw("order_controller.batch_insert_failed", "err", "Internal server error")
-		return nil, status.Errorf(codes.Internal, "Internal server error")
+		l.Errorw("order_controller.batch_insert_failed", "err", err)
+		return nil, toStatus(err)
 	}
 
 	l.Infow("order_controller.batch_create_success")
@@ -70,7 +98,7 @@ func (s *OrderService) BatchCreate(ctx context.Context, req *pb.BatchCreateReque
 }
 
 func (s *OrderService) QueryOrders(ctx context.Context, req *pb.QueryOrdersRequest) (*pb.QueryOrdersResponse, error) {
-	l := s.log.With("op", "query_orders")
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "query_orders")
 	l.Infow("order_controller.query_orders_start")
 
 	if errs := validators.ValidateQueryOrdersRequest(req); errs != nil {
@@ -83,8 +111,8 @@ func (s *OrderService) QueryOrders(ctx context.Context, req *pb.QueryOrdersReque
 
 	result, err := orderSvc.GetOrders(ctx, mappers.PbQueryOrderItemsToBll(req))
 	if err != nil {
-		l.Errorw("order_controller.get_orders_failed", "err", "Internal server error")
-		return nil, status.Errorf(codes.Internal, "Internal server error")
+		l.Errorw("order_controller.get_orders_failed", "err", err)
+		return nil, toStatus(err)
 	}
 
 	l.Infow("order_controller.query_orders_success")
@@ -98,7 +126,7 @@ func (s *OrderService) QueryOrders(ctx context.Context, req *pb.QueryOrdersReque
 }
 
 func (s *OrderService) UpdateOrdersStatus(ctx context.Context, req *pb.UpdateOrdersStatusRequest) (*pb.UpdateOrdersStatusResponse, error) {
-	l := s.log.With("op", "update_orders_status")
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "update_orders_status")
 	l.Infow("order_controller.update_orders_status_start")
 
 	if errs := validators.ValidateUpdateOrdersStatusRequest(req); errs != nil {
@@ -112,10 +140,7 @@ func (s *OrderService) UpdateOrdersStatus(ctx context.Context, req *pb.UpdateOrd
 	_, err := orderSvc.UpdateOrdersStatus(ctx, req.OrderIds, models.OrderStatus(req.NewStatus))
 	if err != nil {
 		l.Errorw("order_controller.update_orders_status_failed", "err", err)
-		if utils.IsGrpcError(err) {
-			return nil, err
-		}
-		return nil, status.Errorf(codes.Internal, "Internal server error")
+		return nil, toStatus(err)
 	}
 
 	l.Infow("order_controller.update_orders_status_success")
@@ -123,7 +148,7 @@ func (s *OrderService) UpdateOrdersStatus(ctx context.Context, req *pb.UpdateOrd
 }
 
 func (s *OrderService) AuditLogOrderBatchCreate(ctx context.Context, req *pb.AuditLogOrderBatchCreateRequest) (*pb.AuditLogOrderBatchCreateResponse, error) {
-	l := s.log.With("op", "audit_log_order_batch_create")
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "audit_log_order_batch_create")
 	l.Infow("order_controller.audit_log_order_batch_create_start")
 
 	if errs := validators.ValidateAuditLogOrderBatchCreateRequest(req); errs != nil {
@@ -141,8 +166,8 @@ func (s *OrderService) AuditLogOrderBatchCreate(ctx context.Context, req *pb.Aud
 
 	result, err := auditLogOrderReporderSvc.BatchInsert(ctx, logs)
 	if err != nil {
-		l.Errorw("order_controller.audit_log_order_batch_insert_failed", "err", "Internal server error")
-		return nil, status.Errorf(codes.Internal, "Internal server error")
+		l.Errorw("order_controller.audit_log_order_batch_insert_failed", "err", err)
+		return nil, toStatus(err)
 	}
 
 	l.Infow("order_controller.audit_log_order_batch_create_success")
@@ -159,7 +184,21 @@ func (s *OrderService) createBllOrderService(log *zap.SugaredLogger) *bllService
 	uow := unitofwork.New(s.pgClient)
 	orderRepo := repositories.NewOrderRepository(uow)
 	orderItemRepo := repositories.NewOrderItemRepository(uow)
-	return bllServices.NewOrderService(uow, orderRepo, orderItemRepo, s.omsPublisher, log)
+
+	var inventorySvc *bllServices.InventoryService
+	if s.inventorySettings.ReservationEnabled {
+		stockRepo := repositories.NewStockRepository(uow)
+		reservationRepo := repositories.NewStockReservationRepository(uow)
+		inventorySvc = bllServices.NewInventoryService(uow, stockRepo, reservationRepo, s.inventorySettings.DefaultWarehouseId, log)
+	}
+
+	return bllServices.NewOrderService(uow, orderRepo, orderItemRepo, inventorySvc, s.omsPublisher, log)
+}
+
+func (s *OrderService) createBllProductService(log *zap.SugaredLogger) *bllServices.ProductService {
+	uow := unitofwork.New(s.pgClient)
+	repo := repositories.NewProductRepository(uow)
+	return bllServices.NewProductService(uow, repo, s.productCache, log)
 }
 
 func (s *OrderService) createBllAuditLogOrderService(log *zap.SugaredLogger) *bllServices.AuditLogOrderService {
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/client/payment"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	publisher "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/publisher/rabbitmq"
+	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/validators"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type PaymentService struct {
+	pb.UnimplementedPaymentServiceServer
+
+	log               *zap.SugaredLogger
+	pgClient          *postgres.PostgresClient
+	omsPublisher      *publisher.Publisher
+	provider          payment.PaymentProvider
+	cfg               settings.PaymentProviderSettings
+	inventorySettings settings.InventorySettings
+}
+
+func NewPaymentService(
+	pgClient *postgres.PostgresClient,
+	omsPublisher *publisher.Publisher,
+	provider payment.PaymentProvider,
+	cfg settings.PaymentProviderSettings,
+	inventorySettings settings.InventorySettings,
+	log *zap.SugaredLogger,
+) *PaymentService {
+	return &PaymentService{
+		pgClient:          pgClient,
+		omsPublisher:      omsPublisher,
+		provider:          provider,
+		cfg:               cfg,
+		inventorySettings: inventorySettings,
+		log:               log,
+	}
+}
+
+func (s *PaymentService) StartPayment(ctx context.Context, req *pb.StartPaymentRequest) (*pb.StartPaymentResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "start_payment")
+	l.Infow("payment_controller.start_payment_start")
+
+	if errs := validators.ValidateStartPaymentRequest(req); errs != nil {
+		l.Errorw("payment_controller.start_payment_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	paymentSvc := s.createBllPaymentService(l)
+	defer paymentSvc.UnitOfWork().Close()
+
+	result, err := paymentSvc.StartPayment(ctx, req.OrderId, s.cfg.AutoCapture)
+	if err != nil {
+		l.Errorw("payment_controller.start_payment_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("payment_controller.start_payment_success")
+	return &pb.StartPaymentResponse{Payment: mappers.BllPaymentToPb(result)}, nil
+}
+
+func (s *PaymentService) QueryPayments(ctx context.Context, req *pb.QueryPaymentsRequest) (*pb.QueryPaymentsResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "query_payments")
+	l.Infow("payment_controller.query_payments_start")
+
+	if errs := validators.ValidateQueryPaymentsRequest(req); errs != nil {
+		l.Errorw("payment_controller.query_payments_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	paymentSvc := s.createBllPaymentService(l)
+	defer paymentSvc.UnitOfWork().Close()
+
+	result, err := paymentSvc.GetPayments(ctx, mappers.PbQueryPaymentsToBll(req))
+	if err != nil {
+		l.Errorw("payment_controller.get_payments_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("payment_controller.query_payments_success")
+
+	var resp pb.QueryPaymentsResponse
+	for _, p := range result {
+		resp.Payments = append(resp.Payments, mappers.BllPaymentToPb(p))
+	}
+
+	return &resp, nil
+}
+
+func (s *PaymentService) HandlePaymentCallback(ctx context.Context, req *pb.HandlePaymentCallbackRequest) (*pb.HandlePaymentCallbackResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "handle_payment_callback")
+	l.Infow("payment_controller.handle_payment_callback_start")
+
+	if !payment.VerifyCallbackSignature(s.cfg.CallbackSecret, req.Payload, req.Signature) {
+		l.Warnw("payment_controller.handle_payment_callback_bad_signature")
+		return nil, status.Errorf(codes.Unauthenticated, "invalid callback signature")
+	}
+
+	var event payment.CallbackEvent
+	if err := json.Unmarshal(req.Payload, &event); err != nil {
+		l.Errorw("payment_controller.handle_payment_callback_bad_payload", "err", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid callback payload")
+	}
+	if errs := validators.ValidatePaymentCallbackEvent(event.Provider, event.ProviderPaymentID, event.Event); errs != nil {
+		l.Errorw("payment_controller.handle_payment_callback_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	paymentSvc := s.createBllPaymentService(l)
+	defer paymentSvc.UnitOfWork().Close()
+
+	if _, err := paymentSvc.HandleCallback(ctx, event); err != nil {
+		l.Errorw("payment_controller.handle_payment_callback_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("payment_controller.handle_payment_callback_success")
+	return &pb.HandlePaymentCallbackResponse{}, nil
+}
+
+func (s *PaymentService) createBllPaymentService(log *zap.SugaredLogger) *bllServices.PaymentService {
+	uow := unitofwork.New(s.pgClient)
+	paymentRepo := repositories.NewPaymentRepository(uow)
+	orderRepo := repositories.NewOrderRepository(uow)
+	orderItemRepo := repositories.NewOrderItemRepository(uow)
+
+	var inventorySvc *bllServices.InventoryService
+	if s.inventorySettings.ReservationEnabled {
+		stockRepo := repositories.NewStockRepository(uow)
+		reservationRepo := repositories.NewStockReservationRepository(uow)
+		inventorySvc = bllServices.NewInventoryService(uow, stockRepo, reservationRepo, s.inventorySettings.DefaultWarehouseId, log)
+	}
+	orderSvc := bllServices.NewOrderService(uow, orderRepo, orderItemRepo, inventorySvc, s.omsPublisher, log)
+
+	return bllServices.NewPaymentService(uow, paymentRepo, orderRepo, s.provider, orderSvc, log)
+}
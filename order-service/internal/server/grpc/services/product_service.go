@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/cache"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/validators"
+	"go.uber.org/zap"
+)
+
+type ProductService struct {
+	pb.UnimplementedProductServiceServer
+
+	log          *zap.SugaredLogger
+	pgClient     *postgres.PostgresClient
+	productCache *cache.ProductCache
+}
+
+func NewProductService(pgClient *postgres.PostgresClient, productCache *cache.ProductCache, log *zap.SugaredLogger) *ProductService {
+	return &ProductService{
+		pgClient:     pgClient,
+		productCache: productCache,
+		log:          log,
+	}
+}
+
+func (s *ProductService) UpsertProducts(ctx context.Context, req *pb.UpsertProductsRequest) (*pb.UpsertProductsResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "upsert_products")
+	l.Infow("product_controller.upsert_products_start")
+
+	if errs := validators.ValidateUpsertProductsRequest(req); errs != nil {
+		l.Errorw("product_controller.upsert_products_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	var products []models.Product
+	for _, p := range req.Products {
+		products = append(products, mappers.PbProductToBll(p))
+	}
+
+	productSvc := s.createBllProductService(l)
+	defer productSvc.UnitOfWork().Close()
+
+	result, err := productSvc.UpsertProducts(ctx, products)
+	if err != nil {
+		l.Errorw("product_controller.upsert_products_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("product_controller.upsert_products_success")
+
+	var resp pb.UpsertProductsResponse
+	for _, p := range result {
+		resp.Products = append(resp.Products, mappers.BllProductToPb(p))
+	}
+
+	return &resp, nil
+}
+
+func (s *ProductService) QueryProducts(ctx context.Context, req *pb.QueryProductsRequest) (*pb.QueryProductsResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "query_products")
+	l.Infow("product_controller.query_products_start")
+
+	if errs := validators.ValidateQueryProductsRequest(req); errs != nil {
+		l.Errorw("product_controller.query_products_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	productSvc := s.createBllProductService(l)
+	defer productSvc.UnitOfWork().Close()
+
+	result, err := productSvc.GetProducts(ctx, mappers.PbQueryProductsToBll(req))
+	if err != nil {
+		l.Errorw("product_controller.get_products_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("product_controller.query_products_success")
+
+	var resp pb.QueryProductsResponse
+	for _, p := range result {
+		resp.Products = append(resp.Products, mappers.BllProductToPb(p))
+	}
+
+	return &resp, nil
+}
+
+func (s *ProductService) DeleteProducts(ctx context.Context, req *pb.DeleteProductsRequest) (*pb.DeleteProductsResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "delete_products")
+	l.Infow("product_controller.delete_products_start")
+
+	if errs := validators.ValidateDeleteProductsRequest(req); errs != nil {
+		l.Errorw("product_controller.delete_products_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	productSvc := s.createBllProductService(l)
+	defer productSvc.UnitOfWork().Close()
+
+	if err := productSvc.DeleteProducts(ctx, req.Ids); err != nil {
+		l.Errorw("product_controller.delete_products_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("product_controller.delete_products_success")
+	return &pb.DeleteProductsResponse{}, nil
+}
+
+func (s *ProductService) createBllProductService(log *zap.SugaredLogger) *bllServices.ProductService {
+	uow := unitofwork.New(s.pgClient)
+	repo := repositories.NewProductRepository(uow)
+	return bllServices.NewProductService(uow, repo, s.productCache, log)
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/mappers"
+	bllServices "github.com/ZaiiiRan/backend_labs/order-service/internal/bll/services"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/dal/postgres"
+	repositories "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/repositories/postgres"
+	unitofwork "github.com/ZaiiiRan/backend_labs/order-service/internal/dal/unit_of_work/postgres"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/validators"
+	"go.uber.org/zap"
+)
+
+type WebhookService struct {
+	pb.UnimplementedWebhookServiceServer
+
+	log      *zap.SugaredLogger
+	pgClient *postgres.PostgresClient
+}
+
+func NewWebhookService(pgClient *postgres.PostgresClient, log *zap.SugaredLogger) *WebhookService {
+	return &WebhookService{
+		pgClient: pgClient,
+		log:      log,
+	}
+}
+
+func (s *WebhookService) CreateWebhookSubscription(ctx context.Context, req *pb.CreateWebhookSubscriptionRequest) (*pb.CreateWebhookSubscriptionResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "create_webhook_subscription")
+	l.Infow("webhook_controller.create_subscription_start")
+
+	if errs := validators.ValidateCreateWebhookSubscriptionRequest(ctx, req); errs != nil {
+		l.Errorw("webhook_controller.create_subscription_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	webhookSvc := s.createBllWebhookService(l)
+	defer webhookSvc.UnitOfWork().Close()
+
+	result, err := webhookSvc.CreateSubscription(ctx, mappers.PbCreateWebhookSubscriptionToBll(req))
+	if err != nil {
+		l.Errorw("webhook_controller.create_subscription_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("webhook_controller.create_subscription_success", "subscription_id", result.ID)
+	return &pb.CreateWebhookSubscriptionResponse{
+		Subscription: mappers.BllWebhookSubscriptionToPb(result, true),
+	}, nil
+}
+
+func (s *WebhookService) QueryWebhookSubscriptions(ctx context.Context, req *pb.QueryWebhookSubscriptionsRequest) (*pb.QueryWebhookSubscriptionsResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "query_webhook_subscriptions")
+	l.Infow("webhook_controller.query_subscriptions_start")
+
+	if errs := validators.ValidateQueryWebhookSubscriptionsRequest(req); errs != nil {
+		l.Errorw("webhook_controller.query_subscriptions_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	webhookSvc := s.createBllWebhookService(l)
+	defer webhookSvc.UnitOfWork().Close()
+
+	result, err := webhookSvc.GetSubscriptions(ctx, mappers.PbQueryWebhookSubscriptionsToBll(req))
+	if err != nil {
+		l.Errorw("webhook_controller.get_subscriptions_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("webhook_controller.query_subscriptions_success")
+
+	var resp pb.QueryWebhookSubscriptionsResponse
+	for _, sub := range result {
+		resp.Subscriptions = append(resp.Subscriptions, mappers.BllWebhookSubscriptionToPb(sub, false))
+	}
+
+	return &resp, nil
+}
+
+func (s *WebhookService) UpdateWebhookSubscription(ctx context.Context, req *pb.UpdateWebhookSubscriptionRequest) (*pb.UpdateWebhookSubscriptionResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "update_webhook_subscription")
+	l.Infow("webhook_controller.update_subscription_start")
+
+	if errs := validators.ValidateUpdateWebhookSubscriptionRequest(ctx, req); errs != nil {
+		l.Errorw("webhook_controller.update_subscription_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	webhookSvc := s.createBllWebhookService(l)
+	defer webhookSvc.UnitOfWork().Close()
+
+	result, err := webhookSvc.UpdateSubscription(ctx, mappers.PbUpdateWebhookSubscriptionToBll(req), req.RotateSecret)
+	if err != nil {
+		l.Errorw("webhook_controller.update_subscription_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("webhook_controller.update_subscription_success", "subscription_id", result.ID)
+	return &pb.UpdateWebhookSubscriptionResponse{
+		Subscription: mappers.BllWebhookSubscriptionToPb(result, req.RotateSecret),
+	}, nil
+}
+
+func (s *WebhookService) DeleteWebhookSubscriptions(ctx context.Context, req *pb.DeleteWebhookSubscriptionsRequest) (*pb.DeleteWebhookSubscriptionsResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "delete_webhook_subscriptions")
+	l.Infow("webhook_controller.delete_subscriptions_start")
+
+	if errs := validators.ValidateDeleteWebhookSubscriptionsRequest(req); errs != nil {
+		l.Errorw("webhook_controller.delete_subscriptions_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	webhookSvc := s.createBllWebhookService(l)
+	defer webhookSvc.UnitOfWork().Close()
+
+	if err := webhookSvc.DeleteSubscriptions(ctx, req.Ids); err != nil {
+		l.Errorw("webhook_controller.delete_subscriptions_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("webhook_controller.delete_subscriptions_success")
+	return &pb.DeleteWebhookSubscriptionsResponse{}, nil
+}
+
+func (s *WebhookService) QueryWebhookDeliveries(ctx context.Context, req *pb.QueryWebhookDeliveriesRequest) (*pb.QueryWebhookDeliveriesResponse, error) {
+	l := utils.LoggerFromContext(ctx, s.log).With("op", "query_webhook_deliveries")
+	l.Infow("webhook_controller.query_deliveries_start")
+
+	if errs := validators.ValidateQueryWebhookDeliveriesRequest(req); errs != nil {
+		l.Errorw("webhook_controller.query_deliveries_request_validation_failed", "err", errs)
+		return nil, errs.ToStatus()
+	}
+
+	webhookSvc := s.createBllWebhookService(l)
+	defer webhookSvc.UnitOfWork().Close()
+
+	result, err := webhookSvc.GetDeliveries(ctx, mappers.PbQueryWebhookDeliveriesToBll(req))
+	if err != nil {
+		l.Errorw("webhook_controller.get_deliveries_failed", "err", err)
+		return nil, toStatus(err)
+	}
+
+	l.Infow("webhook_controller.query_deliveries_success")
+
+	var resp pb.QueryWebhookDeliveriesResponse
+	for _, d := range result {
+		resp.Deliveries = append(resp.Deliveries, mappers.BllWebhookDeliveryToPb(d))
+	}
+
+	return &resp, nil
+}
+
+func (s *WebhookService) createBllWebhookService(log *zap.SugaredLogger) *bllServices.WebhookService {
+	uow := unitofwork.New(s.pgClient)
+	subscriptionRepo := repositories.NewWebhookSubscriptionRepository(uow)
+	deliveryRepo := repositories.NewWebhookDeliveryRepository(uow)
+	return bllServices.NewWebhookService(uow, subscriptionRepo, deliveryRepo, log)
+}
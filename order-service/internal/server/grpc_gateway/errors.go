@@ -0,0 +1,50 @@
+package grpcgateway
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorHandler keeps the default status mapping (ResourceExhausted is already 429) except
+// where httpStatusFromCode differs, and turns RetryInfo into a Retry-After header that
+// HTTP clients understand.
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	setRetryAfter(w, err)
+	if st, ok := status.FromError(err); ok {
+		if code := httpStatusFromCode(st.Code()); code != runtime.HTTPStatusFromCode(st.Code()) {
+			err = &runtime.HTTPStatusError{HTTPStatus: code, Err: err}
+		}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+// The gateway maps FailedPrecondition to 400, but the service uses it for requests that
+// are well-formed and conflict with the resource's current state, e.g. paying for a
+// cancelled order.
+func httpStatusFromCode(code codes.Code) int {
+	if code == codes.FailedPrecondition {
+		return http.StatusConflict
+	}
+	return runtime.HTTPStatusFromCode(code)
+}
+
+func setRetryAfter(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			seconds := int(math.Ceil(info.GetRetryDelay().AsDuration().Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+			return
+		}
+	}
+}
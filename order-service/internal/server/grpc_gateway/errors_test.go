@@ -0,0 +1,40 @@
+package grpcgateway
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func withRetryInfo(delay time.Duration) error {
+	st, _ := status.New(codes.ResourceExhausted, "rate limit exceeded").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	return st.Err()
+}
+
+func TestSetRetryAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"whole seconds", withRetryInfo(100 * time.Second), "100"},
+		{"rounded up", withRetryInfo(1500 * time.Millisecond), "2"},
+		{"at least a second", withRetryInfo(10 * time.Millisecond), "1"},
+		{"no retry info", status.Error(codes.ResourceExhausted, "rate limit exceeded"), ""},
+		{"not a status", errors.New("boom"), ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		setRetryAfter(w, tt.err)
+		if got := w.Header().Get("Retry-After"); got != tt.want {
+			t.Errorf("%s: Retry-After = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package grpcgateway
+
+import (
+	"io"
+	"net/http"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	paymentCallbackPath      = "/api/v1/payment/callback"
+	paymentSignatureHeader   = "X-Payment-Signature"
+	maxPaymentCallbackLength = 1 << 20
+)
+
+// The callback is signed over the raw body, so it can't go through the generated
+// JSON handler, which would re-encode the payload before it reaches the service.
+func paymentCallbackHandler(client pb.PaymentServiceClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		payload, err := io.ReadAll(io.LimitReader(r.Body, maxPaymentCallbackLength))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		if requestID := r.Header.Get("X-Request-Id"); requestID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, utils.REQUEST_ID_METADATA_KEY, requestID)
+		}
+
+		_, err = client.HandlePaymentCallback(ctx, &pb.HandlePaymentCallbackRequest{
+			Payload:   payload,
+			Signature: r.Header.Get(paymentSignatureHeader),
+		})
+		if err != nil {
+			st := status.Convert(err)
+			setRetryAfter(w, err)
+			http.Error(w, st.Message(), httpStatusFromCode(st.Code()))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -5,31 +5,91 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strings"
 
 	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/health"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/tlsconfig"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Server struct {
-	srv *http.Server
+	srv      *http.Server
+	grpcConn *grpc.ClientConn
+	tls      bool
 }
 
-func NewServer(ctx context.Context, port int, grpcPort int) (*Server, error) {
-	mux := runtime.NewServeMux()
-	grpcAddr := fmt.Sprintf("localhost:%d", grpcPort)
+func NewServer(
+	ctx context.Context,
+	cfg settings.HttpServerSettings,
+	grpcCfg settings.GrpcServerSettings,
+	checker *health.Checker,
+	handlers map[string]http.Handler,
+) (*Server, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithErrorHandler(errorHandler),
+	)
+	grpcAddr := fmt.Sprintf("localhost:%d", grpcCfg.Port)
+
+	transportCreds := insecure.NewCredentials()
+	if grpcCfg.Tls.Enabled {
+		tlsCfg, err := tlsconfig.NewClientConfig(grpcCfg.GatewayTls)
+		if err != nil {
+			return nil, fmt.Errorf("gateway grpc client tls: %w", err)
+		}
+		transportCreds = credentials.NewTLS(tlsCfg)
+	}
 
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
 	if err := pb.RegisterOrderServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
 		return nil, fmt.Errorf("failed to register gateway handler: %w", err)
 	}
+	if err := pb.RegisterWebhookServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+		return nil, fmt.Errorf("failed to register webhook gateway handler: %w", err)
+	}
+	if err := pb.RegisterInventoryServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+		return nil, fmt.Errorf("failed to register inventory gateway handler: %w", err)
+	}
+	if err := pb.RegisterProductServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+		return nil, fmt.Errorf("failed to register product gateway handler: %w", err)
+	}
+	if err := pb.RegisterPaymentServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+		return nil, fmt.Errorf("failed to register payment gateway handler: %w", err)
+	}
+	if err := pb.RegisterDlqServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+		return nil, fmt.Errorf("failed to register dlq gateway handler: %w", err)
+	}
+
+	grpcConn, err := grpc.NewClient(grpcAddr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grpc client: %w", err)
+	}
 
 	swaggerDir := filepath.Join("gen", "openapiv2", "order-service", "v1")
 
 	rootMux := http.NewServeMux()
 	rootMux.Handle("/", mux)
+	rootMux.Handle("/metrics", promhttp.Handler())
+	rootMux.Handle("/healthz", checker.LivenessHandler())
+	rootMux.Handle("/readyz", checker.ReadinessHandler())
+	rootMux.Handle(paymentCallbackPath, paymentCallbackHandler(pb.NewPaymentServiceClient(grpcConn)))
+	for path, h := range handlers {
+		rootMux.Handle(path, h)
+	}
 
 	rootMux.Handle("/swagger/", http.StripPrefix("/swagger/",
 		http.FileServer(http.Dir(swaggerDir)),
@@ -43,22 +103,66 @@ func NewServer(ctx context.Context, port int, grpcPort int) (*Server, error) {
 		),
 	)
 
+	handler := otelhttp.NewHandler(rootMux, "grpc_gateway",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}),
+	)
+
 	srv := &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
-		Handler: rootMux,
+		Addr:    fmt.Sprintf(":%d", cfg.Port),
+		Handler: handler,
+	}
+	if cfg.Tls.Enabled {
+		tlsCfg, err := tlsconfig.NewServerConfig(cfg.Tls)
+		if err != nil {
+			grpcConn.Close()
+			return nil, fmt.Errorf("gateway tls: %w", err)
+		}
+		srv.TLSConfig = tlsCfg
 	}
 
-	return &Server{srv: srv}, nil
+	return &Server{srv: srv, grpcConn: grpcConn, tls: cfg.Tls.Enabled}, nil
 }
 
 func (s *Server) Start() error {
+	if s.tls {
+		// Certificates come from TLSConfig, which reloads them on change.
+		return s.srv.ListenAndServeTLS("", "")
+	}
 	return s.srv.ListenAndServe()
 }
 
 func (s *Server) Stop(ctx context.Context) error {
+	defer s.grpcConn.Close()
 	return s.srv.Shutdown(ctx)
 }
 
 func (s *Server) Addr() string {
 	return s.srv.Addr
 }
+
+func incomingHeaderMatcher(key string) (string, bool) {
+	// The gateway always passes Authorization through as "authorization", which is where
+	// the auth interceptor reads it; the prefixed copy would only leak the token further.
+	if strings.EqualFold(key, "Authorization") {
+		return "", false
+	}
+	if strings.EqualFold(key, utils.TENANT_METADATA_KEY) {
+		return utils.TENANT_METADATA_KEY, true
+	}
+	if strings.EqualFold(key, utils.REQUEST_ID_METADATA_KEY) {
+		return utils.REQUEST_ID_METADATA_KEY, true
+	}
+	if strings.EqualFold(key, "X-Api-Key") {
+		return "x-api-key", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == utils.REQUEST_ID_METADATA_KEY {
+		return "X-Request-Id", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,118 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const reloadCheckInterval = 10 * time.Second
+
+// fileReloader keeps a certificate and CA pool loaded from disk and re-reads them when
+// any of the files changes, so rotated certificates are picked up without a restart.
+// A failed reload keeps serving the previous material.
+type fileReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	pool      *x509.CertPool
+	modTimes  map[string]time.Time
+	checkedAt time.Time
+}
+
+func newFileReloader(certFile, keyFile, caFile string) (*fileReloader, error) {
+	r := &fileReloader{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *fileReloader) certificate() (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maybeReload()
+	if r.cert == nil {
+		return nil, fmt.Errorf("no certificate configured")
+	}
+	return r.cert, nil
+}
+
+func (r *fileReloader) caPool() *x509.CertPool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maybeReload()
+	return r.pool
+}
+
+func (r *fileReloader) maybeReload() {
+	if time.Since(r.checkedAt) < reloadCheckInterval {
+		return
+	}
+	r.checkedAt = time.Now()
+
+	changed := false
+	for path, loaded := range r.modTimes {
+		info, err := os.Stat(path)
+		if err == nil && !info.ModTime().Equal(loaded) {
+			changed = true
+			break
+		}
+	}
+	if changed {
+		_ = r.loadLocked()
+	}
+}
+
+func (r *fileReloader) load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.loadLocked()
+}
+
+func (r *fileReloader) loadLocked() error {
+	modTimes := make(map[string]time.Time)
+	for _, path := range []string{r.certFile, r.keyFile, r.caFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", path, err)
+		}
+		modTimes[path] = info.ModTime()
+	}
+
+	var cert *tls.Certificate
+	if r.certFile != "" {
+		c, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return fmt.Errorf("load key pair: %w", err)
+		}
+		cert = &c
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("read ca: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in %s", r.caFile)
+		}
+	}
+
+	r.cert = cert
+	r.pool = pool
+	r.modTimes = modTimes
+	r.checkedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,102 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/config/settings"
+)
+
+func NewServerConfig(cfg settings.TlsServerSettings) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("tls: CertFile and KeyFile are required")
+	}
+
+	r, err := newFileReloader(cfg.CertFile, cfg.KeyFile, cfg.ClientCaFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: %w", err)
+	}
+
+	clientAuth := tls.NoClientCert
+	if cfg.ClientCaFile != "" {
+		clientAuth = tls.VerifyClientCertIfGiven
+		if cfg.RequireClientCert {
+			clientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: clientAuth,
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+
+	// The client CA pool can only be swapped per handshake through GetConfigForClient.
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cert, err := r.certificate()
+		if err != nil {
+			return nil, err
+		}
+		c := base.Clone()
+		c.GetConfigForClient = nil
+		c.Certificates = []tls.Certificate{*cert}
+		c.ClientCAs = r.caPool()
+		return c, nil
+	}
+	return base, nil
+}
+
+func NewClientConfig(cfg settings.TlsClientSettings) (*tls.Config, error) {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("tls: CertFile and KeyFile must be set together")
+	}
+
+	r, err := newFileReloader(cfg.CertFile, cfg.KeyFile, cfg.CaFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: %w", err)
+	}
+
+	c := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+	if cfg.CertFile != "" {
+		c.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.certificate()
+		}
+	}
+	if cfg.CaFile != "" {
+		// Verification is done by hand so a rotated CA file applies to new connections.
+		c.InsecureSkipVerify = true
+		c.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyServer(cs, r.caPool(), cfg.ServerName)
+		}
+	}
+	return c, nil
+}
+
+func verifyServer(cs tls.ConnectionState, roots *x509.CertPool, serverName string) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: server presented no certificate")
+	}
+	if serverName == "" {
+		serverName = cs.ServerName
+	}
+	if host, _, err := net.SplitHostPort(serverName); err == nil {
+		serverName = host
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		DNSName:       serverName,
+	})
+	return err
+}
@@ -0,0 +1,22 @@
+package utils
+
+import "context"
+
+// AccessScope narrows what a caller may do with orders. Calls without a scope, such as
+// background jobs and privileged roles, are unrestricted.
+type AccessScope struct {
+	CustomerID int64
+	// AllowedStatuses lists the statuses the caller may move orders to; empty means none.
+	AllowedStatuses []string
+}
+
+type accessScopeKey struct{}
+
+func WithAccessScope(ctx context.Context, scope *AccessScope) context.Context {
+	return context.WithValue(ctx, accessScopeKey{}, scope)
+}
+
+func AccessScopeFromContext(ctx context.Context) *AccessScope {
+	scope, _ := ctx.Value(accessScopeKey{}).(*AccessScope)
+	return scope
+}
@@ -0,0 +1,17 @@
+package utils
+
+import "context"
+
+const ACTOR_SYSTEM = "system"
+
+type actorKey struct{}
+
+// WithActor marks who is responsible for the changes made with ctx, e.g. in audit logs.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// IsPublicAddr reports whether addr is safe to call on behalf of a tenant: loopback,
+// link-local, private and unspecified addresses all reach this host or its network.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsPrivate() &&
+		!addr.IsUnspecified()
+}
+
+// ResolvePublicHost fails unless every address host resolves to is public.
+func ResolvePublicHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("address %s is not public", addr)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%s resolves to %s, which is not public", host, addr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"context"
+	"regexp"
+)
+
+const (
+	DEFAULT_TENANT_ID   = "default"
+	TENANT_METADATA_KEY = "x-tenant-id"
+)
+
+// Tenant ids end up in RabbitMQ routing keys, so dots and wildcards are not allowed.
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+type tenantKey struct{}
+
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns an empty string for background work that is not bound to a tenant.
+func TenantFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantKey{}).(string)
+	return tenantID
+}
+
+func IsValidTenantID(tenantID string) bool {
+	return tenantIDPattern.MatchString(tenantID)
+}
+
+// TenantOrDefault is used when writing rows, which always belong to some tenant.
+func TenantOrDefault(ctx context.Context) string {
+	if tenantID := TenantFromContext(ctx); tenantID != "" {
+		return tenantID
+	}
+	return DEFAULT_TENANT_ID
+}
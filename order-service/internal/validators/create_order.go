@@ -4,9 +4,11 @@ import (
 	"fmt"
 
 	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
 )
 
-func ValidateBatchCreateRequest(req *pb.BatchCreateRequest) ValidationErrors {
+// ValidateBatchCreateRequest relaxes checks on fields the product catalog fills in for the given mode.
+func ValidateBatchCreateRequest(req *pb.BatchCreateRequest, mode models.ProductValidationMode) ValidationErrors {
 	errs := make(ValidationErrors)
 
 	if len(req.Orders) == 0 {
@@ -16,7 +18,7 @@ func ValidateBatchCreateRequest(req *pb.BatchCreateRequest) ValidationErrors {
 
 	for i, o := range req.Orders {
 		prefix := fmt.Sprintf("orders[%d]", i)
-		orderErrs := validateOrder(o, prefix)
+		orderErrs := validateOrder(o, prefix, mode)
 		errs.Merge(orderErrs)
 	}
 
@@ -26,8 +28,9 @@ func ValidateBatchCreateRequest(req *pb.BatchCreateRequest) ValidationErrors {
 	return nil
 }
 
-func validateOrder(o *pb.Order, prefix string) ValidationErrors {
+func validateOrder(o *pb.Order, prefix string, mode models.ProductValidationMode) ValidationErrors {
 	errs := make(ValidationErrors)
+	pricesFromCatalog := mode == models.PRODUCT_VALIDATION_MODE_OVERRIDE
 
 	if o.CustomerId <= 0 {
 		errs[prefix+".customer_id"] = "must be greater than 0"
@@ -35,10 +38,10 @@ func validateOrder(o *pb.Order, prefix string) ValidationErrors {
 	if o.DeliveryAddress == "" {
 		errs[prefix+".delivery_address"] = "required"
 	}
-	if o.TotalPriceCents <= 0 {
+	if !pricesFromCatalog && o.TotalPriceCents <= 0 {
 		errs[prefix+".total_price_cents"] = "must be greater than 0"
 	}
-	if o.TotalPriceCurrency == "" {
+	if !pricesFromCatalog && o.TotalPriceCurrency == "" {
 		errs[prefix+".total_price_currency"] = "required"
 	}
 	if len(o.OrderItems) == 0 {
@@ -50,13 +53,17 @@ func validateOrder(o *pb.Order, prefix string) ValidationErrors {
 	currencies := map[string]struct{}{}
 	for j, it := range o.OrderItems {
 		iprefix := fmt.Sprintf("%s.order_items[%d]", prefix, j)
-		itemErrs := validateOrderItem(it, iprefix)
+		itemErrs := validateOrderItem(it, iprefix, mode)
 		errs.Merge(itemErrs)
 
 		sum += it.PriceCents * int64(it.Quantity)
 		currencies[it.PriceCurrency] = struct{}{}
 	}
 
+	if pricesFromCatalog {
+		return errs
+	}
+
 	if sum != o.TotalPriceCents {
 		errs[prefix+".total_price_cents"] = "must equal sum of items (priceCents * quantity)"
 	}
@@ -70,7 +77,7 @@ func validateOrder(o *pb.Order, prefix string) ValidationErrors {
 	return errs
 }
 
-func validateOrderItem(it *pb.OrderItem, prefix string) ValidationErrors {
+func validateOrderItem(it *pb.OrderItem, prefix string, mode models.ProductValidationMode) ValidationErrors {
 	errs := make(ValidationErrors)
 
 	if it.ProductId <= 0 {
@@ -79,15 +86,17 @@ func validateOrderItem(it *pb.OrderItem, prefix string) ValidationErrors {
 	if it.Quantity <= 0 {
 		errs[prefix+".quantity"] = "must be greater than 0"
 	}
-	if it.PriceCents <= 0 {
-		errs[prefix+".price_cents"] = "must be greater than 0"
+	if mode != models.PRODUCT_VALIDATION_MODE_OVERRIDE {
+		if it.PriceCents <= 0 {
+			errs[prefix+".price_cents"] = "must be greater than 0"
+		}
+		if it.PriceCurrency == "" {
+			errs[prefix+".price_currency"] = "required"
+		}
 	}
-	if it.ProductTitle == "" {
+	if mode == models.PRODUCT_VALIDATION_MODE_OFF && it.ProductTitle == "" {
 		errs[prefix+".product_title"] = "required"
 	}
-	if it.PriceCurrency == "" {
-		errs[prefix+".price_currency"] = "required"
-	}
 
 	return errs
 }
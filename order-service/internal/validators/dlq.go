@@ -0,0 +1,76 @@
+package validators
+
+import (
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+)
+
+func ValidateListDlqMessagesRequest(req *pb.ListDlqMessagesRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if req.Queue == "" {
+		errs["queue"] = "must not be empty"
+	}
+	if req.Limit < 0 {
+		errs["limit"] = "must be greater than or equal to 0"
+	}
+	if req.Limit > 1000 {
+		errs["limit"] = "must be less than or equal to 1000"
+	}
+	errs.Merge(validateDlqFilter(req.Filter))
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidateReplayDlqMessagesRequest(req *pb.ReplayDlqMessagesRequest) ValidationErrors {
+	errs := validateDlqSelection(req.Queue, req.Filter, req.Ids, req.All)
+
+	if req.RatePerSecond < 0 {
+		errs["rate_per_second"] = "must be greater than or equal to 0"
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidatePurgeDlqMessagesRequest(req *pb.PurgeDlqMessagesRequest) ValidationErrors {
+	errs := validateDlqSelection(req.Queue, req.Filter, req.Ids, req.All)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Replay and purge must name what they act on, so an empty request can't empty a DLQ.
+func validateDlqSelection(queue string, filter *pb.DlqMessageFilter, ids []string, all bool) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if queue == "" {
+		errs["queue"] = "must not be empty"
+	}
+	if len(ids) == 0 && !all {
+		errs["ids"] = "must not be empty unless all is set"
+	}
+	if len(ids) > 0 && all {
+		errs["all"] = "must not be set together with ids"
+	}
+	errs.Merge(validateDlqFilter(filter))
+
+	return errs
+}
+
+func validateDlqFilter(filter *pb.DlqMessageFilter) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if filter != nil && filter.DeadLetteredFrom != nil && filter.DeadLetteredTo != nil &&
+		filter.DeadLetteredFrom.AsTime().After(filter.DeadLetteredTo.AsTime()) {
+		errs["filter.dead_lettered_from"] = "must not be after filter.dead_lettered_to"
+	}
+
+	return errs
+}
@@ -0,0 +1,60 @@
+package validators
+
+import (
+	"fmt"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+)
+
+func ValidateStartPaymentRequest(req *pb.StartPaymentRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if req.OrderId <= 0 {
+		errs["order_id"] = "must be greater than 0"
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidateQueryPaymentsRequest(req *pb.QueryPaymentsRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	for i, id := range req.Ids {
+		if id <= 0 {
+			errs[fmt.Sprintf("ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+	for i, id := range req.OrderIds {
+		if id <= 0 {
+			errs[fmt.Sprintf("order_ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+	errs.Merge(validatePage(req.Page, req.PageSize))
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidatePaymentCallbackEvent(provider, providerPaymentID, event string) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if provider == "" {
+		errs["provider"] = "required"
+	}
+	if providerPaymentID == "" {
+		errs["provider_payment_id"] = "required"
+	}
+	if event == "" {
+		errs["event"] = "required"
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package validators
+
+import (
+	"fmt"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+)
+
+func ValidateUpsertProductsRequest(req *pb.UpsertProductsRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if len(req.Products) == 0 {
+		errs["products"] = "at least one is required"
+	}
+
+	seen := make(map[int64]struct{}, len(req.Products))
+	for i, p := range req.Products {
+		prefix := fmt.Sprintf("products[%d]", i)
+
+		if p.Id <= 0 {
+			errs[prefix+".id"] = "must be greater than 0"
+		} else if _, ok := seen[p.Id]; ok {
+			errs[prefix+".id"] = "must be unique"
+		}
+		seen[p.Id] = struct{}{}
+
+		if p.Title == "" {
+			errs[prefix+".title"] = "required"
+		}
+		if p.PriceCents <= 0 {
+			errs[prefix+".price_cents"] = "must be greater than 0"
+		}
+		if p.PriceCurrency == "" {
+			errs[prefix+".price_currency"] = "required"
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidateQueryProductsRequest(req *pb.QueryProductsRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	for i, id := range req.Ids {
+		if id <= 0 {
+			errs[fmt.Sprintf("ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+	errs.Merge(validatePage(req.Page, req.PageSize))
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidateDeleteProductsRequest(req *pb.DeleteProductsRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if len(req.Ids) == 0 {
+		errs["ids"] = "at least one is required"
+	}
+	for i, id := range req.Ids {
+		if id <= 0 {
+			errs[fmt.Sprintf("ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package validators
+
+import (
+	"fmt"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+)
+
+func ValidateAdjustStockRequest(req *pb.AdjustStockRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if len(req.Adjustments) == 0 {
+		errs["adjustments"] = "must not be empty"
+	}
+	for i, a := range req.Adjustments {
+		if a.ProductId <= 0 {
+			errs[fmt.Sprintf("adjustments[%d].product_id", i)] = "must be greater than 0"
+		}
+		if a.WarehouseId < 0 {
+			errs[fmt.Sprintf("adjustments[%d].warehouse_id", i)] = "must be greater than or equal to 0"
+		}
+		if a.Delta == 0 {
+			errs[fmt.Sprintf("adjustments[%d].delta", i)] = "must not be 0"
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidateQueryStockRequest(req *pb.QueryStockRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	for i, id := range req.ProductIds {
+		if id <= 0 {
+			errs[fmt.Sprintf("product_ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+	for i, id := range req.WarehouseIds {
+		if id <= 0 {
+			errs[fmt.Sprintf("warehouse_ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+	errs.Merge(validatePage(req.Page, req.PageSize))
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
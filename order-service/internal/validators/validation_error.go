@@ -33,3 +33,18 @@ func (v ValidationErrors) ToStatus() error {
 	}
 	return stWithDetails.Err()
 }
+
+// ToPermissionDeniedStatus reports the offending fields of a request the caller may not perform.
+func (v ValidationErrors) ToPermissionDeniedStatus() error {
+	st := status.New(codes.PermissionDenied, "permission denied")
+
+	stWithDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "ACCESS_DENIED",
+		Domain:   "order-service",
+		Metadata: v,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
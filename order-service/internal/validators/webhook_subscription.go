@@ -0,0 +1,139 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	pb "github.com/ZaiiiRan/backend_labs/order-service/gen/go/order-service/v1"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/bll/models"
+	"github.com/ZaiiiRan/backend_labs/order-service/internal/utils"
+)
+
+const minWebhookSecretLength = 16
+
+func ValidateCreateWebhookSubscriptionRequest(ctx context.Context, req *pb.CreateWebhookSubscriptionRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	errs.Merge(validateWebhookTarget(ctx, req.Url, req.EventTypes, req.CustomerIds))
+
+	if req.Secret != "" && len(req.Secret) < minWebhookSecretLength {
+		errs["secret"] = fmt.Sprintf("must be at least %d characters long", minWebhookSecretLength)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidateUpdateWebhookSubscriptionRequest(ctx context.Context, req *pb.UpdateWebhookSubscriptionRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if req.Id <= 0 {
+		errs["id"] = "must be greater than 0"
+	}
+	errs.Merge(validateWebhookTarget(ctx, req.Url, req.EventTypes, req.CustomerIds))
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidateQueryWebhookSubscriptionsRequest(req *pb.QueryWebhookSubscriptionsRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	errs.Merge(validatePage(req.Page, req.PageSize))
+	for i, id := range req.Ids {
+		if id <= 0 {
+			errs[fmt.Sprintf("ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidateDeleteWebhookSubscriptionsRequest(req *pb.DeleteWebhookSubscriptionsRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if len(req.Ids) == 0 {
+		errs["ids"] = "at least one is required"
+	}
+	for i, id := range req.Ids {
+		if id <= 0 {
+			errs[fmt.Sprintf("ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func ValidateQueryWebhookDeliveriesRequest(req *pb.QueryWebhookDeliveriesRequest) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	errs.Merge(validatePage(req.Page, req.PageSize))
+	for i, id := range req.SubscriptionIds {
+		if id <= 0 {
+			errs[fmt.Sprintf("subscription_ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateWebhookTarget resolves the url's host, so tenants can't point deliveries at this
+// host or its network. The dispatcher checks the address it connects to again.
+func validateWebhookTarget(ctx context.Context, rawURL string, eventTypes []string, customerIDs []int64) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if rawURL == "" {
+		errs["url"] = "required"
+	} else if u, err := url.Parse(rawURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs["url"] = "must be an absolute http or https url"
+	} else if err := utils.ResolvePublicHost(ctx, u.Hostname()); err != nil {
+		errs["url"] = "must resolve to public addresses only"
+	}
+
+	if len(eventTypes) == 0 {
+		errs["event_types"] = "at least one is required"
+	}
+	for i, t := range eventTypes {
+		if models.StringToWebhookEventType(t) == "" {
+			errs[fmt.Sprintf("event_types[%d]", i)] = "unknown event type"
+		}
+	}
+
+	for i, id := range customerIDs {
+		if id <= 0 {
+			errs[fmt.Sprintf("customer_ids[%d]", i)] = "must be greater than 0"
+		}
+	}
+
+	return errs
+}
+
+func validatePage(page, pageSize int32) ValidationErrors {
+	errs := make(ValidationErrors)
+
+	if page < 1 {
+		errs["page"] = "must be greater than or equal to 1"
+	}
+	if pageSize < 1 {
+		errs["page_size"] = "must be greater than or equal to 1"
+	}
+	if pageSize > 100 {
+		errs["page_size"] = "must be less than or equal to 100"
+	}
+
+	return errs
+}
@@ -0,0 +1,62 @@
+-- +goose Up
+create table if not exists webhook_subscriptions (
+    id bigserial not null primary key,
+    url text not null,
+    event_types text[] not null,
+    customer_ids bigint[] not null default '{}',
+    secret text not null,
+    is_active boolean not null default true,
+    consecutive_failures integer not null default 0,
+    disabled_at timestamp with time zone null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null
+);
+
+create index if not exists idx_webhook_subscription_event_types on webhook_subscriptions using gin (event_types);
+
+create table if not exists webhook_deliveries (
+    id bigserial not null primary key,
+    subscription_id bigint not null references webhook_subscriptions (id) on delete cascade,
+    event_type text not null,
+    event_id text not null,
+    attempt integer not null,
+    status_code integer not null,
+    success boolean not null,
+    error text not null,
+    duration_ms bigint not null,
+    created_at timestamp with time zone not null
+);
+
+create index if not exists idx_webhook_delivery_subscription_id on webhook_deliveries (subscription_id, created_at desc);
+
+create type v1_webhook_subscription as (
+    id bigint,
+    url text,
+    event_types text[],
+    customer_ids bigint[],
+    secret text,
+    is_active boolean,
+    consecutive_failures integer,
+    disabled_at timestamp with time zone,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+
+create type v1_webhook_delivery as (
+    id bigint,
+    subscription_id bigint,
+    event_type text,
+    event_id text,
+    attempt integer,
+    status_code integer,
+    success boolean,
+    error text,
+    duration_ms bigint,
+    created_at timestamp with time zone
+);
+
+-- +goose Down
+drop table if exists webhook_deliveries;
+drop table if exists webhook_subscriptions;
+drop type if exists v1_webhook_delivery;
+drop type if exists v1_webhook_subscription;
@@ -0,0 +1,55 @@
+-- +goose Up
+create table if not exists stocks (
+    warehouse_id bigint not null,
+    product_id bigint not null,
+    quantity integer not null,
+    reserved integer not null default 0,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    primary key (warehouse_id, product_id),
+    constraint chk_stock_quantity check (quantity >= 0),
+    constraint chk_stock_reserved check (reserved >= 0 and reserved <= quantity)
+);
+
+create index if not exists idx_stock_product_id on stocks (product_id);
+
+create table if not exists stock_reservations (
+    id bigserial not null primary key,
+    order_id bigint not null,
+    order_item_id bigint not null,
+    warehouse_id bigint not null,
+    product_id bigint not null,
+    quantity integer not null,
+    status text not null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null
+);
+
+create index if not exists idx_stock_reservation_order_id on stock_reservations (order_id);
+
+create type v1_stock as (
+    warehouse_id bigint,
+    product_id bigint,
+    quantity integer,
+    reserved integer,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+
+create type v1_stock_reservation as (
+    id bigint,
+    order_id bigint,
+    order_item_id bigint,
+    warehouse_id bigint,
+    product_id bigint,
+    quantity integer,
+    status text,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+
+-- +goose Down
+drop table if exists stock_reservations;
+drop table if exists stocks;
+drop type if exists v1_stock_reservation;
+drop type if exists v1_stock;
@@ -0,0 +1,25 @@
+-- +goose Up
+create table if not exists products (
+    id bigint not null primary key,
+    title text not null,
+    url text not null default '',
+    price_cents bigint not null,
+    price_currency text not null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    constraint chk_product_price check (price_cents > 0)
+);
+
+create type v1_product as (
+    id bigint,
+    title text,
+    url text,
+    price_cents bigint,
+    price_currency text,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+
+-- +goose Down
+drop table if exists products;
+drop type if exists v1_product;
@@ -0,0 +1,31 @@
+-- +goose Up
+create table if not exists sagas (
+    id bigserial not null primary key,
+    order_id bigint not null,
+    status text not null,
+    steps text[] not null,
+    current_step integer not null default 0,
+    data jsonb not null default '{}',
+    error text not null default '',
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    constraint uq_saga_order_id unique (order_id)
+);
+
+create index if not exists idx_saga_status on sagas (status);
+
+create type v1_saga as (
+    id bigint,
+    order_id bigint,
+    status text,
+    steps text[],
+    current_step integer,
+    data jsonb,
+    error text,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+
+-- +goose Down
+drop table if exists sagas;
+drop type if exists v1_saga;
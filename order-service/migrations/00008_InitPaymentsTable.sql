@@ -0,0 +1,33 @@
+-- +goose Up
+create table if not exists payments (
+    id bigserial not null primary key,
+    order_id bigint not null,
+    provider text not null,
+    provider_payment_id text not null default '',
+    amount_cents bigint not null,
+    currency text not null,
+    status text not null,
+    failure_reason text not null default '',
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null
+);
+
+create index if not exists idx_payment_order_id on payments (order_id);
+create index if not exists idx_payment_provider_payment_id on payments (provider, provider_payment_id);
+
+create type v1_payment as (
+    id bigint,
+    order_id bigint,
+    provider text,
+    provider_payment_id text,
+    amount_cents bigint,
+    currency text,
+    status text,
+    failure_reason text,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+
+-- +goose Down
+drop table if exists payments;
+drop type if exists v1_payment;
@@ -0,0 +1,7 @@
+-- +goose Up
+alter table audit_log_order add column actor text not null default '';
+alter type v1_audit_log_order add attribute actor text;
+
+-- +goose Down
+alter table audit_log_order drop column actor;
+alter type v1_audit_log_order drop attribute actor;
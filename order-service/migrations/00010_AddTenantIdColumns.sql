@@ -0,0 +1,25 @@
+-- +goose Up
+alter table orders add column tenant_id text not null default 'default';
+alter type v1_order add attribute tenant_id text;
+
+alter table order_items add column tenant_id text not null default 'default';
+alter type v1_order_item add attribute tenant_id text;
+
+alter table audit_log_order add column tenant_id text not null default 'default';
+alter type v1_audit_log_order add attribute tenant_id text;
+
+create index if not exists idx_order_tenant_id_customer_id on orders (tenant_id, customer_id);
+create index if not exists idx_order_item_tenant_id_order_id on order_items (tenant_id, order_id);
+
+-- +goose Down
+drop index if exists idx_order_item_tenant_id_order_id;
+drop index if exists idx_order_tenant_id_customer_id;
+
+alter table audit_log_order drop column tenant_id;
+alter type v1_audit_log_order drop attribute tenant_id;
+
+alter table order_items drop column tenant_id;
+alter type v1_order_item drop attribute tenant_id;
+
+alter table orders drop column tenant_id;
+alter type v1_order drop attribute tenant_id;
@@ -0,0 +1,25 @@
+-- +goose Up
+alter table webhook_subscriptions add column tenant_id text not null default 'default';
+alter type v1_webhook_subscription add attribute tenant_id text;
+
+alter table webhook_deliveries add column tenant_id text not null default 'default';
+alter type v1_webhook_delivery add attribute tenant_id text;
+
+alter table payments add column tenant_id text not null default 'default';
+alter type v1_payment add attribute tenant_id text;
+
+create index if not exists idx_webhook_subscription_tenant_id on webhook_subscriptions (tenant_id);
+create index if not exists idx_payment_tenant_id_order_id on payments (tenant_id, order_id);
+
+-- +goose Down
+drop index if exists idx_payment_tenant_id_order_id;
+drop index if exists idx_webhook_subscription_tenant_id;
+
+alter table payments drop column tenant_id;
+alter type v1_payment drop attribute tenant_id;
+
+alter table webhook_deliveries drop column tenant_id;
+alter type v1_webhook_delivery drop attribute tenant_id;
+
+alter table webhook_subscriptions drop column tenant_id;
+alter type v1_webhook_subscription drop attribute tenant_id;
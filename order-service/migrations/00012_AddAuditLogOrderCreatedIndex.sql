@@ -0,0 +1,9 @@
+-- +goose Up
+-- Backs the check that status entries only follow a recorded created entry. order_id
+-- leads so the index also serves the unscoped lookup without a tenant.
+create index if not exists idx_audit_log_order_created_order_id_tenant_id
+    on audit_log_order (order_id, tenant_id)
+    where order_status = 'created';
+
+-- +goose Down
+drop index if exists idx_audit_log_order_created_order_id_tenant_id;
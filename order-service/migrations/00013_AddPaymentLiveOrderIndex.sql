@@ -0,0 +1,9 @@
+-- +goose Up
+-- An order has at most one payment that can still be authorized or captured, so
+-- concurrent authorizations of the same order can't both charge it.
+create unique index if not exists idx_payment_live_order_id
+    on payments (order_id)
+    where status in ('pending', 'authorized', 'captured');
+
+-- +goose Down
+drop index if exists idx_payment_live_order_id;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Backs the lookup of events that already reached a subscription when a message is retried.
+create index if not exists idx_webhook_delivery_succeeded_event_id
+    on webhook_deliveries (event_id)
+    where success;
+
+-- +goose Down
+drop index if exists idx_webhook_delivery_succeeded_event_id;
@@ -11,8 +11,9 @@ type OrderCreatedMessage struct {
 	CreatedAt       time.Time                 `json:"created_at"`
 	UpdatedAt       time.Time                 `json:"updated_at"`
 	OrderItems      []OrderCreatedItemMessage `json:"order_items"`
+	TenantId        string                    `json:"tenant_id,omitempty"`
 }
 
 func (m *OrderCreatedMessage) RoutingKey() string {
-	return "order.created"
+	return TenantRoutingKey(ORDER_CREATED_EVENT, m.TenantId)
 }
@@ -0,0 +1,19 @@
+package messages
+
+import "encoding/json"
+
+// OrderID returns the order an event is about: "id" of order.created and "order_id" of
+// order.status.changed messages.
+func OrderID(body []byte) (int64, bool) {
+	var m struct {
+		Id      int64 `json:"id"`
+		OrderId int64 `json:"order_id"`
+	}
+	if err := json.Unmarshal(body, &m); err != nil {
+		return 0, false
+	}
+	if m.OrderId != 0 {
+		return m.OrderId, true
+	}
+	return m.Id, m.Id != 0
+}
@@ -0,0 +1,27 @@
+package messages
+
+import "testing"
+
+func TestOrderID(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		want   int64
+		wantOk bool
+	}{
+		{"order.created", `{"id":5,"customer_id":42}`, 5, true},
+		{"order.status.changed", `{"order_id":7,"order_status":"completed"}`, 7, true},
+		{"order_id wins over id", `{"id":5,"order_id":7}`, 7, true},
+		{"no id", `{"customer_id":42}`, 0, false},
+		{"zero id", `{"id":0}`, 0, false},
+		{"id of wrong type", `{"id":"5"}`, 0, false},
+		{"malformed", `{"id":`, 0, false},
+		{"empty", ``, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := OrderID([]byte(tt.body))
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("%s: OrderID = %d, %v; want %d, %v", tt.name, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
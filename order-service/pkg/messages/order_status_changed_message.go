@@ -1,11 +1,16 @@
 package messages
 
+import "time"
+
 type OrderStatusChangedMessage struct {
-	OrderId     int64  `json:"order_id"`
-	CustomerId  int64  `json:"customer_id"`
-	OrderStatus string `json:"order_status"`
+	OrderId     int64     `json:"order_id"`
+	CustomerId  int64     `json:"customer_id"`
+	OrderStatus string    `json:"order_status"`
+	Actor       string    `json:"actor,omitempty"`
+	TenantId    string    `json:"tenant_id,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 func (m *OrderStatusChangedMessage) RoutingKey() string {
-	return "order.status.changed"
+	return TenantRoutingKey(ORDER_STATUS_CHANGED_EVENT, m.TenantId)
 }
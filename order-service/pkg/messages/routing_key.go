@@ -0,0 +1,33 @@
+package messages
+
+import "strings"
+
+const (
+	ORDER_CREATED_EVENT        = "order.created"
+	ORDER_STATUS_CHANGED_EVENT = "order.status.changed"
+)
+
+var events = []string{ORDER_CREATED_EVENT, ORDER_STATUS_CHANGED_EVENT}
+
+// TenantRoutingKey appends the tenant to the event name, so queues can bind either to
+// "<event>.#" for every tenant or to "<event>.<tenant>" for a single one.
+func TenantRoutingKey(event, tenantId string) string {
+	if tenantId == "" {
+		return event
+	}
+	return event + "." + tenantId
+}
+
+// ParseRoutingKey splits a routing key produced by TenantRoutingKey. Unknown keys are
+// returned as is with an empty tenant.
+func ParseRoutingKey(key string) (event, tenantId string) {
+	for _, e := range events {
+		if key == e {
+			return e, ""
+		}
+		if strings.HasPrefix(key, e+".") {
+			return e, strings.TrimPrefix(key, e+".")
+		}
+	}
+	return key, ""
+}